@@ -7,6 +7,14 @@ import (
 	"github.com/joho/godotenv"
 )
 
+// @title Personal Finance Management API
+// @version 1.0
+// @description Cards, accounts, transactions and budgeting for personal finances.
+// @BasePath /
+// @securityDefinitions.apikey Bearer
+// @in header
+// @name Authorization
+// @description Access token or API key as "Bearer <token>"
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
@@ -17,6 +25,19 @@ func main() {
 	configPath := flag.String("config", "", "Path to configuration file")
 	flag.Parse()
 
+	// Run a subcommand instead of the server when one is given
+	if flag.NArg() > 0 {
+		switch flag.Arg(0) {
+		case "reconcile":
+			if err := runReconcile(*configPath, flag.Args()[1:]); err != nil {
+				log.Fatalf("Reconcile failed: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown command: %s", flag.Arg(0))
+		}
+		return
+	}
+
 	// Initialize application with Wire
 	server, err := InitializeApplication(*configPath)
 	if err != nil {
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// runReconcile implements the "reconcile" subcommand
+func runReconcile(configPath string, args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	userFlag := fs.String("user", "", "Only reconcile the user with this ID")
+	fix := fs.Bool("fix", false, "Record adjustment transactions for cards that have drifted")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var userID *uuid.UUID
+	if *userFlag != "" {
+		parsed, err := uuid.Parse(*userFlag)
+		if err != nil {
+			return fmt.Errorf("invalid user ID: %w", err)
+		}
+		userID = &parsed
+	}
+
+	cmd, err := InitializeReconcileCommand(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	defer cmd.Close()
+
+	return cmd.Run(context.Background(), userID, *fix, os.Stdout)
+}
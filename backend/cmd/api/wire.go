@@ -16,6 +16,8 @@ func InitializeApplication(configPath string) (*provider.Server, error) {
 		provider.ProviderLogger,
 		provider.ProviderDatabase,
 		provider.ProvideJWTManager,
+		provider.ProvideEventBus,
+		provider.ProvideEventPublisher,
 
 		// Repositories
 		provider.ProvideUserRepository,
@@ -23,6 +25,18 @@ func InitializeApplication(configPath string) (*provider.Server, error) {
 		provider.ProvideTransactionRepository,
 		provider.ProvideCategoryRepository,
 		provider.ProvideRefreshTokenRepository,
+		provider.ProvideNetWorthRepository,
+		provider.ProvideRuleRepository,
+		provider.ProvidePayeeRepository,
+		provider.ProvideDuplicateRepository,
+		provider.ProvideBillRepository,
+		provider.ProvideNotificationRepository,
+		provider.ProvideWebhookRepository,
+		provider.ProvideAPIKeyRepository,
+		provider.ProvideHouseholdRepository,
+		provider.ProvideSharedExpenseRepository,
+		provider.ProvideAuditLogRepository,
+		provider.ProvideIdempotencyKeyRepository,
 
 		// Services
 		provider.ProvideAuthService,
@@ -30,6 +44,25 @@ func InitializeApplication(configPath string) (*provider.Server, error) {
 		provider.ProvideCardService,
 		provider.ProvideTransactionService,
 		provider.ProvideCategoryService,
+		provider.ProvideNetWorthService,
+		provider.ProvideReconciliationService,
+		provider.ProvideRuleService,
+		provider.ProvideSuggestionService,
+		provider.ProvidePayeeService,
+		provider.ProvideDuplicateService,
+		provider.ProvideNotifier,
+		provider.ProvideNotificationService,
+		provider.ProvideBillService,
+		provider.ProvideWebhookService,
+		provider.ProvideAPIKeyService,
+		provider.ProvideAdminService,
+		provider.ProvideAccessService,
+		provider.ProvideHouseholdService,
+		provider.ProvideSplitService,
+		provider.ProvideAuditRecorder,
+		provider.ProvideAuditService,
+		provider.ProvideTrashService,
+		provider.ProvideIdempotencyService,
 
 		// Handlers
 		provider.ProvideAuthHandler,
@@ -37,17 +70,59 @@ func InitializeApplication(configPath string) (*provider.Server, error) {
 		provider.ProvideCardHandler,
 		provider.ProvideTransactionHandler,
 		provider.ProvideCategoryHandler,
+		provider.ProvideNetWorthHandler,
+		provider.ProvideReconciliationHandler,
+		provider.ProvideRuleHandler,
+		provider.ProvideSuggestionHandler,
+		provider.ProvidePayeeHandler,
+		provider.ProvideDuplicateHandler,
+		provider.ProvideBillHandler,
+		provider.ProvideNotificationHandler,
+		provider.ProvideWebhookHandler,
+		provider.ProvideAPIKeyHandler,
+		provider.ProvideAdminHandler,
+		provider.ProvideHouseholdHandler,
+		provider.ProvideSplitHandler,
+		provider.ProvideAuditHandler,
+		provider.ProvideTrashHandler,
+		provider.ProvideGraphQLSchema,
+		provider.ProvideGraphQLHandler,
 
 		// Middleware
 		provider.ProvideAuthMiddleware,
+		provider.ProvideIdempotencyMiddleware,
 		provider.ProvideLoggerMiddleware,
 		provider.ProvideCORSMiddleware,
 		provider.ProvideRecoveryMiddleware,
+		provider.ProvideErrorMiddleware,
+
+		// Background jobs
+		provider.ProvideScheduler,
 
 		// Router & Server
 		provider.ProvideRouter,
+		provider.ProvideGRPCServer,
 		provider.ProvideServer,
 	)
 
 	return &provider.Server{}, nil
 }
+
+func InitializeReconcileCommand(configPath string) (*provider.ReconcileCommand, error) {
+	wire.Build(
+		provider.ProvideConfig,
+		provider.ProviderLogger,
+		provider.ProviderDatabase,
+
+		provider.ProvideUserRepository,
+		provider.ProvideCardRepository,
+		provider.ProvideTransactionRepository,
+		provider.ProvideAuditLogRepository,
+
+		provider.ProvideAuditRecorder,
+		provider.ProvideReconciliationService,
+		provider.ProvideReconcileCommand,
+	)
+
+	return &provider.ReconcileCommand{}, nil
+}
@@ -28,24 +28,104 @@ func InitializeApplication(configPath string) (*provider.Server, error) {
 	userRepository := provider.ProvideUserRepository(database)
 	refreshTokenRepository := provider.ProvideRefreshTokenRepository(database)
 	jwtManager := provider.ProvideJWTManager(config)
-	service := provider.ProvideAuthService(userRepository, refreshTokenRepository, jwtManager, logger)
-	authHandler := provider.ProvideAuthHandler(service, logger)
-	userService := provider.ProvideUserService(userRepository)
+	bus := provider.ProvideEventBus(logger)
+	notificationRepository := provider.ProvideNotificationRepository(database)
+	notifier := provider.ProvideNotifier(notificationRepository)
+	service := provider.ProvideNotificationService(notificationRepository, notifier)
+	webhookRepository := provider.ProvideWebhookRepository(database)
+	webhookService := provider.ProvideWebhookService(webhookRepository, config, logger)
+	publisher := provider.ProvideEventPublisher(bus, service, webhookService)
+	auditLogRepository := provider.ProvideAuditLogRepository(database)
+	recorder := provider.ProvideAuditRecorder(auditLogRepository, logger)
+	authService := provider.ProvideAuthService(userRepository, refreshTokenRepository, jwtManager, publisher, recorder, logger)
+	authHandler := provider.ProvideAuthHandler(authService, logger)
+	userService := provider.ProvideUserService(userRepository, recorder)
 	userHandler := provider.ProvideUserHandler(userService)
 	cardRepository := provider.ProvideCardRepository(database)
-	cardService := provider.ProvideCardService(cardRepository)
-	cardHandler := provider.ProvideCardHandler(cardService)
+	householdRepository := provider.ProvideHouseholdRepository(database)
+	accessService := provider.ProvideAccessService(householdRepository)
 	transactionRepository := provider.ProvideTransactionRepository(database)
-	transactionService := provider.ProvideTransactionService(transactionRepository, cardRepository)
-	transactionHandler := provider.ProvideTransactionHandler(transactionService)
 	categoryRepository := provider.ProvideCategoryRepository(database)
+	suggestionService := provider.ProvideSuggestionService(transactionRepository, categoryRepository, config)
+	cardService := provider.ProvideCardService(cardRepository, accessService, suggestionService, publisher, recorder)
+	cardHandler := provider.ProvideCardHandler(cardService)
+	ruleRepository := provider.ProvideRuleRepository(database)
+	ruleService := provider.ProvideRuleService(ruleRepository, transactionRepository, cardRepository, categoryRepository, suggestionService, publisher, recorder)
+	payeeRepository := provider.ProvidePayeeRepository(database)
+	payeeService := provider.ProvidePayeeService(payeeRepository, transactionRepository, recorder)
+	duplicateRepository := provider.ProvideDuplicateRepository(database)
+	duplicateService := provider.ProvideDuplicateService(duplicateRepository, transactionRepository, suggestionService, publisher, recorder, config)
+	transactionService := provider.ProvideTransactionService(transactionRepository, cardRepository, categoryRepository, accessService, ruleService, payeeService, suggestionService, duplicateService, publisher, recorder)
+	transactionHandler := provider.ProvideTransactionHandler(transactionService)
 	categoryService := provider.ProvideCategoryService(categoryRepository)
 	categoryHandler := provider.ProvideCategoryHandler(categoryService)
-	authMiddleware := provider.ProvideAuthMiddleware(jwtManager, logger)
+	netWorthRepository := provider.ProvideNetWorthRepository(database)
+	networthService := provider.ProvideNetWorthService(netWorthRepository, cardRepository, transactionRepository, userRepository, logger)
+	netWorthHandler := provider.ProvideNetWorthHandler(networthService)
+	reconciliationService := provider.ProvideReconciliationService(cardRepository, transactionRepository, recorder)
+	reconciliationHandler := provider.ProvideReconciliationHandler(reconciliationService)
+	ruleHandler := provider.ProvideRuleHandler(ruleService)
+	suggestionHandler := provider.ProvideSuggestionHandler(suggestionService)
+	payeeHandler := provider.ProvidePayeeHandler(payeeService)
+	duplicateHandler := provider.ProvideDuplicateHandler(duplicateService)
+	billRepository := provider.ProvideBillRepository(database)
+	billService := provider.ProvideBillService(billRepository, cardRepository, categoryRepository, transactionRepository, transactionService, notifier, logger)
+	billHandler := provider.ProvideBillHandler(billService)
+	notificationHandler := provider.ProvideNotificationHandler(service)
+	webhookHandler := provider.ProvideWebhookHandler(webhookService)
+	apiKeyRepository := provider.ProvideAPIKeyRepository(database)
+	apikeyService := provider.ProvideAPIKeyService(apiKeyRepository, userRepository, logger)
+	apiKeyHandler := provider.ProvideAPIKeyHandler(apikeyService)
+	adminService := provider.ProvideAdminService(userRepository, refreshTokenRepository, categoryRepository, recorder, logger)
+	adminHandler := provider.ProvideAdminHandler(adminService)
+	householdService := provider.ProvideHouseholdService(householdRepository, cardRepository, userRepository, accessService, notifier, recorder, logger)
+	householdHandler := provider.ProvideHouseholdHandler(householdService)
+	sharedExpenseRepository := provider.ProvideSharedExpenseRepository(database)
+	splitService := provider.ProvideSplitService(sharedExpenseRepository, userRepository, transactionService, logger)
+	splitHandler := provider.ProvideSplitHandler(splitService)
+	auditService := provider.ProvideAuditService(auditLogRepository)
+	auditHandler := provider.ProvideAuditHandler(auditService)
+	trashService := provider.ProvideTrashService(cardRepository, transactionRepository, accessService, suggestionService, publisher, recorder, config, logger)
+	trashHandler := provider.ProvideTrashHandler(trashService)
+	schema, err := provider.ProvideGraphQLSchema(config, userService, cardService, transactionService, categoryService, logger)
+	if err != nil {
+		return nil, err
+	}
+	graphQLHandler := provider.ProvideGraphQLHandler(schema)
+	authMiddleware := provider.ProvideAuthMiddleware(jwtManager, apikeyService, userRepository, logger)
+	idempotencyKeyRepository := provider.ProvideIdempotencyKeyRepository(database)
+	idempotencyService := provider.ProvideIdempotencyService(idempotencyKeyRepository, config, logger)
+	idempotencyMiddleware := provider.ProvideIdempotencyMiddleware(idempotencyService, logger)
 	loggerMiddleware := provider.ProvideLoggerMiddleware(logger)
 	corsMiddleware := provider.ProvideCORSMiddleware(config)
 	recoveryMiddleware := provider.ProvideRecoveryMiddleware(logger)
-	router := provider.ProvideRouter(config, authHandler, userHandler, cardHandler, transactionHandler, categoryHandler, authMiddleware, loggerMiddleware, corsMiddleware, recoveryMiddleware)
-	server := provider.ProvideServer(config, router, database, logger)
-	return server, nil
+	errorMiddleware := provider.ProvideErrorMiddleware(logger)
+	router := provider.ProvideRouter(config, authHandler, userHandler, cardHandler, transactionHandler, categoryHandler, netWorthHandler, reconciliationHandler, ruleHandler, suggestionHandler, payeeHandler, duplicateHandler, billHandler, notificationHandler, webhookHandler, apiKeyHandler, adminHandler, householdHandler, splitHandler, auditHandler, trashHandler, graphQLHandler, authMiddleware, idempotencyMiddleware, loggerMiddleware, corsMiddleware, recoveryMiddleware, errorMiddleware)
+	server := provider.ProvideGRPCServer(jwtManager, userRepository, authService, cardService, transactionService, categoryService, logger)
+	scheduler := provider.ProvideScheduler(config, logger, networthService, billService, webhookService, trashService, idempotencyService)
+	providerServer := provider.ProvideServer(config, router, server, database, scheduler, logger)
+	return providerServer, nil
+}
+
+func InitializeReconcileCommand(configPath string) (*provider.ReconcileCommand, error) {
+	config, err := provider.ProvideConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	logger, err := provider.ProviderLogger(config)
+	if err != nil {
+		return nil, err
+	}
+	database, err := provider.ProviderDatabase(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	cardRepository := provider.ProvideCardRepository(database)
+	transactionRepository := provider.ProvideTransactionRepository(database)
+	auditLogRepository := provider.ProvideAuditLogRepository(database)
+	recorder := provider.ProvideAuditRecorder(auditLogRepository, logger)
+	service := provider.ProvideReconciliationService(cardRepository, transactionRepository, recorder)
+	userRepository := provider.ProvideUserRepository(database)
+	reconcileCommand := provider.ProvideReconcileCommand(service, userRepository, database)
+	return reconcileCommand, nil
 }
@@ -0,0 +1,63 @@
+// Command openapi generates the OpenAPI 3 document served at
+// /api/docs/openapi.json from the swag annotations on the handlers.
+//
+//	go generate ./internal/docs
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/swaggo/swag"
+)
+
+func main() {
+	root := flag.String("root", ".", "Path to the backend module")
+	output := flag.String("o", "internal/docs/openapi.json", "Output file, relative to -root")
+	flag.Parse()
+
+	if err := generate(*root, *output); err != nil {
+		log.Fatalf("Failed to generate OpenAPI document: %v", err)
+	}
+}
+
+func generate(root, output string) error {
+	// swag resolves its search directories against the working directory
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+
+	// swag only speaks Swagger 2.0; parse with it and convert the result
+	parser := swag.New(
+		swag.ParseUsingGoList(true),
+		swag.SetDebugger(log.New(io.Discard, "", 0)),
+	)
+	if err := parser.ParseAPIMultiSearchDir([]string{"./cmd/api", "./internal"}, "main.go", 100); err != nil {
+		return fmt.Errorf("failed to parse annotations: %w", err)
+	}
+
+	v2, err := json.Marshal(parser.GetSwagger())
+	if err != nil {
+		return err
+	}
+	var doc openapi2.T
+	if err := json.Unmarshal(v2, &doc); err != nil {
+		return err
+	}
+	v3, err := openapi2conv.ToV3(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to convert to OpenAPI 3: %w", err)
+	}
+
+	body, err := json.MarshalIndent(v3, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, append(body, '\n'), 0o644)
+}
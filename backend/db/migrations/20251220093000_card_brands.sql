@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS card_type_valid;
+ALTER TABLE cards ADD CONSTRAINT card_type_valid
+    CHECK (card_type IN ('Visa', 'MasterCard', 'Amex', 'JCB', 'UnionPay', 'Discover'));
+
+-- +goose Down
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS card_type_valid;
+ALTER TABLE cards ADD CONSTRAINT card_type_valid CHECK (card_type IN ('Visa', 'MasterCard'));
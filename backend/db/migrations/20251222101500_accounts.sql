@@ -0,0 +1,51 @@
+-- +goose Up
+ALTER TABLE cards ADD COLUMN account_type VARCHAR(20) NOT NULL DEFAULT 'Card';
+ALTER TABLE cards ADD COLUMN credit_limit BIGINT;
+
+ALTER TABLE cards ALTER COLUMN card_number DROP NOT NULL;
+ALTER TABLE cards ALTER COLUMN card_number_last4 DROP NOT NULL;
+ALTER TABLE cards ALTER COLUMN holder_name DROP NOT NULL;
+ALTER TABLE cards ALTER COLUMN expiry_date DROP NOT NULL;
+ALTER TABLE cards ALTER COLUMN card_type DROP NOT NULL;
+ALTER TABLE cards ALTER COLUMN card_type DROP DEFAULT;
+
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS card_type_valid;
+ALTER TABLE cards ADD CONSTRAINT card_type_valid
+    CHECK (card_type IS NULL OR card_type = '' OR card_type IN ('Visa', 'MasterCard', 'Amex', 'JCB', 'UnionPay', 'Discover'));
+ALTER TABLE cards ADD CONSTRAINT account_type_valid
+    CHECK (account_type IN ('Card', 'Cash', 'Checking', 'Savings', 'Credit', 'Loan'));
+ALTER TABLE cards ADD CONSTRAINT card_details_required
+    CHECK (account_type <> 'Card' OR COALESCE(card_number, '') <> '');
+ALTER TABLE cards ADD CONSTRAINT credit_limit_non_negative
+    CHECK (credit_limit IS NULL OR credit_limit >= 0);
+
+CREATE INDEX idx_cards_account_type ON cards(account_type);
+
+-- +goose Down
+-- Other account types have no card details to fall back to, so refuse rather
+-- than delete them
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM cards WHERE account_type <> 'Card') THEN
+        RAISE EXCEPTION 'cannot roll back accounts: non-card accounts exist';
+    END IF;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_cards_account_type;
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS credit_limit_non_negative;
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS card_details_required;
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS account_type_valid;
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS card_type_valid;
+ALTER TABLE cards ADD CONSTRAINT card_type_valid
+    CHECK (card_type IN ('Visa', 'MasterCard', 'Amex', 'JCB', 'UnionPay', 'Discover'));
+ALTER TABLE cards ALTER COLUMN card_type SET DEFAULT 'Visa';
+ALTER TABLE cards ALTER COLUMN card_type SET NOT NULL;
+ALTER TABLE cards ALTER COLUMN expiry_date SET NOT NULL;
+ALTER TABLE cards ALTER COLUMN holder_name SET NOT NULL;
+ALTER TABLE cards ALTER COLUMN card_number_last4 SET NOT NULL;
+ALTER TABLE cards ALTER COLUMN card_number SET NOT NULL;
+ALTER TABLE cards DROP COLUMN credit_limit;
+ALTER TABLE cards DROP COLUMN account_type;
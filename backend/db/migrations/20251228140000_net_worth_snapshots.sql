@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE net_worth_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL,
+    total_assets BIGINT NOT NULL DEFAULT 0,
+    total_liabilities BIGINT NOT NULL DEFAULT 0,
+    net_worth BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_net_worth_snapshots_user_date ON net_worth_snapshots(user_id, snapshot_date);
+
+CREATE TABLE net_worth_snapshot_items (
+    id BIGSERIAL PRIMARY KEY,
+    snapshot_id BIGINT NOT NULL REFERENCES net_worth_snapshots(id) ON DELETE CASCADE,
+    card_id BIGINT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    account_type VARCHAR(20) NOT NULL,
+    balance BIGINT NOT NULL
+);
+
+CREATE INDEX idx_net_worth_snapshot_items_snapshot_id ON net_worth_snapshot_items(snapshot_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS net_worth_snapshot_items;
+DROP TABLE IF EXISTS net_worth_snapshots;
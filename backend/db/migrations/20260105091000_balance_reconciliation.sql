@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE cards ADD COLUMN initial_balance BIGINT NOT NULL DEFAULT 0;
+
+-- Treat the current balance minus the existing ledger as the opening balance
+UPDATE cards c SET initial_balance = c.balance - COALESCE((
+    SELECT SUM(CASE WHEN t.transaction_type = 'Income' THEN t.amount ELSE -t.amount END)
+    FROM transactions t
+    WHERE t.card_id = c.id
+), 0);
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transaction_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transaction_type_valid
+    CHECK (transaction_type IN ('Income', 'Expense', 'Transfer', 'Adjustment'));
+
+-- Adjustments carry a signed amount
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS amount_positive;
+ALTER TABLE transactions ADD CONSTRAINT amount_positive
+    CHECK (amount > 0 OR (transaction_type = 'Adjustment' AND amount <> 0));
+
+-- +goose Down
+DELETE FROM transactions WHERE transaction_type = 'Adjustment';
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS amount_positive;
+ALTER TABLE transactions ADD CONSTRAINT amount_positive CHECK (amount > 0);
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transaction_type_valid;
+ALTER TABLE transactions ADD CONSTRAINT transaction_type_valid
+    CHECK (transaction_type IN ('Income', 'Expense', 'Transfer'));
+ALTER TABLE cards DROP COLUMN initial_balance;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE transaction_splits (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories(id),
+    amount BIGINT NOT NULL,
+    note VARCHAR(255),
+    CONSTRAINT split_amount_positive CHECK (amount > 0)
+);
+
+CREATE INDEX idx_transaction_splits_transaction_id ON transaction_splits(transaction_id);
+CREATE INDEX idx_transaction_splits_category_id ON transaction_splits(category_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS transaction_splits;
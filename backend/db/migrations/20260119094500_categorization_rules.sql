@@ -0,0 +1,28 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN tags JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE categorization_rules (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    description_pattern VARCHAR(255),
+    pattern_type VARCHAR(10) NOT NULL DEFAULT 'contains',
+    min_amount BIGINT,
+    max_amount BIGINT,
+    card_id BIGINT REFERENCES cards(id) ON DELETE CASCADE,
+    transaction_type VARCHAR(10),
+    set_category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    add_tags JSONB NOT NULL DEFAULT '[]',
+    set_description VARCHAR(255),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT pattern_type_valid CHECK (pattern_type IN ('contains', 'regex'))
+);
+
+CREATE INDEX idx_categorization_rules_user_priority ON categorization_rules(user_id, priority);
+
+-- +goose Down
+DROP TABLE IF EXISTS categorization_rules;
+ALTER TABLE transactions DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,30 @@
+-- +goose Up
+CREATE TABLE payees (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    normalized_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_payees_user_normalized ON payees(user_id, normalized_name);
+
+CREATE TABLE payee_aliases (
+    id BIGSERIAL PRIMARY KEY,
+    payee_id BIGINT NOT NULL REFERENCES payees(id) ON DELETE CASCADE,
+    pattern VARCHAR(255) NOT NULL,
+    pattern_type VARCHAR(10) NOT NULL DEFAULT 'contains',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT alias_pattern_type_valid CHECK (pattern_type IN ('contains', 'regex'))
+);
+
+CREATE INDEX idx_payee_aliases_payee_id ON payee_aliases(payee_id);
+
+ALTER TABLE transactions ADD COLUMN payee_id BIGINT REFERENCES payees(id) ON DELETE SET NULL;
+CREATE INDEX idx_transactions_payee_id ON transactions(payee_id);
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN IF EXISTS payee_id;
+DROP TABLE IF EXISTS payee_aliases;
+DROP TABLE IF EXISTS payees;
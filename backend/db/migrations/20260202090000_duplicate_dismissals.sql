@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE duplicate_dismissals (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    duplicate_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT dismissal_pair_ordered CHECK (transaction_id < duplicate_id)
+);
+
+CREATE UNIQUE INDEX idx_duplicate_dismissals_pair ON duplicate_dismissals(transaction_id, duplicate_id);
+CREATE INDEX idx_duplicate_dismissals_user_id ON duplicate_dismissals(user_id);
+
+-- Duplicate lookups compare card, amount and date
+CREATE INDEX idx_transactions_card_amount_date ON transactions(card_id, amount, transaction_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_card_amount_date;
+DROP TABLE IF EXISTS duplicate_dismissals;
@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE bills (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    card_id BIGINT REFERENCES cards(id) ON DELETE SET NULL,
+    category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    due_date DATE NOT NULL,
+    recurrence VARCHAR(10) NOT NULL CHECK (recurrence IN ('none', 'weekly', 'monthly', 'quarterly', 'yearly')),
+    anchor_day INT NOT NULL CHECK (anchor_day BETWEEN 1 AND 31),
+    remind_days_before INT NOT NULL DEFAULT 3,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    reminded_for DATE,
+    overdue_for DATE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_bills_user_id ON bills(user_id);
+CREATE INDEX idx_bills_due_date ON bills(due_date) WHERE is_active;
+
+CREATE TABLE bill_payments (
+    id BIGSERIAL PRIMARY KEY,
+    bill_id BIGINT NOT NULL REFERENCES bills(id) ON DELETE CASCADE,
+    due_date DATE NOT NULL,
+    transaction_id BIGINT REFERENCES transactions(id) ON DELETE SET NULL,
+    amount BIGINT NOT NULL,
+    paid_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bill_payments_bill_id ON bill_payments(bill_id);
+CREATE INDEX idx_bill_payments_transaction_id ON bill_payments(transaction_id);
+
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS bill_payments;
+DROP TABLE IF EXISTS bills;
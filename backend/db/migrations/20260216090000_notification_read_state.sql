@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE notifications ADD COLUMN read_at TIMESTAMPTZ;
+
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+ALTER TABLE notifications DROP COLUMN IF EXISTS read_at;
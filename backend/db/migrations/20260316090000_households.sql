@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE households (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE household_members (
+    id BIGSERIAL PRIMARY KEY,
+    household_id BIGINT NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_household_members_user ON household_members(household_id, user_id);
+CREATE INDEX idx_household_members_user_id ON household_members(user_id);
+
+CREATE TABLE household_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    household_id BIGINT NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('editor', 'viewer')),
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_household_invitations_household_id ON household_invitations(household_id);
+CREATE INDEX idx_household_invitations_email ON household_invitations(LOWER(email));
+
+ALTER TABLE cards ADD COLUMN household_id BIGINT REFERENCES households(id) ON DELETE SET NULL;
+CREATE INDEX idx_cards_household_id ON cards(household_id) WHERE household_id IS NOT NULL;
+
+ALTER TABLE transactions ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN IF EXISTS created_by;
+DROP INDEX IF EXISTS idx_cards_household_id;
+ALTER TABLE cards DROP COLUMN IF EXISTS household_id;
+DROP TABLE IF EXISTS household_invitations;
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS households;
@@ -0,0 +1,64 @@
+-- +goose Up
+CREATE TABLE split_contacts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_split_contacts_user_name ON split_contacts(user_id, LOWER(name));
+
+CREATE TABLE shared_expenses (
+    id BIGSERIAL PRIMARY KEY,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    description VARCHAR(200) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    split_mode VARCHAR(10) NOT NULL CHECK (split_mode IN ('equal', 'percentage', 'exact')),
+    expense_date DATE NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_shared_expenses_created_by ON shared_expenses(created_by);
+
+CREATE TABLE shared_expense_shares (
+    id BIGSERIAL PRIMARY KEY,
+    expense_id BIGINT NOT NULL REFERENCES shared_expenses(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    contact_id BIGINT REFERENCES split_contacts(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    basis_points BIGINT,
+    is_payer BOOLEAN NOT NULL DEFAULT FALSE,
+
+    CONSTRAINT share_party CHECK ((user_id IS NULL) <> (contact_id IS NULL))
+);
+
+CREATE INDEX idx_shared_expense_shares_expense_id ON shared_expense_shares(expense_id);
+CREATE INDEX idx_shared_expense_shares_user_id ON shared_expense_shares(user_id);
+CREATE INDEX idx_shared_expense_shares_contact_id ON shared_expense_shares(contact_id);
+
+CREATE TABLE split_settlements (
+    id BIGSERIAL PRIMARY KEY,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    from_contact_id BIGINT REFERENCES split_contacts(id) ON DELETE CASCADE,
+    to_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    to_contact_id BIGINT REFERENCES split_contacts(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    transaction_id BIGINT REFERENCES transactions(id) ON DELETE SET NULL,
+    note VARCHAR(200),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT settlement_from CHECK ((from_user_id IS NULL) <> (from_contact_id IS NULL)),
+    CONSTRAINT settlement_to CHECK ((to_user_id IS NULL) <> (to_contact_id IS NULL))
+);
+
+CREATE INDEX idx_split_settlements_created_by ON split_settlements(created_by);
+CREATE INDEX idx_split_settlements_from_user_id ON split_settlements(from_user_id);
+CREATE INDEX idx_split_settlements_to_user_id ON split_settlements(to_user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS split_settlements;
+DROP TABLE IF EXISTS shared_expense_shares;
+DROP TABLE IF EXISTS shared_expenses;
+DROP TABLE IF EXISTS split_contacts;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE cards ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE transactions ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Live rows are what almost every query reads; the trash and purge job scan the rest
+CREATE INDEX idx_cards_deleted_at ON cards(deleted_at);
+CREATE INDEX idx_transactions_deleted_at ON transactions(deleted_at);
+CREATE INDEX idx_transactions_card_id_live ON transactions(card_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_action_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_action_check CHECK (action IN ('create', 'update', 'delete', 'restore'));
+
+-- +goose Down
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_action_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_action_check CHECK (action IN ('create', 'update', 'delete'));
+
+DROP INDEX IF EXISTS idx_transactions_card_id_live;
+DROP INDEX IF EXISTS idx_transactions_deleted_at;
+DROP INDEX IF EXISTS idx_cards_deleted_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE cards DROP COLUMN IF EXISTS deleted_at;
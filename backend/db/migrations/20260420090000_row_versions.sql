@@ -0,0 +1,8 @@
+-- +goose Up
+-- Bumped on every write; updates only apply when the version read still matches
+ALTER TABLE cards ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE transactions ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE transactions DROP COLUMN IF EXISTS version;
+ALTER TABLE cards DROP COLUMN IF EXISTS version;
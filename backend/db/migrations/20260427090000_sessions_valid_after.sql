@@ -0,0 +1,6 @@
+-- +goose Up
+-- Access tokens issued before this time are refused; set by a forced logout
+ALTER TABLE users ADD COLUMN sessions_valid_after TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS sessions_valid_after;
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog is one entry of the append-only trail of changes to financial
+// data. Before and After hold JSON snapshots of the record; Before is empty
+// for creates and After for deletes.
+type AuditLog struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"` // whose data changed
+	ActorID    *uuid.UUID `gorm:"type:uuid;index" json:"actor_id"`         // who changed it; empty for system jobs
+	Action     string     `gorm:"type:varchar(10);not null" json:"action"`
+	EntityType string     `gorm:"type:varchar(30);not null" json:"entity_type"`
+	EntityID   string     `gorm:"type:varchar(64);not null" json:"entity_id"`
+	Before     *string    `gorm:"type:jsonb" json:"before"`
+	After      *string    `gorm:"type:jsonb" json:"after"`
+	RequestID  string     `gorm:"type:varchar(64)" json:"request_id"`
+	IPAddress  string     `gorm:"type:varchar(45)" json:"ip_address"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+// TableName sets the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AuditAction constants
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// Audited entity types
+const (
+	AuditEntityCard        = "card"
+	AuditEntityTransaction = "transaction"
+	AuditEntityUser        = "user"
+	// AuditEntityCategory is reserved; categories have no write API yet
+	AuditEntityCategory = "category"
+)
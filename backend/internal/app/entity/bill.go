@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bill is a payment due on a date, optionally recurring. DueDate is the next
+// unpaid occurrence; paying it advances DueDate by the recurrence.
+type Bill struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name             string    `gorm:"type:varchar(100);not null" json:"name"`
+	Amount           int64     `gorm:"not null" json:"amount"`
+	CardID           *int64    `json:"card_id"`
+	CategoryID       *int64    `json:"category_id"`
+	DueDate          time.Time `gorm:"type:date;not null;index" json:"due_date"`
+	Recurrence       string    `gorm:"type:varchar(10);not null" json:"recurrence"`
+	AnchorDay        int       `gorm:"not null" json:"-"` // day of month monthly recurrences fall on
+	RemindDaysBefore int       `gorm:"not null" json:"remind_days_before"`
+	IsActive         bool      `gorm:"not null" json:"is_active"`
+
+	// Occurrence (due date) for which a reminder or overdue notice was sent
+	RemindedFor *time.Time `gorm:"type:date" json:"-"`
+	OverdueFor  *time.Time `gorm:"type:date" json:"-"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Payments []BillPayment `gorm:"foreignKey:BillID" json:"payments,omitempty"`
+}
+
+// TableName sets the table name for Bill
+func (Bill) TableName() string {
+	return "bills"
+}
+
+// BillPayment records that an occurrence of a bill was paid by a transaction
+type BillPayment struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	BillID        int64     `gorm:"not null;index" json:"bill_id"`
+	DueDate       time.Time `gorm:"type:date;not null" json:"due_date"`
+	TransactionID *int64    `gorm:"index" json:"transaction_id"`
+	Amount        int64     `gorm:"not null" json:"amount"`
+	PaidAt        time.Time `gorm:"not null" json:"paid_at"`
+}
+
+// TableName sets the table name for BillPayment
+func (BillPayment) TableName() string {
+	return "bill_payments"
+}
+
+// Recurrence constants
+const (
+	RecurrenceNone      = "none"
+	RecurrenceWeekly    = "weekly"
+	RecurrenceMonthly   = "monthly"
+	RecurrenceQuarterly = "quarterly"
+	RecurrenceYearly    = "yearly"
+)
@@ -4,22 +4,34 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// Card is a financial account. Payment cards were the first account type, so
+// the table keeps its name; card-specific fields are empty for other types.
 type Card struct {
 	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	UserID          uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
-	CardNumber      string    `gorm:"type:varchar(19);not null" json:"card_number"`
-	CardNumberLast4 string    `gorm:"type:varchar(4);not null" json:"card_number_last4"`
-	HolderName      string    `gorm:"type:varchar(100);not null" json:"holder_name"`
-	ExpiryDate      string    `gorm:"type:varchar(7);not null" json:"expiry_date"`
-	CardType        string    `gorm:"type:varchar(20);not null;default:Visa" json:"card_type"`
+	AccountType     string    `gorm:"type:varchar(20);not null;default:Card;index" json:"account_type"`
+	CardNumber      string    `gorm:"type:varchar(19)" json:"card_number"`
+	CardNumberLast4 string    `gorm:"type:varchar(4)" json:"card_number_last4"`
+	HolderName      string    `gorm:"type:varchar(100)" json:"holder_name"`
+	ExpiryDate      string    `gorm:"type:varchar(7)" json:"expiry_date"`
+	CardType        string    `gorm:"type:varchar(20)" json:"card_type"`
 	Alias           string    `gorm:"type:varchar(100)" json:"alias"`
 	Balance         int64     `gorm:"not null;default:0" json:"balance"`
+	InitialBalance  int64     `gorm:"not null;default:0" json:"initial_balance"`
+	CreditLimit     *int64    `json:"credit_limit"`
 	Color           string    `gorm:"type:varchar(100);not null;default:from-[#667eea] to-[#764ba2]" json:"color"`
 	IsFrozen        bool      `gorm:"default:false" json:"is_frozen"`
+	HouseholdID     *int64    `gorm:"index" json:"household_id"` // shared into this household
 	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// Version increases with every write and backs the ETag used for
+	// optimistic concurrency
+	Version int64 `gorm:"not null;default:1" json:"version"`
+	// Soft-deleted cards are excluded from queries until restored or purged
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	// Relationships
 	User         User          `gorm:"foreignKey:UserID;references:ID" json:"-"`
@@ -30,3 +42,43 @@ type Card struct {
 func (Card) TableName() string {
 	return "cards"
 }
+
+// IsLiability reports whether the account tracks money owed. Liability
+// balances are negative: -50000 means 500.00 is owed.
+func (c *Card) IsLiability() bool {
+	return c.AccountType == AccountTypeCredit || c.AccountType == AccountTypeLoan
+}
+
+// HasCardDetails reports whether the account is backed by a payment card
+func (c *Card) HasCardDetails() bool {
+	return c.CardNumber != ""
+}
+
+// AvailableCredit returns the remaining credit for accounts with a credit limit
+func (c *Card) AvailableCredit() *int64 {
+	if c.CreditLimit == nil {
+		return nil
+	}
+	available := *c.CreditLimit + c.Balance
+	return &available
+}
+
+// AccountType constants
+const (
+	AccountTypeCard     = "Card"
+	AccountTypeCash     = "Cash"
+	AccountTypeChecking = "Checking"
+	AccountTypeSavings  = "Savings"
+	AccountTypeCredit   = "Credit"
+	AccountTypeLoan     = "Loan"
+)
+
+// CardType constants
+const (
+	CardTypeVisa       = "Visa"
+	CardTypeMasterCard = "MasterCard"
+	CardTypeAmex       = "Amex"
+	CardTypeJCB        = "JCB"
+	CardTypeUnionPay   = "UnionPay"
+	CardTypeDiscover   = "Discover"
+)
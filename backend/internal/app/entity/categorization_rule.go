@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CategorizationRule assigns a category, tags or a cleaned description to
+// matching transactions. Unset conditions match every transaction; rules are
+// evaluated in ascending priority order.
+type CategorizationRule struct {
+	ID       int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name     string    `gorm:"type:varchar(100);not null" json:"name"`
+	Priority int       `gorm:"not null;default:0" json:"priority"`
+	IsActive bool      `gorm:"not null" json:"is_active"`
+
+	// Conditions
+	DescriptionPattern string  `gorm:"type:varchar(255)" json:"description_pattern"`
+	PatternType        string  `gorm:"type:varchar(10);not null;default:contains" json:"pattern_type"`
+	MinAmount          *int64  `json:"min_amount"`
+	MaxAmount          *int64  `json:"max_amount"`
+	CardID             *int64  `json:"card_id"`
+	TransactionType    *string `gorm:"type:varchar(10)" json:"transaction_type"`
+
+	// Actions
+	SetCategoryID  *int64  `json:"set_category_id"`
+	AddTags        Tags    `gorm:"type:jsonb" json:"add_tags"`
+	SetDescription *string `gorm:"type:varchar(255)" json:"set_description"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	SetCategory *Category `gorm:"foreignKey:SetCategoryID;references:ID" json:"-"`
+}
+
+// TableName sets the table name for CategorizationRule
+func (CategorizationRule) TableName() string {
+	return "categorization_rules"
+}
+
+// PatternType constants
+const (
+	PatternTypeContains = "contains"
+	PatternTypeRegex    = "regex"
+)
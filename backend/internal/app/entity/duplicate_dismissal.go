@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateDismissal records that two transactions flagged as probable
+// duplicates are distinct. TransactionID is always the lower of the two IDs.
+type DuplicateDismissal struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	TransactionID int64     `gorm:"not null;uniqueIndex:idx_duplicate_dismissals_pair" json:"transaction_id"`
+	DuplicateID   int64     `gorm:"not null;uniqueIndex:idx_duplicate_dismissals_pair" json:"duplicate_id"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for DuplicateDismissal
+func (DuplicateDismissal) TableName() string {
+	return "duplicate_dismissals"
+}
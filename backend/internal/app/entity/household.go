@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Household groups users that share cards. Cards stay owned by one user and
+// are shared into at most one household.
+type Household struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	OwnerID   uuid.UUID `gorm:"type:uuid;not null" json:"owner_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Members []HouseholdMember `gorm:"foreignKey:HouseholdID" json:"members,omitempty"`
+}
+
+// TableName sets the table name for Household
+func (Household) TableName() string {
+	return "households"
+}
+
+// HouseholdMember is a user's membership and role in a household
+type HouseholdMember struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	HouseholdID int64     `gorm:"not null;uniqueIndex:idx_household_members_user" json:"household_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_household_members_user" json:"user_id"`
+	Role        string    `gorm:"type:varchar(10);not null" json:"role"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;references:ID" json:"-"`
+}
+
+// TableName sets the table name for HouseholdMember
+func (HouseholdMember) TableName() string {
+	return "household_members"
+}
+
+// CanPost reports whether the member may post transactions to shared cards
+func (m *HouseholdMember) CanPost() bool {
+	return m.Role == HouseholdRoleOwner || m.Role == HouseholdRoleEditor
+}
+
+// HouseholdInvitation invites an email address into a household. It is
+// accepted by the user who signs in with that email.
+type HouseholdInvitation struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	HouseholdID int64      `gorm:"not null;index" json:"household_id"`
+	Email       string     `gorm:"type:varchar(255);not null;index" json:"email"`
+	Role        string     `gorm:"type:varchar(10);not null" json:"role"`
+	InvitedBy   uuid.UUID  `gorm:"type:uuid;not null" json:"invited_by"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Household Household `gorm:"foreignKey:HouseholdID;references:ID" json:"-"`
+}
+
+// TableName sets the table name for HouseholdInvitation
+func (HouseholdInvitation) TableName() string {
+	return "household_invitations"
+}
+
+// IsPending reports whether the invitation can still be accepted
+func (i *HouseholdInvitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// HouseholdRole constants. Owners manage the household, editors may post
+// transactions to shared cards and viewers may only see them.
+const (
+	HouseholdRoleOwner  = "owner"
+	HouseholdRoleEditor = "editor"
+	HouseholdRoleViewer = "viewer"
+)
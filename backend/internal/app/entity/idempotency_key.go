@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey remembers the outcome of a POST sent with an
+// Idempotency-Key header so a retry replays it instead of running again.
+// StatusCode stays zero while the first request is still in flight.
+type IdempotencyKey struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_keys_user_key" json:"user_id"`
+	Key          string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key" json:"key"`
+	RequestHash  string    `gorm:"type:varchar(64);not null" json:"request_hash"` // SHA-256 of method, path and body
+	StatusCode   int       `gorm:"not null;default:0" json:"status_code"`
+	ResponseBody []byte    `gorm:"type:bytea" json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for IdempotencyKey
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// IsCompleted reports whether the original request has finished and its
+// response is stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetWorthSnapshot records a user's account balances at the end of a day
+type NetWorthSnapshot struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_net_worth_snapshots_user_date" json:"user_id"`
+	SnapshotDate     time.Time `gorm:"type:date;not null;uniqueIndex:idx_net_worth_snapshots_user_date" json:"snapshot_date"`
+	TotalAssets      int64     `gorm:"not null;default:0" json:"total_assets"`
+	TotalLiabilities int64     `gorm:"not null;default:0" json:"total_liabilities"`
+	NetWorth         int64     `gorm:"not null;default:0" json:"net_worth"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	User  User                   `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	Items []NetWorthSnapshotItem `gorm:"foreignKey:SnapshotID" json:"items,omitempty"`
+}
+
+// TableName sets the table name for NetWorthSnapshot
+func (NetWorthSnapshot) TableName() string {
+	return "net_worth_snapshots"
+}
+
+// NetWorthSnapshotItem records a single account balance within a snapshot
+type NetWorthSnapshotItem struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	SnapshotID  int64  `gorm:"not null;index" json:"snapshot_id"`
+	CardID      int64  `gorm:"not null" json:"card_id"`
+	AccountType string `gorm:"type:varchar(20);not null" json:"account_type"`
+	Balance     int64  `gorm:"not null" json:"balance"`
+}
+
+// TableName sets the table name for NetWorthSnapshotItem
+func (NetWorthSnapshotItem) TableName() string {
+	return "net_worth_snapshot_items"
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is an in-app message shown to a user
+type Notification struct {
+	ID        int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type      string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title     string     `gorm:"type:varchar(200);not null" json:"title"`
+	Message   string     `gorm:"type:text" json:"message"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for Notification
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// IsRead reports whether the user has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationType constants. Types raised from events share the event's name.
+const (
+	NotificationTypeBillDue             = "bill.due"
+	NotificationTypeBillOverdue         = "bill.overdue"
+	NotificationTypeNewSignIn           = "auth.login"
+	NotificationTypePasswordChanged     = "auth.password_changed"
+	NotificationTypeCardFrozen          = "card.frozen"
+	NotificationTypeCardUnfrozen        = "card.unfrozen"
+	NotificationTypeFrozenCardAttempt   = "card.frozen_attempt"
+	NotificationTypeHouseholdInvitation = "household.invitation"
+)
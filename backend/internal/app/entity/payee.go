@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payee is a merchant or counterparty that transaction descriptions map to.
+// NormalizedName is the merchant key the payee was created from.
+type Payee struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_payees_user_normalized" json:"user_id"`
+	Name           string    `gorm:"type:varchar(100);not null" json:"name"`
+	NormalizedName string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_payees_user_normalized" json:"normalized_name"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Aliases []PayeeAlias `gorm:"foreignKey:PayeeID" json:"aliases,omitempty"`
+}
+
+// TableName sets the table name for Payee
+func (Payee) TableName() string {
+	return "payees"
+}
+
+// PayeeAlias maps descriptions to a payee by substring or regex
+type PayeeAlias struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PayeeID     int64     `gorm:"not null;index" json:"payee_id"`
+	Pattern     string    `gorm:"type:varchar(255);not null" json:"pattern"`
+	PatternType string    `gorm:"type:varchar(10);not null;default:contains" json:"pattern_type"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for PayeeAlias
+func (PayeeAlias) TableName() string {
+	return "payee_aliases"
+}
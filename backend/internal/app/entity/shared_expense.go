@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SplitContact is a named person without an account that a user splits
+// expenses with. Contacts are private to the user who created them.
+type SplitContact struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for SplitContact
+func (SplitContact) TableName() string {
+	return "split_contacts"
+}
+
+// SharedExpense is a cost paid by one participant and shared by all of them.
+// Every participant other than the payer owes the payer their share.
+type SharedExpense struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedBy   uuid.UUID `gorm:"type:uuid;not null;index" json:"created_by"`
+	Description string    `gorm:"type:varchar(200);not null" json:"description"`
+	Amount      int64     `gorm:"not null" json:"amount"`
+	SplitMode   string    `gorm:"type:varchar(10);not null" json:"split_mode"`
+	ExpenseDate time.Time `gorm:"type:date;not null" json:"expense_date"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Shares []SharedExpenseShare `gorm:"foreignKey:ExpenseID" json:"shares,omitempty"`
+}
+
+// TableName sets the table name for SharedExpense
+func (SharedExpense) TableName() string {
+	return "shared_expenses"
+}
+
+// Payer returns the share of the participant who paid
+func (e *SharedExpense) Payer() *SharedExpenseShare {
+	for i := range e.Shares {
+		if e.Shares[i].IsPayer {
+			return &e.Shares[i]
+		}
+	}
+	return nil
+}
+
+// SharedExpenseShare is one participant's part of a shared expense. Exactly
+// one of UserID and ContactID is set.
+type SharedExpenseShare struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ExpenseID   int64      `gorm:"not null;index" json:"expense_id"`
+	UserID      *uuid.UUID `gorm:"type:uuid;index" json:"user_id"`
+	ContactID   *int64     `gorm:"index" json:"contact_id"`
+	Amount      int64      `gorm:"not null" json:"amount"`
+	BasisPoints *int64     `json:"basis_points"` // percentage splits, 10000 = 100%
+	IsPayer     bool       `gorm:"not null" json:"is_payer"`
+
+	// Relationships
+	User    *User         `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	Contact *SplitContact `gorm:"foreignKey:ContactID;references:ID" json:"-"`
+}
+
+// TableName sets the table name for SharedExpenseShare
+func (SharedExpenseShare) TableName() string {
+	return "shared_expense_shares"
+}
+
+// SplitSettlement records a payment that reduces what one party owes another.
+// Each side is either a user or a contact of the user who recorded it.
+type SplitSettlement struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CreatedBy     uuid.UUID  `gorm:"type:uuid;not null;index" json:"created_by"`
+	FromUserID    *uuid.UUID `gorm:"type:uuid;index" json:"from_user_id"`
+	FromContactID *int64     `json:"from_contact_id"`
+	ToUserID      *uuid.UUID `gorm:"type:uuid;index" json:"to_user_id"`
+	ToContactID   *int64     `json:"to_contact_id"`
+	Amount        int64      `gorm:"not null" json:"amount"`
+	TransactionID *int64     `json:"transaction_id"`
+	Note          string     `gorm:"type:varchar(200)" json:"note"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName sets the table name for SplitSettlement
+func (SplitSettlement) TableName() string {
+	return "split_settlements"
+}
+
+// SplitMode constants
+const (
+	SplitModeEqual      = "equal"
+	SplitModePercentage = "percentage"
+	SplitModeExact      = "exact"
+)
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Tags is a list of free-form labels stored as a JSON array
+type Tags []string
+
+// Value implements driver.Valuer
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner
+func (t *Tags) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Tags", value)
+	}
+	return json.Unmarshal(data, t)
+}
+
+// Has reports whether the tag is present, ignoring case
+func (t Tags) Has(tag string) bool {
+	for _, existing := range t {
+		if strings.EqualFold(existing, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns the tags with any new, non-empty tags appended
+func (t Tags) Merge(tags ...string) Tags {
+	merged := append(Tags{}, t...)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || merged.Has(tag) {
+			continue
+		}
+		merged = append(merged, tag)
+	}
+	return merged
+}
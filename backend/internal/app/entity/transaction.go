@@ -4,24 +4,35 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Transaction struct {
-	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID          uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
-	CardID          int64     `gorm:"not null" json:"card_id"`
-	CategoryID      *int64    `gorm:"index" json:"category_id"`
-	TransactionType string    `gorm:"type:varchar(10);not null" json:"transaction_type"`
-	Amount          int64     `gorm:"not null" json:"amount"`
-	TransactionDate time.Time `gorm:"type:date;not null" json:"transaction_date"`
-	Description     string    `gorm:"type:text" json:"description"`
-	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	CardID          int64      `gorm:"not null" json:"card_id"`
+	CategoryID      *int64     `gorm:"index" json:"category_id"`
+	PayeeID         *int64     `gorm:"index" json:"payee_id"`
+	TransactionType string     `gorm:"type:varchar(10);not null" json:"transaction_type"`
+	Amount          int64      `gorm:"not null" json:"amount"`
+	TransactionDate time.Time  `gorm:"type:date;not null" json:"transaction_date"`
+	Description     string     `gorm:"type:text" json:"description"`
+	Tags            Tags       `gorm:"type:jsonb" json:"tags"`
+	CreatedBy       *uuid.UUID `gorm:"type:uuid" json:"created_by"` // household member who posted to a shared card
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	// Version increases with every write and backs the ETag used for
+	// optimistic concurrency
+	Version int64 `gorm:"not null;default:1" json:"version"`
+	// Soft-deleted transactions are excluded from queries, stats and balances
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	// Relationships
-	User     User      `gorm:"foreignKey:UserID;references:ID" json:"-"`
-	Card     Card      `gorm:"foreignKey:CardID;references:ID" json:"-"`
-	Category *Category `gorm:"foreignKey:CategoryID;references:ID" json:"category,omitempty"`
+	User     User               `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	Card     Card               `gorm:"foreignKey:CardID;references:ID" json:"-"`
+	Category *Category          `gorm:"foreignKey:CategoryID;references:ID" json:"category,omitempty"`
+	Payee    *Payee             `gorm:"foreignKey:PayeeID;references:ID" json:"payee,omitempty"`
+	Splits   []TransactionSplit `gorm:"foreignKey:TransactionID" json:"splits,omitempty"`
 }
 
 // TableName sets the table name for Transaction
@@ -29,9 +40,29 @@ func (Transaction) TableName() string {
 	return "transactions"
 }
 
+// IsSplit reports whether the transaction is divided across categories
+func (t *Transaction) IsSplit() bool {
+	return len(t.Splits) > 0
+}
+
 // TransactionType constants
 const (
 	TransactionTypeIncome   = "Income"
 	TransactionTypeExpense  = "Expense"
 	TransactionTypeTransfer = "Transfer"
+	// TransactionTypeAdjustment corrects a balance; its amount is signed
+	TransactionTypeAdjustment = "Adjustment"
 )
+
+// BalanceChange returns the signed effect of a transaction on its card balance
+func BalanceChange(transactionType string, amount int64) int64 {
+	if transactionType == TransactionTypeIncome || transactionType == TransactionTypeAdjustment {
+		return amount
+	}
+	return -amount
+}
+
+// BalanceChange returns the signed effect of the transaction on its card balance
+func (t *Transaction) BalanceChange() int64 {
+	return BalanceChange(t.TransactionType, t.Amount)
+}
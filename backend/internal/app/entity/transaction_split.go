@@ -0,0 +1,18 @@
+package entity
+
+// TransactionSplit assigns part of a transaction's amount to a category
+type TransactionSplit struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransactionID int64  `gorm:"not null;index" json:"transaction_id"`
+	CategoryID    int64  `gorm:"not null;index" json:"category_id"`
+	Amount        int64  `gorm:"not null" json:"amount"`
+	Note          string `gorm:"type:varchar(255)" json:"note"`
+
+	// Relationships
+	Category *Category `gorm:"foreignKey:CategoryID;references:ID" json:"category,omitempty"`
+}
+
+// TableName sets the table name for TransactionSplit
+func (TransactionSplit) TableName() string {
+	return "transaction_splits"
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type User struct {
 	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -8,7 +12,12 @@ type User struct {
 	PasswordHash string    `gorm:"not null" json:"-"`
 	FirstName    string    `gorm:"not null" json:"first_name"`
 	LastName     string    `gorm:"not null" json:"last_name"`
+	Role         string    `gorm:"type:varchar(20);not null;default:user" json:"role"`
 	IsActive     bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Access tokens issued before this time are refused (set by a forced logout)
+	SessionsValidAfter *time.Time `json:"-"`
 }
 
 // TableName sets the table name for User
@@ -16,7 +25,29 @@ func (User) TableName() string {
 	return "users"
 }
 
+// AcceptsToken reports whether an access token issued at issuedAt is still
+// valid for the user, i.e. was not issued before a forced logout
+func (u *User) AcceptsToken(issuedAt time.Time) bool {
+	return u.SessionsValidAfter == nil || !issuedAt.Before(*u.SessionsValidAfter)
+}
+
 // FullName returns the user's full name
 func (u *User) FullName() string {
 	return u.FirstName + " " + u.LastName
 }
+
+// Role constants. Support staff get read-only access to the admin API.
+const (
+	RoleUser    = "user"
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+)
+
+// IsValidRole reports whether role is one of the known roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleUser, RoleAdmin, RoleSupport:
+		return true
+	}
+	return false
+}
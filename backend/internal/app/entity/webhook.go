@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a user-registered endpoint that receives events as signed POSTs
+type Webhook struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	URL         string     `gorm:"type:varchar(500);not null" json:"url"`
+	Description string     `gorm:"type:varchar(200)" json:"description"`
+	Secret      string     `gorm:"type:varchar(100);not null" json:"-"`
+	Events      EventTypes `gorm:"type:jsonb;not null" json:"events"`
+	IsActive    bool       `gorm:"not null" json:"is_active"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName sets the table name for Webhook
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDelivery is an event queued for a webhook (the outbox row) and the
+// log of its delivery attempts
+type WebhookDelivery struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WebhookID      int64      `gorm:"not null;index" json:"webhook_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload        string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null" json:"status"`
+	Attempts       int        `gorm:"not null" json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	Error          string     `gorm:"type:text" json:"error"`
+	DurationMs     int64      `json:"duration_ms"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Webhook *Webhook `gorm:"foreignKey:WebhookID" json:"-"`
+}
+
+// TableName sets the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// DeliveryStatus constants
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// EventTypes is a list of event types stored as a JSON array
+type EventTypes []string
+
+// Value implements driver.Valuer
+func (e EventTypes) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner
+func (e *EventTypes) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EventTypes", value)
+	}
+	return json.Unmarshal(data, e)
+}
+
+// Has reports whether the event type is in the list
+func (e EventTypes) Has(eventType string) bool {
+	for _, existing := range e {
+		if existing == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL implementation of APIKeyRepository
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	if err := r.db.WithContext(ctx).Create(key).Error; err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id int64) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("api key not found")
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("api key not found")
+		}
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to find api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error; err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.APIKey{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+
+	"gorm.io/gorm"
+)
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new PostgreSQL implementation of AuditLogRepository
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, entry *entity.AuditLog) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+func (r *auditLogRepository) Find(ctx context.Context, filter repository.AuditLogFilter) ([]entity.AuditLog, error) {
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&entity.AuditLog{}), filter).Order("created_at DESC, id DESC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var entries []entity.AuditLog
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to find audit logs: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *auditLogRepository) Count(ctx context.Context, filter repository.AuditLogFilter) (int64, error) {
+	var count int64
+	if err := r.applyFilter(r.db.WithContext(ctx).Model(&entity.AuditLog{}), filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+	return count, nil
+}
+
+func (r *auditLogRepository) applyFilter(query *gorm.DB, filter repository.AuditLogFilter) *gorm.DB {
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("created_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("created_at <= ?", *filter.EndDate)
+	}
+	return query
+}
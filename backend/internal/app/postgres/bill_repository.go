@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type billRepository struct {
+	db *gorm.DB
+}
+
+// NewBillRepository creates a new PostgreSQL implementation of BillRepository
+func NewBillRepository(db *gorm.DB) repository.BillRepository {
+	return &billRepository{db: db}
+}
+
+func (r *billRepository) Create(ctx context.Context, bill *entity.Bill) error {
+	if err := r.db.WithContext(ctx).Create(bill).Error; err != nil {
+		return fmt.Errorf("failed to create bill: %w", err)
+	}
+	return nil
+}
+
+func (r *billRepository) FindByID(ctx context.Context, id int64) (*entity.Bill, error) {
+	var bill entity.Bill
+	if err := r.db.WithContext(ctx).
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("due_date DESC")
+		}).
+		Where("id = ?", id).
+		First(&bill).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("bill not found")
+		}
+		return nil, fmt.Errorf("failed to find bill: %w", err)
+	}
+	return &bill, nil
+}
+
+func (r *billRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Bill, error) {
+	var bills []entity.Bill
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("due_date ASC").
+		Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to find bills: %w", err)
+	}
+	return bills, nil
+}
+
+func (r *billRepository) FindDue(ctx context.Context, before time.Time) ([]entity.Bill, error) {
+	var bills []entity.Bill
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ? AND due_date <= ?", true, before).
+		Order("due_date ASC").
+		Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to find due bills: %w", err)
+	}
+	return bills, nil
+}
+
+func (r *billRepository) Update(ctx context.Context, bill *entity.Bill) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(bill).Error; err != nil {
+		return fmt.Errorf("failed to update bill: %w", err)
+	}
+	return nil
+}
+
+func (r *billRepository) Delete(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bill_id = ?", id).Delete(&entity.BillPayment{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.Bill{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete bill: %w", err)
+	}
+	return nil
+}
+
+func (r *billRepository) RecordPayment(ctx context.Context, bill *entity.Bill, payment *entity.BillPayment) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		// Only one payment can move the bill past the occurrence
+		result := db.Model(&entity.Bill{}).
+			Where("id = ? AND due_date = ? AND is_active = ?", bill.ID, payment.DueDate, true).
+			Updates(map[string]interface{}{
+				"due_date":  bill.DueDate,
+				"is_active": bill.IsActive,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update bill: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.Conflict("bill occurrence was already paid")
+		}
+
+		if err := db.Create(payment).Error; err != nil {
+			return fmt.Errorf("failed to create bill payment: %w", err)
+		}
+		return nil
+	})
+}
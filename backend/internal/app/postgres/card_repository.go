@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type cardRepository struct {
@@ -30,7 +33,7 @@ func (r *cardRepository) FindByID(ctx context.Context, id int64) (*entity.Card,
 	var card entity.Card
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&card).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("card not found")
+			return nil, apperror.NotFound("card not found")
 		}
 		return nil, fmt.Errorf("failed to find card: %w", err)
 	}
@@ -48,25 +51,138 @@ func (r *cardRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]
 	return cards, nil
 }
 
+func (r *cardRepository) FindByHouseholdID(ctx context.Context, householdID int64) ([]entity.Card, error) {
+	var cards []entity.Card
+	if err := r.db.WithContext(ctx).
+		Where("household_id = ?", householdID).
+		Order("created_at DESC").
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to find household cards: %w", err)
+	}
+	return cards, nil
+}
+
+// FindSharedWithUser returns cards of other users shared into households the
+// user belongs to
+func (r *cardRepository) FindSharedWithUser(ctx context.Context, userID uuid.UUID) ([]entity.Card, error) {
+	var cards []entity.Card
+	if err := r.db.WithContext(ctx).
+		Where("user_id <> ? AND household_id IN (SELECT household_id FROM household_members WHERE user_id = ?)", userID, userID).
+		Order("created_at DESC").
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to find shared cards: %w", err)
+	}
+	return cards, nil
+}
+
+// Update writes the card only if its version is still the one it was read
+// with, then advances the version. Otherwise another write got there first
+// and Update returns a stale error without changing anything.
 func (r *cardRepository) Update(ctx context.Context, card *entity.Card) error {
-	if err := r.db.WithContext(ctx).Save(card).Error; err != nil {
-		return fmt.Errorf("failed to update card: %w", err)
+	read := card.Version
+	card.Version++
+	result := r.db.WithContext(ctx).
+		Model(card).
+		Where("version = ?", read).
+		Select("*").
+		Omit(clause.Associations, "created_at", "deleted_at").
+		Updates(card)
+	if result.Error != nil {
+		card.Version = read
+		return fmt.Errorf("failed to update card: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		card.Version = read
+		return apperror.Stale("card was changed by another request")
 	}
 	return nil
 }
 
-func (r *cardRepository) Delete(ctx context.Context, id int64) error {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Card{}).Error; err != nil {
-		return fmt.Errorf("failed to delete card: %w", err)
+// Delete soft-deletes the card together with its live transactions. Both get
+// the same deleted_at so Restore can tell them apart from transactions that
+// were deleted on their own beforehand.
+func (r *cardRepository) Delete(ctx context.Context, id int64, version int64) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Card{}).
+			Where("id = ? AND version = ?", id, version).
+			UpdateColumn("deleted_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete card: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.Stale("card was changed by another request")
+		}
+		if err := tx.Model(&entity.Transaction{}).
+			Where("card_id = ?", id).
+			UpdateColumn("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to delete card transactions: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *cardRepository) FindDeletedByID(ctx context.Context, id int64) (*entity.Card, error) {
+	var card entity.Card
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&card).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("card not found")
+		}
+		return nil, fmt.Errorf("failed to find card: %w", err)
 	}
-	return nil
+	return &card, nil
+}
+
+func (r *cardRepository) FindDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Card, error) {
+	var cards []entity.Card
+	if err := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deleted cards: %w", err)
+	}
+	return cards, nil
+}
+
+// Restore un-deletes the card and the transactions that were deleted with it
+func (r *cardRepository) Restore(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&entity.Transaction{}).
+			Where("card_id = ? AND deleted_at = (SELECT deleted_at FROM cards WHERE id = ?)", id, id).
+			UpdateColumn("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore card transactions: %w", err)
+		}
+		if err := tx.Unscoped().Model(&entity.Card{}).
+			Where("id = ?", id).
+			UpdateColumn("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore card: %w", err)
+		}
+		return nil
+	})
+}
+
+// PurgeDeleted permanently removes cards deleted before the cutoff. Their
+// transactions go with them through the foreign key cascade.
+func (r *cardRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&entity.Card{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted cards: %w", result.Error)
+	}
+	return result.RowsAffected, nil
 }
 
 func (r *cardRepository) UpdateBalance(ctx context.Context, id int64, amount int64) error {
 	if err := r.db.WithContext(ctx).
 		Model(&entity.Card{}).
 		Where("id = ?", id).
-		UpdateColumn("balance", gorm.Expr("balance + ?", amount)).
+		UpdateColumns(map[string]interface{}{
+			"balance": gorm.Expr("balance + ?", amount),
+			"version": gorm.Expr("version + 1"),
+		}).
 		Error; err != nil {
 		return fmt.Errorf("failed to update card balance: %w", err)
 	}
@@ -77,7 +193,10 @@ func (r *cardRepository) ToggleFreeze(ctx context.Context, id int64) error {
 	if err := r.db.WithContext(ctx).
 		Model(&entity.Card{}).
 		Where("id = ?", id).
-		UpdateColumn("is_frozen", gorm.Expr("NOT is_frozen")).
+		UpdateColumns(map[string]interface{}{
+			"is_frozen": gorm.Expr("NOT is_frozen"),
+			"version":   gorm.Expr("version + 1"),
+		}).
 		Error; err != nil {
 		return fmt.Errorf("failed to toggle card freeze: %w", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
 
 	"gorm.io/gorm"
 )
@@ -41,9 +42,38 @@ func (r *categoryRepository) FindByID(ctx context.Context, id int64) (*entity.Ca
 	var category entity.Category
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&category).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("category not found")
+			return nil, apperror.NotFound("category not found")
 		}
 		return nil, fmt.Errorf("failed to find category: %w", err)
 	}
 	return &category, nil
 }
+
+func (r *categoryRepository) GetUsage(ctx context.Context) ([]repository.CategoryUsage, error) {
+	query := `
+		SELECT c.id AS category_id,
+			COUNT(lines.category_id) AS transaction_count,
+			COUNT(DISTINCT lines.user_id) AS user_count,
+			COALESCE(SUM(lines.amount), 0) AS total,
+			MAX(lines.transaction_date) AS last_used_at
+		FROM categories c
+		LEFT JOIN (
+			SELECT t.category_id, t.user_id, t.amount, t.transaction_date
+			FROM transactions t
+			WHERE t.category_id IS NOT NULL AND t.deleted_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id)
+			UNION ALL
+			SELECT s.category_id, t.user_id, s.amount, t.transaction_date
+			FROM transaction_splits s
+			JOIN transactions t ON t.id = s.transaction_id
+			WHERE t.deleted_at IS NULL
+		) lines ON lines.category_id = c.id
+		GROUP BY c.id
+		ORDER BY transaction_count DESC, c.id ASC`
+
+	var usage []repository.CategoryUsage
+	if err := r.db.WithContext(ctx).Raw(query).Scan(&usage).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category usage: %w", err)
+	}
+	return usage, nil
+}
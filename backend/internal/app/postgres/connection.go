@@ -37,6 +37,8 @@ func New(cfg Config, logger *logger.Logger) (*Database, error) {
 			return time.Now().UTC()
 		},
 		PrepareStmt: true,
+		// Unique violations surface as gorm.ErrDuplicatedKey
+		TranslateError: true,
 	}
 
 	// connect to db
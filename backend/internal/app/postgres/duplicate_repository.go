@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type duplicateRepository struct {
+	db *gorm.DB
+}
+
+// NewDuplicateRepository creates a new PostgreSQL implementation of DuplicateRepository
+func NewDuplicateRepository(db *gorm.DB) repository.DuplicateRepository {
+	return &duplicateRepository{db: db}
+}
+
+func (r *duplicateRepository) FindCandidates(ctx context.Context, userID uuid.UUID, filter repository.DuplicateCandidateFilter) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND card_id = ? AND amount = ? AND transaction_type = ?",
+			userID, filter.CardID, filter.Amount, filter.TransactionType).
+		Where("transaction_date BETWEEN ? AND ?", filter.From, filter.To).
+		Order("transaction_date DESC").
+		Find(&transactions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find duplicate candidates: %w", err)
+	}
+	return transactions, nil
+}
+
+func (r *duplicateRepository) FindPairs(ctx context.Context, userID uuid.UUID, windowDays int) ([]repository.DuplicatePair, error) {
+	query := `
+		SELECT t1.id AS first_id, t2.id AS second_id
+		FROM transactions t1
+		JOIN transactions t2
+			ON t2.card_id = t1.card_id
+			AND t2.amount = t1.amount
+			AND t2.transaction_type = t1.transaction_type
+			AND t2.id > t1.id
+			AND ABS(t2.transaction_date - t1.transaction_date) <= ?
+		WHERE t1.user_id = ? AND t2.user_id = ?
+			AND t1.deleted_at IS NULL AND t2.deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM duplicate_dismissals d
+				WHERE d.transaction_id = t1.id AND d.duplicate_id = t2.id
+			)
+		ORDER BY t1.transaction_date DESC, t1.id`
+
+	var pairs []repository.DuplicatePair
+	if err := r.db.WithContext(ctx).Raw(query, windowDays, userID, userID).Scan(&pairs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find duplicate pairs: %w", err)
+	}
+	return pairs, nil
+}
+
+func (r *duplicateRepository) Dismiss(ctx context.Context, dismissal *entity.DuplicateDismissal) error {
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(dismissal).Error; err != nil {
+		return fmt.Errorf("failed to dismiss duplicate: %w", err)
+	}
+	return nil
+}
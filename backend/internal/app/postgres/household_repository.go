@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type householdRepository struct {
+	db *gorm.DB
+}
+
+// NewHouseholdRepository creates a new PostgreSQL implementation of HouseholdRepository
+func NewHouseholdRepository(db *gorm.DB) repository.HouseholdRepository {
+	return &householdRepository{db: db}
+}
+
+// Create stores the household together with its initial members
+func (r *householdRepository) Create(ctx context.Context, household *entity.Household) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(household).Error; err != nil {
+			return fmt.Errorf("failed to create household: %w", err)
+		}
+
+		for i := range household.Members {
+			household.Members[i].HouseholdID = household.ID
+			if err := tx.Omit(clause.Associations).Create(&household.Members[i]).Error; err != nil {
+				return fmt.Errorf("failed to add household member: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *householdRepository) FindByID(ctx context.Context, id int64) (*entity.Household, error) {
+	var household entity.Household
+	if err := r.db.WithContext(ctx).
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Members.User").
+		Where("id = ?", id).
+		First(&household).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("household not found")
+		}
+		return nil, fmt.Errorf("failed to find household: %w", err)
+	}
+	return &household, nil
+}
+
+func (r *householdRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Household, error) {
+	var households []entity.Household
+	if err := r.db.WithContext(ctx).
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		Preload("Members.User").
+		Where("id IN (SELECT household_id FROM household_members WHERE user_id = ?)", userID).
+		Order("name ASC").
+		Find(&households).Error; err != nil {
+		return nil, fmt.Errorf("failed to find households: %w", err)
+	}
+	return households, nil
+}
+
+func (r *householdRepository) Update(ctx context.Context, household *entity.Household) error {
+	// Preloaded relationships are read-only views; never write them back
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(household).Error; err != nil {
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+	return nil
+}
+
+func (r *householdRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Household{}).Error; err != nil {
+		return fmt.Errorf("failed to delete household: %w", err)
+	}
+	return nil
+}
+
+func (r *householdRepository) FindMember(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.HouseholdMember, error) {
+	var member entity.HouseholdMember
+	if err := r.db.WithContext(ctx).
+		Where("household_id = ? AND user_id = ?", householdID, userID).
+		First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("household member not found")
+		}
+		return nil, fmt.Errorf("failed to find household member: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *householdRepository) AddMember(ctx context.Context, member *entity.HouseholdMember) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(member).Error; err != nil {
+		return fmt.Errorf("failed to add household member: %w", err)
+	}
+	return nil
+}
+
+func (r *householdRepository) UpdateMember(ctx context.Context, member *entity.HouseholdMember) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(member).Error; err != nil {
+		return fmt.Errorf("failed to update household member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember deletes the membership and unshares the member's cards from
+// the household
+func (r *householdRepository) RemoveMember(ctx context.Context, householdID int64, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Card{}).
+			Where("household_id = ? AND user_id = ?", householdID, userID).
+			UpdateColumns(map[string]interface{}{
+				"household_id": nil,
+				"version":      gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to unshare member cards: %w", err)
+		}
+
+		if err := tx.Where("household_id = ? AND user_id = ?", householdID, userID).
+			Delete(&entity.HouseholdMember{}).Error; err != nil {
+			return fmt.Errorf("failed to remove household member: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *householdRepository) CreateInvitation(ctx context.Context, invitation *entity.HouseholdInvitation) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(invitation).Error; err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *householdRepository) FindInvitationByID(ctx context.Context, id int64) (*entity.HouseholdInvitation, error) {
+	var invitation entity.HouseholdInvitation
+	if err := r.db.WithContext(ctx).Preload("Household").Where("id = ?", id).First(&invitation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("invitation not found")
+		}
+		return nil, fmt.Errorf("failed to find invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+// FindInvitationsByHouseholdID returns the household's pending invitations
+func (r *householdRepository) FindInvitationsByHouseholdID(ctx context.Context, householdID int64) ([]entity.HouseholdInvitation, error) {
+	var invitations []entity.HouseholdInvitation
+	if err := r.db.WithContext(ctx).
+		Where("household_id = ? AND accepted_at IS NULL AND expires_at > ?", householdID, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		return nil, fmt.Errorf("failed to find invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// FindInvitationsByEmail returns the pending invitations addressed to email
+func (r *householdRepository) FindInvitationsByEmail(ctx context.Context, email string) ([]entity.HouseholdInvitation, error) {
+	var invitations []entity.HouseholdInvitation
+	if err := r.db.WithContext(ctx).
+		Preload("Household").
+		Where("LOWER(email) = LOWER(?) AND accepted_at IS NULL AND expires_at > ?", email, time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		return nil, fmt.Errorf("failed to find invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *householdRepository) UpdateInvitation(ctx context.Context, invitation *entity.HouseholdInvitation) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(invitation).Error; err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *householdRepository) DeleteInvitation(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.HouseholdInvitation{}).Error; err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	return nil
+}
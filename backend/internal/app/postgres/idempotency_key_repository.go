@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new PostgreSQL implementation of IdempotencyKeyRepository
+func NewIdempotencyKeyRepository(db *gorm.DB) repository.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+func (r *idempotencyKeyRepository) Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	// An expired row that the purge job hasn't reached yet is reused in place
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"request_hash":  key.RequestHash,
+			"status_code":   0,
+			"response_body": nil,
+			"expires_at":    key.ExpiresAt,
+			"created_at":    time.Now(),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lt{Column: clause.Column{Table: "idempotency_keys", Name: "expires_at"}, Value: time.Now()},
+		}},
+	}).Create(key)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *idempotencyKeyRepository) FindByKey(ctx context.Context, userID uuid.UUID, key string) (*entity.IdempotencyKey, error) {
+	var record entity.IdempotencyKey
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ?", userID, key).
+		First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("idempotency key not found")
+		}
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+	return &record, nil
+}
+
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, id int64, statusCode int, body []byte) error {
+	if err := r.db.WithContext(ctx).
+		Model(&entity.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"response_body": body,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyKeyRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.IdempotencyKey{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at <= ?", before).
+		Delete(&entity.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type netWorthRepository struct {
+	db *gorm.DB
+}
+
+// NewNetWorthRepository creates a new PostgreSQL implementation of NetWorthRepository
+func NewNetWorthRepository(db *gorm.DB) repository.NetWorthRepository {
+	return &netWorthRepository{db: db}
+}
+
+// Save stores a snapshot, replacing any existing snapshot for the same user and date
+func (r *netWorthRepository) Save(ctx context.Context, snapshot *entity.NetWorthSnapshot) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingIDs []int64
+		if err := tx.Model(&entity.NetWorthSnapshot{}).
+			Where("user_id = ? AND snapshot_date = ?", snapshot.UserID, snapshot.SnapshotDate).
+			Pluck("id", &existingIDs).Error; err != nil {
+			return err
+		}
+
+		if len(existingIDs) > 0 {
+			if err := tx.Where("snapshot_id IN ?", existingIDs).Delete(&entity.NetWorthSnapshotItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", existingIDs).Delete(&entity.NetWorthSnapshot{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(snapshot).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save net worth snapshot: %w", err)
+	}
+	return nil
+}
+
+func (r *netWorthRepository) FindByUserID(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.NetWorthSnapshot, error) {
+	var snapshots []entity.NetWorthSnapshot
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Where("user_id = ? AND snapshot_date >= ? AND snapshot_date <= ?", userID, from, to).
+		Order("snapshot_date ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to find net worth snapshots: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new PostgreSQL implementation of NotificationRepository
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	if err := r.db.WithContext(ctx).Create(notification).Error; err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) FindByUserID(ctx context.Context, userID uuid.UUID, filter repository.NotificationFilter) ([]entity.Notification, error) {
+	var notifications []entity.Notification
+	query := applyNotificationFilter(r.db.WithContext(ctx).Where("user_id = ?", userID), filter).
+		Order("created_at DESC, id DESC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	if err := query.Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("failed to find notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) Count(ctx context.Context, userID uuid.UUID, filter repository.NotificationFilter) (int64, error) {
+	var count int64
+	query := applyNotificationFilter(r.db.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", userID), filter)
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	return count, nil
+}
+
+func (r *notificationRepository) SetRead(ctx context.Context, userID uuid.UUID, ids []int64, read bool) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ?", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	// Only touch rows whose state changes so read_at keeps the first read time
+	var readAt interface{}
+	if read {
+		readAt = time.Now()
+		query = query.Where("read_at IS NULL")
+	} else {
+		query = query.Where("read_at IS NOT NULL")
+	}
+
+	result := query.Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to update notifications: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// applyNotificationFilter adds the optional filter conditions to a notification query
+func applyNotificationFilter(query *gorm.DB, filter repository.NotificationFilter) *gorm.DB {
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	return query
+}
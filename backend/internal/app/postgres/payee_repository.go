@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type payeeRepository struct {
+	db *gorm.DB
+}
+
+// NewPayeeRepository creates a new PostgreSQL implementation of PayeeRepository
+func NewPayeeRepository(db *gorm.DB) repository.PayeeRepository {
+	return &payeeRepository{db: db}
+}
+
+func (r *payeeRepository) Create(ctx context.Context, payee *entity.Payee) error {
+	if err := r.db.WithContext(ctx).Create(payee).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apperror.Conflict("a payee with this name already exists")
+		}
+		return fmt.Errorf("failed to create payee: %w", err)
+	}
+	return nil
+}
+
+func (r *payeeRepository) FindByID(ctx context.Context, id int64) (*entity.Payee, error) {
+	var payee entity.Payee
+	if err := r.db.WithContext(ctx).Preload("Aliases").Where("id = ?", id).First(&payee).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("payee not found")
+		}
+		return nil, fmt.Errorf("failed to find payee: %w", err)
+	}
+	return &payee, nil
+}
+
+func (r *payeeRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Payee, error) {
+	var payees []entity.Payee
+	if err := r.db.WithContext(ctx).
+		Preload("Aliases").
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&payees).Error; err != nil {
+		return nil, fmt.Errorf("failed to find payees: %w", err)
+	}
+	return payees, nil
+}
+
+func (r *payeeRepository) FindOrCreate(ctx context.Context, payee *entity.Payee) error {
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND normalized_name = ?", payee.UserID, payee.NormalizedName).
+		Attrs(entity.Payee{Name: payee.Name}).
+		FirstOrCreate(payee).Error
+	if err != nil {
+		return fmt.Errorf("failed to find or create payee: %w", err)
+	}
+	return nil
+}
+
+func (r *payeeRepository) Update(ctx context.Context, payee *entity.Payee) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(payee).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apperror.Conflict("a payee with this name already exists")
+		}
+		return fmt.Errorf("failed to update payee: %w", err)
+	}
+	return nil
+}
+
+func (r *payeeRepository) Delete(ctx context.Context, id int64) ([]entity.Transaction, error) {
+	var unlinked []entity.Transaction
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if unlinked, err = movePayeeTransactions(tx, nil, "payee_id = ?", id); err != nil {
+			return err
+		}
+		if err := tx.Where("payee_id = ?", id).Delete(&entity.PayeeAlias{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.Payee{}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete payee: %w", err)
+	}
+	return unlinked, nil
+}
+
+func (r *payeeRepository) CreateAlias(ctx context.Context, alias *entity.PayeeAlias) error {
+	if err := r.db.WithContext(ctx).Create(alias).Error; err != nil {
+		return fmt.Errorf("failed to create payee alias: %w", err)
+	}
+	return nil
+}
+
+func (r *payeeRepository) DeleteAlias(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.PayeeAlias{}).Error; err != nil {
+		return fmt.Errorf("failed to delete payee alias: %w", err)
+	}
+	return nil
+}
+
+func (r *payeeRepository) Merge(ctx context.Context, targetID int64, sourceIDs []int64) ([]entity.Transaction, error) {
+	var moved []entity.Transaction
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if moved, err = movePayeeTransactions(tx, targetID, "payee_id IN ?", sourceIDs); err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.PayeeAlias{}).
+			Where("payee_id IN ?", sourceIDs).
+			Update("payee_id", targetID).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", sourceIDs).Delete(&entity.Payee{}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge payees: %w", err)
+	}
+	return moved, nil
+}
+
+func (r *payeeRepository) Split(ctx context.Context, userID uuid.UUID, sourceID, targetID int64, aliasIDs, transactionIDs []int64) ([]entity.Transaction, error) {
+	var moved []entity.Transaction
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(aliasIDs) > 0 {
+			if err := tx.Model(&entity.PayeeAlias{}).
+				Where("payee_id = ? AND id IN ?", sourceID, aliasIDs).
+				Update("payee_id", targetID).Error; err != nil {
+				return err
+			}
+		}
+		if len(transactionIDs) == 0 {
+			return nil
+		}
+		var err error
+		moved, err = movePayeeTransactions(tx, targetID,
+			"user_id = ? AND payee_id = ? AND id IN ?", userID, sourceID, transactionIDs)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to split payee: %w", err)
+	}
+	return moved, nil
+}
+
+func (r *payeeRepository) GetStats(ctx context.Context, userID uuid.UUID, filter repository.PayeeStatsFilter) ([]repository.PayeeStat, error) {
+	query := r.db.WithContext(ctx).
+		Table("transactions t").
+		Select(`p.id AS payee_id, p.name,
+			COALESCE(SUM(CASE WHEN t.transaction_type = ? THEN t.amount ELSE 0 END), 0) AS total_expense,
+			COALESCE(SUM(CASE WHEN t.transaction_type = ? THEN t.amount ELSE 0 END), 0) AS total_income,
+			COUNT(*) AS transaction_count,
+			MIN(t.transaction_date) AS first_date,
+			MAX(t.transaction_date) AS last_date`,
+			entity.TransactionTypeExpense, entity.TransactionTypeIncome).
+		Joins("JOIN payees p ON p.id = t.payee_id").
+		Where("t.user_id = ? AND t.deleted_at IS NULL", userID)
+
+	if filter.PayeeID != nil {
+		query = query.Where("t.payee_id = ?", *filter.PayeeID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("t.transaction_date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("t.transaction_date <= ?", *filter.EndDate)
+	}
+
+	var stats []repository.PayeeStat
+	if err := query.Group("p.id, p.name").Order("total_expense DESC").Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get payee stats: %w", err)
+	}
+	return stats, nil
+}
+
+// movePayeeTransactions points the live transactions matching the query at
+// payeeID (nil unlinks them) and returns them as they were before, so callers
+// can audit the change
+func movePayeeTransactions(db *gorm.DB, payeeID interface{}, query string, args ...interface{}) ([]entity.Transaction, error) {
+	var moved []entity.Transaction
+	if err := db.Preload("Splits").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(query, args...).
+		Order("id").
+		Find(&moved).Error; err != nil {
+		return nil, err
+	}
+	if len(moved) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(moved))
+	for i := range moved {
+		ids[i] = moved[i].ID
+	}
+	if err := db.Model(&entity.Transaction{}).
+		Where("id IN ?", ids).
+		Updates(reassignPayee(payeeID)).Error; err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
+// reassignPayee is the column update moving transactions to another payee (or
+// none); it advances their version like any other write
+func reassignPayee(payeeID interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"payee_id": payeeID,
+		"version":  gorm.Expr("version + 1"),
+	}
+}
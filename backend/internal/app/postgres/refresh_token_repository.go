@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,7 +34,7 @@ func (r *refreshTokenRepository) FindByToken(ctx context.Context, tokenHash stri
 		Where("token_hash = ? AND revoked = false AND expires_at > ?", tokenHash, time.Now()).
 		First(&token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("refresh token not found or expired")
+			return nil, apperror.NotFound("refresh token not found or expired")
 		}
 		return nil, fmt.Errorf("failed to find refresh token: %w", err)
 	}
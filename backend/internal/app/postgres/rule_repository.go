@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ruleRepository struct {
+	db *gorm.DB
+}
+
+// NewRuleRepository creates a new PostgreSQL implementation of RuleRepository
+func NewRuleRepository(db *gorm.DB) repository.RuleRepository {
+	return &ruleRepository{db: db}
+}
+
+func (r *ruleRepository) Create(ctx context.Context, rule *entity.CategorizationRule) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create rule: %w", err)
+	}
+	return nil
+}
+
+func (r *ruleRepository) FindByID(ctx context.Context, id int64) (*entity.CategorizationRule, error) {
+	var rule entity.CategorizationRule
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&rule).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("rule not found")
+		}
+		return nil, fmt.Errorf("failed to find rule: %w", err)
+	}
+	return &rule, nil
+}
+
+func (r *ruleRepository) FindByUserID(ctx context.Context, userID uuid.UUID, activeOnly bool) ([]entity.CategorizationRule, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rules []entity.CategorizationRule
+	if err := query.Order("priority ASC, id ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to find rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *ruleRepository) Update(ctx context.Context, rule *entity.CategorizationRule) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(rule).Error; err != nil {
+		return fmt.Errorf("failed to update rule: %w", err)
+	}
+	return nil
+}
+
+func (r *ruleRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.CategorizationRule{}).Error; err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return nil
+}
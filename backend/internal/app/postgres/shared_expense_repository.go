@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sharedExpenseRepository struct {
+	db *gorm.DB
+}
+
+// NewSharedExpenseRepository creates a new PostgreSQL implementation of SharedExpenseRepository
+func NewSharedExpenseRepository(db *gorm.DB) repository.SharedExpenseRepository {
+	return &sharedExpenseRepository{db: db}
+}
+
+func (r *sharedExpenseRepository) CreateContact(ctx context.Context, contact *entity.SplitContact) error {
+	if err := r.db.WithContext(ctx).Create(contact).Error; err != nil {
+		return fmt.Errorf("failed to create contact: %w", err)
+	}
+	return nil
+}
+
+func (r *sharedExpenseRepository) FindContactByID(ctx context.Context, id int64) (*entity.SplitContact, error) {
+	var contact entity.SplitContact
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&contact).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("contact not found")
+		}
+		return nil, fmt.Errorf("failed to find contact: %w", err)
+	}
+	return &contact, nil
+}
+
+func (r *sharedExpenseRepository) FindContactByName(ctx context.Context, userID uuid.UUID, name string) (*entity.SplitContact, error) {
+	var contact entity.SplitContact
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND LOWER(name) = LOWER(?)", userID, name).
+		First(&contact).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("contact not found")
+		}
+		return nil, fmt.Errorf("failed to find contact: %w", err)
+	}
+	return &contact, nil
+}
+
+func (r *sharedExpenseRepository) FindContacts(ctx context.Context, userID uuid.UUID) ([]entity.SplitContact, error) {
+	var contacts []entity.SplitContact
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&contacts).Error; err != nil {
+		return nil, fmt.Errorf("failed to find contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+// Create stores the expense together with its shares
+func (r *sharedExpenseRepository) Create(ctx context.Context, expense *entity.SharedExpense) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(expense).Error; err != nil {
+			return fmt.Errorf("failed to create shared expense: %w", err)
+		}
+
+		for i := range expense.Shares {
+			expense.Shares[i].ExpenseID = expense.ID
+			if err := tx.Omit(clause.Associations).Create(&expense.Shares[i]).Error; err != nil {
+				return fmt.Errorf("failed to create share: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *sharedExpenseRepository) FindByID(ctx context.Context, id int64) (*entity.SharedExpense, error) {
+	var expense entity.SharedExpense
+	if err := r.preloadShares(r.db.WithContext(ctx)).Where("id = ?", id).First(&expense).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("shared expense not found")
+		}
+		return nil, fmt.Errorf("failed to find shared expense: %w", err)
+	}
+	return &expense, nil
+}
+
+func (r *sharedExpenseRepository) FindInvolving(ctx context.Context, userID uuid.UUID, limit, offset int) ([]entity.SharedExpense, error) {
+	query := r.involving(r.preloadShares(r.db.WithContext(ctx)), userID).
+		Order("expense_date DESC, id DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	var expenses []entity.SharedExpense
+	if err := query.Find(&expenses).Error; err != nil {
+		return nil, fmt.Errorf("failed to find shared expenses: %w", err)
+	}
+	return expenses, nil
+}
+
+func (r *sharedExpenseRepository) CountInvolving(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.involving(r.db.WithContext(ctx).Model(&entity.SharedExpense{}), userID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count shared expenses: %w", err)
+	}
+	return count, nil
+}
+
+func (r *sharedExpenseRepository) Delete(ctx context.Context, id int64) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.SharedExpense{}).Error; err != nil {
+		return fmt.Errorf("failed to delete shared expense: %w", err)
+	}
+	return nil
+}
+
+func (r *sharedExpenseRepository) CreateSettlement(ctx context.Context, settlement *entity.SplitSettlement) error {
+	if err := r.db.WithContext(ctx).Create(settlement).Error; err != nil {
+		return fmt.Errorf("failed to create settlement: %w", err)
+	}
+	return nil
+}
+
+func (r *sharedExpenseRepository) FindSettlementsInvolving(ctx context.Context, userID uuid.UUID) ([]entity.SplitSettlement, error) {
+	var settlements []entity.SplitSettlement
+	if err := r.db.WithContext(ctx).
+		Where("created_by = ? OR from_user_id = ? OR to_user_id = ?", userID, userID, userID).
+		Order("created_at DESC").
+		Find(&settlements).Error; err != nil {
+		return nil, fmt.Errorf("failed to find settlements: %w", err)
+	}
+	return settlements, nil
+}
+
+func (r *sharedExpenseRepository) preloadShares(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Shares", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Shares.User").
+		Preload("Shares.Contact")
+}
+
+func (r *sharedExpenseRepository) involving(query *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return query.Where(
+		"created_by = ? OR id IN (SELECT expense_id FROM shared_expense_shares WHERE user_id = ?)",
+		userID, userID,
+	)
+}
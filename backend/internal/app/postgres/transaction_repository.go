@@ -2,15 +2,21 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// balanceChangeExpr is the SQL form of entity.BalanceChange
+const balanceChangeExpr = "CASE WHEN transaction_type IN ('Income', 'Adjustment') THEN amount ELSE -amount END"
+
 type transactionRepository struct {
 	db *gorm.DB
 }
@@ -31,34 +37,43 @@ func (r *transactionRepository) FindByID(ctx context.Context, id int64) (*entity
 	var transaction entity.Transaction
 	if err := r.db.WithContext(ctx).
 		Preload("Category").
+		Preload("Payee").
+		Preload("Splits.Category").
 		Where("id = ?", id).
 		First(&transaction).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("transaction not found")
+			return nil, apperror.NotFound("transaction not found")
 		}
 		return nil, fmt.Errorf("failed to find transaction: %w", err)
 	}
 	return &transaction, nil
 }
 
+func (r *transactionRepository) FindByIDs(ctx context.Context, ids []int64) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+	if len(ids) == 0 {
+		return transactions, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Payee").
+		Preload("Splits.Category").
+		Where("id IN ?", ids).
+		Find(&transactions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+	return transactions, nil
+}
+
 func (r *transactionRepository) FindByUserID(ctx context.Context, userID uuid.UUID, filter repository.TransactionFilter) ([]entity.Transaction, error) {
 	query := r.db.WithContext(ctx).
 		Preload("Category").
+		Preload("Payee").
+		Preload("Splits.Category").
 		Where("user_id = ?", userID)
 
 	// Apply filters
-	if filter.TransactionType != nil {
-		query = query.Where("transaction_type = ?", *filter.TransactionType)
-	}
-	if filter.CategoryID != nil {
-		query = query.Where("category_id = ?", *filter.CategoryID)
-	}
-	if filter.StartDate != nil {
-		query = query.Where("transaction_date >= ?", *filter.StartDate)
-	}
-	if filter.EndDate != nil {
-		query = query.Where("transaction_date <= ?", *filter.EndDate)
-	}
+	query = applyTransactionFilter(query, filter)
 
 	// Pagination
 	if filter.Limit > 0 {
@@ -76,31 +91,259 @@ func (r *transactionRepository) FindByUserID(ctx context.Context, userID uuid.UU
 	return transactions, nil
 }
 
+// Update writes the transaction only if its version is still the one it was
+// read with, then advances the version. Otherwise another write got there
+// first and Update returns a stale error without changing anything.
 func (r *transactionRepository) Update(ctx context.Context, transaction *entity.Transaction) error {
-	if err := r.db.WithContext(ctx).Save(transaction).Error; err != nil {
-		return fmt.Errorf("failed to update transaction: %w", err)
+	return updateVersioned(r.db.WithContext(ctx), transaction)
+}
+
+func updateVersioned(db *gorm.DB, transaction *entity.Transaction) error {
+	read := transaction.Version
+	transaction.Version++
+	// Preloaded relationships are read-only views; never write them back
+	result := db.
+		Model(transaction).
+		Where("version = ?", read).
+		Select("*").
+		Omit(clause.Associations, "created_at", "deleted_at").
+		Updates(transaction)
+	if result.Error != nil {
+		transaction.Version = read
+		return fmt.Errorf("failed to update transaction: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		transaction.Version = read
+		return apperror.Stale("transaction was changed by another request")
+	}
+	return nil
+}
+
+func (r *transactionRepository) Delete(ctx context.Context, transaction *entity.Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		return deleteTransaction(db, transaction)
+	})
+}
+
+// deleteTransaction soft-deletes a live transaction still at the version it
+// was read with and reverses its effect on the card balance
+func deleteTransaction(db *gorm.DB, transaction *entity.Transaction) error {
+	result := db.Where("id = ? AND version = ? AND deleted_at IS NULL", transaction.ID, transaction.Version).
+		Delete(&entity.Transaction{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete transaction: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		var live int64
+		if err := db.Model(&entity.Transaction{}).Where("id = ?", transaction.ID).Count(&live).Error; err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+		if live == 0 {
+			return apperror.NotFound("transaction not found")
+		}
+		return apperror.Stale("transaction was changed by another request")
+	}
+	return adjustCardBalance(db, transaction.CardID, -transaction.BalanceChange())
+}
+
+// errBulkAborted rolls back an atomic bulk operation after a failed change
+var errBulkAborted = errors.New("bulk operation aborted")
+
+func (r *transactionRepository) ApplyBulk(ctx context.Context, changes []repository.BulkChange, atomic bool) ([]error, error) {
+	failures := make([]error, len(changes))
+	err := r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		for i, change := range changes {
+			if atomic {
+				if err := applyBulkChange(db, change); err != nil {
+					failures[i] = err
+					return errBulkAborted
+				}
+				continue
+			}
+
+			// Each change gets a savepoint so a failure undoes only itself
+			savepoint := fmt.Sprintf("bulk_%d", i)
+			if err := db.SavePoint(savepoint).Error; err != nil {
+				return err
+			}
+			if err := applyBulkChange(db, change); err != nil {
+				failures[i] = err
+				if err := db.RollbackTo(savepoint).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkAborted) {
+		return nil, fmt.Errorf("failed to apply bulk changes: %w", err)
+	}
+	return failures, nil
+}
+
+func applyBulkChange(db *gorm.DB, change repository.BulkChange) error {
+	switch {
+	case change.Create != nil:
+		if err := db.Create(change.Create).Error; err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		return adjustCardBalance(db, change.Create.CardID, change.Create.BalanceChange())
+	case change.Update != nil:
+		return updateVersioned(db, change.Update)
+	case change.Delete != nil:
+		return deleteTransaction(db, change.Delete)
 	}
 	return nil
 }
 
-func (r *transactionRepository) Delete(ctx context.Context, id int64) error {
-	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Transaction{}).Error; err != nil {
-		return fmt.Errorf("failed to delete transaction: %w", err)
+// adjustCardBalance is cardRepository.UpdateBalance within a database transaction
+func adjustCardBalance(db *gorm.DB, cardID int64, amount int64) error {
+	if err := db.Model(&entity.Card{}).
+		Where("id = ?", cardID).
+		UpdateColumns(map[string]interface{}{
+			"balance": gorm.Expr("balance + ?", amount),
+			"version": gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update card balance: %w", err)
 	}
 	return nil
 }
 
+func (r *transactionRepository) SetBalance(ctx context.Context, cardID int64, target int64, adjustment *entity.Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		// Posts to the card wait until the balance is set
+		var card entity.Card
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", cardID).
+			First(&card).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NotFound("card not found")
+			}
+			return fmt.Errorf("failed to lock card: %w", err)
+		}
+
+		var ledger int64
+		if err := db.Model(&entity.Transaction{}).
+			Select("COALESCE(SUM("+balanceChangeExpr+"), 0)").
+			Where("card_id = ?", cardID).
+			Scan(&ledger).Error; err != nil {
+			return fmt.Errorf("failed to get ledger total: %w", err)
+		}
+
+		adjustment.Amount = target - (card.InitialBalance + ledger)
+		if adjustment.Amount != 0 {
+			if err := db.Create(adjustment).Error; err != nil {
+				return fmt.Errorf("failed to record adjustment: %w", err)
+			}
+		}
+
+		if card.Balance == target {
+			return nil
+		}
+		if err := db.Model(&entity.Card{}).
+			Where("id = ?", cardID).
+			UpdateColumns(map[string]interface{}{
+				"balance": target,
+				"version": gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to set card balance: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *transactionRepository) FindDeletedByID(ctx context.Context, id int64) (*entity.Transaction, error) {
+	var transaction entity.Transaction
+	if err := r.db.WithContext(ctx).Unscoped().
+		Preload("Category").
+		Preload("Payee").
+		Preload("Splits.Category").
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&transaction).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperror.NotFound("transaction not found")
+		}
+		return nil, fmt.Errorf("failed to find transaction: %w", err)
+	}
+	return &transaction, nil
+}
+
+// FindDeletedByUserID returns transactions deleted on their own. Transactions
+// deleted along with their card are listed and restored through the card.
+func (r *transactionRepository) FindDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+	if err := r.db.WithContext(ctx).Unscoped().
+		Preload("Category").
+		Preload("Payee").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Where("card_id IN (SELECT id FROM cards WHERE deleted_at IS NULL)").
+		Order("deleted_at DESC").
+		Find(&transactions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deleted transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (r *transactionRepository) Restore(ctx context.Context, transaction *entity.Transaction) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		result := db.Unscoped().
+			Model(&entity.Transaction{}).
+			Where("id = ? AND deleted_at IS NOT NULL", transaction.ID).
+			UpdateColumn("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore transaction: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.Conflict("transaction is no longer in the trash")
+		}
+		return adjustCardBalance(db, transaction.CardID, transaction.BalanceChange())
+	})
+}
+
+// PurgeDeleted permanently removes transactions deleted before the cutoff
+func (r *transactionRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&entity.Transaction{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted transactions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 func (r *transactionRepository) Count(ctx context.Context, userID uuid.UUID, filter repository.TransactionFilter) (int64, error) {
 	query := r.db.WithContext(ctx).
 		Model(&entity.Transaction{}).
 		Where("user_id = ?", userID)
 
 	// Apply same filters as FindByUserID
+	query = applyTransactionFilter(query, filter)
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	return count, nil
+}
+
+// applyTransactionFilter adds the optional filter conditions to a transaction query
+func applyTransactionFilter(query *gorm.DB, filter repository.TransactionFilter) *gorm.DB {
+	if filter.CardID != nil {
+		query = query.Where("card_id = ?", *filter.CardID)
+	}
 	if filter.TransactionType != nil {
 		query = query.Where("transaction_type = ?", *filter.TransactionType)
 	}
 	if filter.CategoryID != nil {
-		query = query.Where("category_id = ?", *filter.CategoryID)
+		// Split transactions match on any of their split categories
+		query = query.Where(
+			"category_id = ? OR id IN (SELECT transaction_id FROM transaction_splits WHERE category_id = ?)",
+			*filter.CategoryID, *filter.CategoryID,
+		)
+	}
+	if filter.PayeeID != nil {
+		query = query.Where("payee_id = ?", *filter.PayeeID)
 	}
 	if filter.StartDate != nil {
 		query = query.Where("transaction_date >= ?", *filter.StartDate)
@@ -108,13 +351,7 @@ func (r *transactionRepository) Count(ctx context.Context, userID uuid.UUID, fil
 	if filter.EndDate != nil {
 		query = query.Where("transaction_date <= ?", *filter.EndDate)
 	}
-
-	var count int64
-	if err := query.Count(&count).Error; err != nil {
-		return 0, fmt.Errorf("failed to count transactions: %w", err)
-	}
-
-	return count, nil
+	return query
 }
 
 func (r *transactionRepository) GetStats(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) (*repository.TransactionStats, error) {
@@ -147,3 +384,95 @@ func (r *transactionRepository) GetStats(ctx context.Context, userID uuid.UUID,
 
 	return &stats, nil
 }
+
+// GetDailyBalanceChanges also counts the transactions deleted along with their
+// card, whose balance still reflects them
+func (r *transactionRepository) GetDailyBalanceChanges(ctx context.Context, userID uuid.UUID, after time.Time) ([]repository.DailyBalanceChange, error) {
+	var changes []repository.DailyBalanceChange
+	if err := r.db.WithContext(ctx).Unscoped().
+		Model(&entity.Transaction{}).
+		Select("card_id, transaction_date, SUM("+balanceChangeExpr+") AS change").
+		Where("user_id = ? AND transaction_date > ?", userID, after).
+		Where("deleted_at IS NULL OR deleted_at = (SELECT deleted_at FROM cards WHERE cards.id = transactions.card_id)").
+		Group("card_id, transaction_date").
+		Order("transaction_date DESC").
+		Scan(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get daily balance changes: %w", err)
+	}
+	return changes, nil
+}
+
+func (r *transactionRepository) GetCardLedgerTotals(ctx context.Context, userID uuid.UUID) ([]repository.CardLedgerTotal, error) {
+	var totals []repository.CardLedgerTotal
+	if err := r.db.WithContext(ctx).
+		Model(&entity.Transaction{}).
+		Select("card_id, SUM("+balanceChangeExpr+") AS total, COUNT(*) AS transaction_count").
+		Where("user_id = ?", userID).
+		Group("card_id").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to get card ledger totals: %w", err)
+	}
+	return totals, nil
+}
+
+func (r *transactionRepository) GetCategoryStats(ctx context.Context, userID uuid.UUID, filter repository.CategoryStatsFilter) ([]repository.CategoryStat, error) {
+	conditions := "t.user_id = ? AND t.deleted_at IS NULL"
+	args := []interface{}{userID}
+
+	if filter.TransactionType != nil {
+		conditions += " AND t.transaction_type = ?"
+		args = append(args, *filter.TransactionType)
+	}
+	if filter.StartDate != nil {
+		conditions += " AND t.transaction_date >= ?"
+		args = append(args, *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		conditions += " AND t.transaction_date <= ?"
+		args = append(args, *filter.EndDate)
+	}
+
+	// Unsplit transactions count once under their own category; split
+	// transactions count each split line under the split's category.
+	query := `
+		SELECT category_id, SUM(amount) AS total, COUNT(*) AS count FROM (
+			SELECT t.category_id, t.amount
+			FROM transactions t
+			WHERE ` + conditions + `
+				AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id)
+			UNION ALL
+			SELECT s.category_id, s.amount
+			FROM transaction_splits s
+			JOIN transactions t ON t.id = s.transaction_id
+			WHERE ` + conditions + `
+		) lines
+		GROUP BY category_id
+		ORDER BY total DESC`
+
+	var stats []repository.CategoryStat
+	if err := r.db.WithContext(ctx).Raw(query, append(args, args...)...).Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category stats: %w", err)
+	}
+	return stats, nil
+}
+
+// GetTrainingSamples returns every categorized transaction of a user. Split
+// transactions yield one sample per split line.
+func (r *transactionRepository) GetTrainingSamples(ctx context.Context, userID uuid.UUID) ([]repository.TrainingSample, error) {
+	query := `
+		SELECT t.description, t.amount, t.category_id
+		FROM transactions t
+		WHERE t.user_id = ? AND t.deleted_at IS NULL AND t.category_id IS NOT NULL AND t.description <> ''
+			AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = t.id)
+		UNION ALL
+		SELECT t.description, s.amount, s.category_id
+		FROM transaction_splits s
+		JOIN transactions t ON t.id = s.transaction_id
+		WHERE t.user_id = ? AND t.deleted_at IS NULL AND t.description <> ''`
+
+	var samples []repository.TrainingSample
+	if err := r.db.WithContext(ctx).Raw(query, userID, userID).Scan(&samples).Error; err != nil {
+		return nil, fmt.Errorf("failed to get training samples: %w", err)
+	}
+	return samples, nil
+}
@@ -2,9 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -21,6 +25,9 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return apperror.Conflict("user with this email already exists")
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 	return nil
@@ -30,7 +37,7 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Us
 	var user entity.User
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperror.NotFound("user not found")
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -41,7 +48,7 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 	var user entity.User
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user not found")
+			return nil, apperror.NotFound("user not found")
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
@@ -49,12 +56,28 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 }
 
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
-	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+	// Sessions are only revoked through RevokeSessions, so a stale copy of the
+	// user cannot undo a forced logout
+	if err := r.db.WithContext(ctx).Omit("SessionsValidAfter").Save(user).Error; err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 	return nil
 }
 
+func (r *userRepository) RevokeSessions(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		UpdateColumn("sessions_valid_after", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NotFound("user not found")
+	}
+	return nil
+}
+
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.User{}).Error; err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
@@ -72,3 +95,53 @@ func (r *userRepository) Exists(ctx context.Context, email string) (bool, error)
 	}
 	return count > 0, nil
 }
+
+func (r *userRepository) FindActiveIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("is_active = ?", true).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find active users: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *userRepository) Search(ctx context.Context, filter repository.UserFilter) ([]entity.User, error) {
+	var users []entity.User
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&entity.User{}), filter).Order("email ASC")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	if err := query.Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	return users, nil
+}
+
+func (r *userRepository) Count(ctx context.Context, filter repository.UserFilter) (int64, error) {
+	var count int64
+	if err := r.applyFilter(r.db.WithContext(ctx).Model(&entity.User{}), filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+func (r *userRepository) applyFilter(query *gorm.DB, filter repository.UserFilter) *gorm.DB {
+	if filter.Query != "" {
+		pattern := "%" + strings.ToLower(filter.Query) + "%"
+		query = query.Where("LOWER(email) LIKE ? OR LOWER(first_name) LIKE ? OR LOWER(last_name) LIKE ?", pattern, pattern, pattern)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	return query
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new PostgreSQL implementation of WebhookRepository
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	if err := r.db.WithContext(ctx).Create(webhook).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("webhook not found")
+		}
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) FindSubscribed(ctx context.Context, userID uuid.UUID, eventType string) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Where("events @> ?::jsonb", fmt.Sprintf("[%q]", eventType)).
+		Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to find subscribed webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	if err := r.db.WithContext(ctx).Save(webhook).Error; err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&entity.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		if err := tx.Delete(&entity.Webhook{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	if err := r.db.WithContext(ctx).Preload("Webhook").Where("id = ?", id).First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("delivery not found")
+		}
+		return nil, fmt.Errorf("failed to find delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) FindDeliveries(ctx context.Context, webhookID int64, filter repository.DeliveryFilter) ([]entity.WebhookDelivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var deliveries []entity.WebhookDelivery
+	if err := query.Order("created_at DESC, id DESC").Find(&deliveries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to find deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+func (r *webhookRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several API instances drain the outbox side by side
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return fmt.Errorf("failed to find due deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		if err := tx.Model(&entity.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error; err != nil {
+			return fmt.Errorf("failed to lease deliveries: %w", err)
+		}
+
+		return tx.Preload("Webhook").Where("id IN ?", ids).Order("next_attempt_at ASC, id ASC").Find(&deliveries).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+	return nil
+}
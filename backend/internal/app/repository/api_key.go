@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	FindByID(ctx context.Context, id int64) (*entity.APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.APIKey, error)
+	TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogRepository defines the interface for audit trail data access. The
+// trail is append-only, so there is no update or delete.
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *entity.AuditLog) error
+	Find(ctx context.Context, filter AuditLogFilter) ([]entity.AuditLog, error)
+	Count(ctx context.Context, filter AuditLogFilter) (int64, error)
+}
+
+// AuditLogFilter narrows the audit trail; zero values match everything
+type AuditLogFilter struct {
+	UserID     *uuid.UUID
+	ActorID    *uuid.UUID
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BillRepository defines the interface for bill data access
+type BillRepository interface {
+	Create(ctx context.Context, bill *entity.Bill) error
+	FindByID(ctx context.Context, id int64) (*entity.Bill, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Bill, error)
+	// FindDue returns active bills of all users due on or before the given date
+	FindDue(ctx context.Context, before time.Time) ([]entity.Bill, error)
+	Update(ctx context.Context, bill *entity.Bill) error
+	Delete(ctx context.Context, id int64) error
+	// RecordPayment stores the payment of the occurrence due on payment.DueDate
+	// and saves the bill moved past it, in one database transaction. It returns
+	// Conflict when that occurrence is no longer due.
+	RecordPayment(ctx context.Context, bill *entity.Bill, payment *entity.BillPayment) error
+}
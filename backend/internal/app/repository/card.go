@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"pfn-backend/internal/app/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,8 +13,16 @@ type CardRepository interface {
 	Create(ctx context.Context, card *entity.Card) error
 	FindByID(ctx context.Context, id int64) (*entity.Card, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Card, error)
+	FindByHouseholdID(ctx context.Context, householdID int64) ([]entity.Card, error)
+	FindSharedWithUser(ctx context.Context, userID uuid.UUID) ([]entity.Card, error)
 	Update(ctx context.Context, card *entity.Card) error
-	Delete(ctx context.Context, id int64) error
+	// Delete moves the card and its transactions to the trash if the card is
+	// still at version; otherwise it returns a stale error.
+	Delete(ctx context.Context, id int64, version int64) error
+	FindDeletedByID(ctx context.Context, id int64) (*entity.Card, error)
+	FindDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Card, error)
+	Restore(ctx context.Context, id int64) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 	UpdateBalance(ctx context.Context, id int64, amount int64) error
 	ToggleFreeze(ctx context.Context, id int64) error
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"pfn-backend/internal/app/entity"
+	"time"
 )
 
 // CategoryRepository defines the interface for category data access
@@ -10,4 +11,15 @@ type CategoryRepository interface {
 	FindAll(ctx context.Context) ([]entity.Category, error)
 	FindByType(ctx context.Context, categoryType string) ([]entity.Category, error)
 	FindByID(ctx context.Context, id int64) (*entity.Category, error)
+	GetUsage(ctx context.Context) ([]CategoryUsage, error)
+}
+
+// CategoryUsage aggregates how a category is used across all users. Split
+// lines count under their own category.
+type CategoryUsage struct {
+	CategoryID       int64
+	TransactionCount int64
+	UserCount        int64
+	Total            int64
+	LastUsedAt       *time.Time
 }
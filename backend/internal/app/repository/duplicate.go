@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DuplicateCandidateFilter selects transactions that could duplicate a new one
+type DuplicateCandidateFilter struct {
+	CardID          int64
+	Amount          int64
+	TransactionType string
+	From            time.Time
+	To              time.Time
+}
+
+// DuplicatePair is two transactions on the same card with the same amount
+// and type posted close together. FirstID is the lower ID.
+type DuplicatePair struct {
+	FirstID  int64
+	SecondID int64
+}
+
+// DuplicateRepository defines the interface for duplicate detection data access
+type DuplicateRepository interface {
+	FindCandidates(ctx context.Context, userID uuid.UUID, filter DuplicateCandidateFilter) ([]entity.Transaction, error)
+	// FindPairs returns undismissed pairs posted at most windowDays apart
+	FindPairs(ctx context.Context, userID uuid.UUID, windowDays int) ([]DuplicatePair, error)
+	Dismiss(ctx context.Context, dismissal *entity.DuplicateDismissal) error
+}
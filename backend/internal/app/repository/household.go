@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+
+	"github.com/google/uuid"
+)
+
+// HouseholdRepository defines the interface for household, membership and
+// invitation data access
+type HouseholdRepository interface {
+	Create(ctx context.Context, household *entity.Household) error
+	FindByID(ctx context.Context, id int64) (*entity.Household, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Household, error)
+	Update(ctx context.Context, household *entity.Household) error
+	Delete(ctx context.Context, id int64) error
+
+	FindMember(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.HouseholdMember, error)
+	AddMember(ctx context.Context, member *entity.HouseholdMember) error
+	UpdateMember(ctx context.Context, member *entity.HouseholdMember) error
+	// RemoveMember deletes the membership and unshares the member's cards
+	// from the household in one transaction
+	RemoveMember(ctx context.Context, householdID int64, userID uuid.UUID) error
+
+	CreateInvitation(ctx context.Context, invitation *entity.HouseholdInvitation) error
+	FindInvitationByID(ctx context.Context, id int64) (*entity.HouseholdInvitation, error)
+	FindInvitationsByHouseholdID(ctx context.Context, householdID int64) ([]entity.HouseholdInvitation, error)
+	FindInvitationsByEmail(ctx context.Context, email string) ([]entity.HouseholdInvitation, error)
+	UpdateInvitation(ctx context.Context, invitation *entity.HouseholdInvitation) error
+	DeleteInvitation(ctx context.Context, id int64) error
+}
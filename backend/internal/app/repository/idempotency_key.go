@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key data access
+type IdempotencyKeyRepository interface {
+	// Reserve inserts the key, taking over an expired row with the same key.
+	// It reports false when a live row for the user and key already exists.
+	Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error)
+	FindByKey(ctx context.Context, userID uuid.UUID, key string) (*entity.IdempotencyKey, error)
+	Complete(ctx context.Context, id int64, statusCode int, body []byte) error
+	Delete(ctx context.Context, id int64) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
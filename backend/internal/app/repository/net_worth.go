@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetWorthRepository defines the interface for net worth snapshot data access
+type NetWorthRepository interface {
+	Save(ctx context.Context, snapshot *entity.NetWorthSnapshot) error
+	FindByUserID(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.NetWorthSnapshot, error)
+}
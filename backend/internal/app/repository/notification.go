@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+
+	"github.com/google/uuid"
+)
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entity.Notification) error
+	FindByUserID(ctx context.Context, userID uuid.UUID, filter NotificationFilter) ([]entity.Notification, error)
+	Count(ctx context.Context, userID uuid.UUID, filter NotificationFilter) (int64, error)
+	// SetRead marks the given notifications, or all of the user's when ids is
+	// empty, as read or unread and returns how many changed
+	SetRead(ctx context.Context, userID uuid.UUID, ids []int64, read bool) (int64, error)
+}
+
+// NotificationFilter contains notification query filters
+type NotificationFilter struct {
+	UnreadOnly bool
+	Type       string
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayeeStatsFilter contains filtering parameters for payee analytics
+type PayeeStatsFilter struct {
+	PayeeID   *int64
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// PayeeStat contains spending totals for a single payee
+type PayeeStat struct {
+	PayeeID          int64
+	Name             string
+	TotalExpense     int64
+	TotalIncome      int64
+	TransactionCount int64
+	FirstDate        time.Time
+	LastDate         time.Time
+}
+
+// PayeeRepository defines the interface for payee data access
+type PayeeRepository interface {
+	Create(ctx context.Context, payee *entity.Payee) error
+	FindByID(ctx context.Context, id int64) (*entity.Payee, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Payee, error)
+	// FindOrCreate returns the user's payee with the same normalized name, creating it if needed
+	FindOrCreate(ctx context.Context, payee *entity.Payee) error
+	Update(ctx context.Context, payee *entity.Payee) error
+	// Delete removes a payee and its aliases; its transactions are unlinked and
+	// returned as they were before
+	Delete(ctx context.Context, id int64) ([]entity.Transaction, error)
+	CreateAlias(ctx context.Context, alias *entity.PayeeAlias) error
+	DeleteAlias(ctx context.Context, id int64) error
+	// Merge moves the transactions and aliases of the source payees to the target and deletes the sources.
+	// It returns the moved transactions as they were before.
+	Merge(ctx context.Context, targetID int64, sourceIDs []int64) ([]entity.Transaction, error)
+	// Split moves the given aliases and transactions of the source payee to the
+	// target in one transaction, skipping any that belong elsewhere, and returns
+	// the moved transactions as they were before
+	Split(ctx context.Context, userID uuid.UUID, sourceID, targetID int64, aliasIDs, transactionIDs []int64) ([]entity.Transaction, error)
+	GetStats(ctx context.Context, userID uuid.UUID, filter PayeeStatsFilter) ([]PayeeStat, error)
+}
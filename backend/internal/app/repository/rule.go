@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+
+	"github.com/google/uuid"
+)
+
+// RuleRepository defines the interface for categorization rule data access
+type RuleRepository interface {
+	Create(ctx context.Context, rule *entity.CategorizationRule) error
+	FindByID(ctx context.Context, id int64) (*entity.CategorizationRule, error)
+	// FindByUserID returns the user's rules in evaluation order
+	FindByUserID(ctx context.Context, userID uuid.UUID, activeOnly bool) ([]entity.CategorizationRule, error)
+	Update(ctx context.Context, rule *entity.CategorizationRule) error
+	Delete(ctx context.Context, id int64) error
+}
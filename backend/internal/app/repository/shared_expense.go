@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+
+	"github.com/google/uuid"
+)
+
+// SharedExpenseRepository defines the interface for shared expense, contact
+// and settlement data access
+type SharedExpenseRepository interface {
+	CreateContact(ctx context.Context, contact *entity.SplitContact) error
+	FindContactByID(ctx context.Context, id int64) (*entity.SplitContact, error)
+	FindContactByName(ctx context.Context, userID uuid.UUID, name string) (*entity.SplitContact, error)
+	FindContacts(ctx context.Context, userID uuid.UUID) ([]entity.SplitContact, error)
+
+	Create(ctx context.Context, expense *entity.SharedExpense) error
+	FindByID(ctx context.Context, id int64) (*entity.SharedExpense, error)
+	// FindInvolving returns expenses the user created or shares in, newest
+	// first; a zero limit returns all of them
+	FindInvolving(ctx context.Context, userID uuid.UUID, limit, offset int) ([]entity.SharedExpense, error)
+	CountInvolving(ctx context.Context, userID uuid.UUID) (int64, error)
+	Delete(ctx context.Context, id int64) error
+
+	CreateSettlement(ctx context.Context, settlement *entity.SplitSettlement) error
+	// FindSettlementsInvolving returns settlements the user recorded or is a party to
+	FindSettlementsInvolving(ctx context.Context, userID uuid.UUID) ([]entity.SplitSettlement, error)
+}
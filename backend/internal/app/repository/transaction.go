@@ -10,8 +10,10 @@ import (
 
 // TransactionFilter contains filtering parameters for transactions
 type TransactionFilter struct {
+	CardID          *int64
 	TransactionType *string
 	CategoryID      *int64
+	PayeeID         *int64
 	StartDate       *time.Time
 	EndDate         *time.Time
 	Limit           int
@@ -26,13 +28,82 @@ type TransactionStats struct {
 	Count         int64
 }
 
+// CategoryStatsFilter contains filtering parameters for category analytics
+type CategoryStatsFilter struct {
+	TransactionType *string
+	StartDate       *time.Time
+	EndDate         *time.Time
+}
+
+// CategoryStat contains the total amount spent or earned in a category. Split
+// transactions contribute each split line to its own category.
+type CategoryStat struct {
+	CategoryID *int64
+	Total      int64
+	Count      int64
+}
+
+// TrainingSample is a categorized description used to learn category suggestions
+type TrainingSample struct {
+	Description string
+	Amount      int64
+	CategoryID  int64
+}
+
+// DailyBalanceChange is the net balance change of a card on a single day
+type DailyBalanceChange struct {
+	CardID          int64
+	TransactionDate time.Time
+	Change          int64
+}
+
+// CardLedgerTotal is the net balance change of all transactions on a card
+type CardLedgerTotal struct {
+	CardID           int64
+	Total            int64
+	TransactionCount int64
+}
+
+// BulkChange is one write of a bulk operation; exactly one field is set.
+// Creates and deletes also move the card balance.
+type BulkChange struct {
+	Create *entity.Transaction
+	Update *entity.Transaction
+	Delete *entity.Transaction
+}
+
 // TransactionRepository defines the interface for transaction data access
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *entity.Transaction) error
 	FindByID(ctx context.Context, id int64) (*entity.Transaction, error)
+	FindByIDs(ctx context.Context, ids []int64) ([]entity.Transaction, error)
 	FindByUserID(ctx context.Context, userID uuid.UUID, filter TransactionFilter) ([]entity.Transaction, error)
 	Update(ctx context.Context, transaction *entity.Transaction) error
-	Delete(ctx context.Context, id int64) error
+	// Delete moves the transaction to the trash and reverses its effect on the
+	// card balance, in one database transaction. It returns NotFound when the
+	// transaction is already deleted and a stale error when it is no longer at
+	// transaction.Version.
+	Delete(ctx context.Context, transaction *entity.Transaction) error
+	// ApplyBulk writes the changes and their balance effects in one database
+	// transaction and returns the error of each failed change by index. When
+	// atomic, the first failure rolls back every change; otherwise only the
+	// failed changes are rolled back.
+	ApplyBulk(ctx context.Context, changes []BulkChange, atomic bool) ([]error, error)
+	// SetBalance sets the card balance to target under a row lock. In the same
+	// database transaction it sizes adjustment so the initial balance plus the
+	// ledger matches target, and records it unless its amount is zero.
+	SetBalance(ctx context.Context, cardID int64, target int64, adjustment *entity.Transaction) error
+	FindDeletedByID(ctx context.Context, id int64) (*entity.Transaction, error)
+	FindDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Transaction, error)
+	// Restore brings the transaction back from the trash and re-applies it to
+	// the card balance, in one database transaction. It returns Conflict when
+	// the transaction is no longer in the trash.
+	Restore(ctx context.Context, transaction *entity.Transaction) error
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 	Count(ctx context.Context, userID uuid.UUID, filter TransactionFilter) (int64, error)
 	GetStats(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) (*TransactionStats, error)
+	GetDailyBalanceChanges(ctx context.Context, userID uuid.UUID, after time.Time) ([]DailyBalanceChange, error)
+	GetCardLedgerTotals(ctx context.Context, userID uuid.UUID) ([]CardLedgerTotal, error)
+	GetCategoryStats(ctx context.Context, userID uuid.UUID, filter CategoryStatsFilter) ([]CategoryStat, error)
+	GetTrainingSamples(ctx context.Context, userID uuid.UUID) ([]TrainingSample, error)
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"pfn-backend/internal/app/entity"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +14,20 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) error
+	// RevokeSessions makes access tokens of the user issued before at invalid
+	RevokeSessions(ctx context.Context, id uuid.UUID, at time.Time) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	Exists(ctx context.Context, email string) (bool, error)
+	FindActiveIDs(ctx context.Context) ([]uuid.UUID, error)
+	Search(ctx context.Context, filter UserFilter) ([]entity.User, error)
+	Count(ctx context.Context, filter UserFilter) (int64, error)
+}
+
+// UserFilter narrows the admin user search. Query matches email and names.
+type UserFilter struct {
+	Query    string
+	Role     string
+	IsActive *bool
+	Limit    int
+	Offset   int
 }
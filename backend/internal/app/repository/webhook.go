@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRepository defines the interface for webhook and delivery data access
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Webhook, error)
+	// FindSubscribed returns the user's active webhooks subscribed to an event type
+	FindSubscribed(ctx context.Context, userID uuid.UUID, eventType string) ([]entity.Webhook, error)
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	Delete(ctx context.Context, id int64) error
+
+	CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) error
+	FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	FindDeliveries(ctx context.Context, webhookID int64, filter DeliveryFilter) ([]entity.WebhookDelivery, int64, error)
+	// ClaimDue locks up to limit pending deliveries due at now, pushes their
+	// next attempt to leaseUntil so no other worker picks them up, and returns
+	// them with their webhook
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
+
+// DeliveryFilter contains delivery log query filters
+type DeliveryFilter struct {
+	Status string
+	Limit  int
+	Offset int
+}
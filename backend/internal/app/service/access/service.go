@@ -0,0 +1,60 @@
+package access
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/google/uuid"
+)
+
+// Action is something a user wants to do with a card
+type Action int
+
+const (
+	// ActionView covers reading the card and its transactions
+	ActionView Action = iota
+	// ActionPost covers posting transactions to the card
+	ActionPost
+	// ActionManage covers editing, freezing, sharing and deleting the card
+	ActionManage
+)
+
+// Service decides who may do what with a card. Owners may do anything;
+// members of the household a card is shared into may view it, and owners
+// and editors of that household may also post to it.
+type Service interface {
+	AuthorizeCard(ctx context.Context, userID uuid.UUID, card *entity.Card, action Action) error
+}
+
+type service struct {
+	householdRepo repository.HouseholdRepository
+}
+
+func NewService(householdRepo repository.HouseholdRepository) Service {
+	return &service{
+		householdRepo: householdRepo,
+	}
+}
+
+func (s *service) AuthorizeCard(ctx context.Context, userID uuid.UUID, card *entity.Card, action Action) error {
+	if card.UserID == userID {
+		return nil
+	}
+
+	if card.HouseholdID == nil || action == ActionManage {
+		return apperror.Forbidden("unauthorized access to card")
+	}
+
+	member, err := s.householdRepo.FindMember(ctx, *card.HouseholdID, userID)
+	if err != nil {
+		return apperror.Forbidden("unauthorized access to card")
+	}
+
+	if action == ActionPost && !member.CanPost() {
+		return apperror.Forbidden("unauthorized access to card")
+	}
+
+	return nil
+}
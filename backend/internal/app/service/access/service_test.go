@@ -0,0 +1,70 @@
+package access_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memberRepository is a HouseholdRepository that only knows memberships
+type memberRepository struct {
+	repository.HouseholdRepository
+	members []entity.HouseholdMember
+}
+
+func (r *memberRepository) FindMember(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.HouseholdMember, error) {
+	for i := range r.members {
+		if r.members[i].HouseholdID == householdID && r.members[i].UserID == userID {
+			return &r.members[i], nil
+		}
+	}
+	return nil, assert.AnError
+}
+
+func TestAuthorizeCard(t *testing.T) {
+	ctx := context.Background()
+	owner, editor, viewer, stranger := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	householdID := int64(7)
+
+	svc := access.NewService(&memberRepository{members: []entity.HouseholdMember{
+		{HouseholdID: householdID, UserID: owner, Role: entity.HouseholdRoleOwner},
+		{HouseholdID: householdID, UserID: editor, Role: entity.HouseholdRoleEditor},
+		{HouseholdID: householdID, UserID: viewer, Role: entity.HouseholdRoleViewer},
+	}})
+
+	private := &entity.Card{ID: 1, UserID: owner}
+	shared := &entity.Card{ID: 2, UserID: owner, HouseholdID: &householdID}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		card    *entity.Card
+		action  access.Action
+		allowed bool
+	}{
+		{"owner manages own card", owner, private, access.ActionManage, true},
+		{"editor cannot see private card", editor, private, access.ActionView, false},
+		{"editor views shared card", editor, shared, access.ActionView, true},
+		{"editor posts to shared card", editor, shared, access.ActionPost, true},
+		{"editor cannot manage shared card", editor, shared, access.ActionManage, false},
+		{"viewer views shared card", viewer, shared, access.ActionView, true},
+		{"viewer cannot post to shared card", viewer, shared, access.ActionPost, false},
+		{"non-member cannot view shared card", stranger, shared, access.ActionView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.AuthorizeCard(ctx, tt.userID, tt.card, tt.action)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "unauthorized access to card")
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserQuery filters the admin user list
+type UserQuery struct {
+	Query    string `form:"q"`
+	Role     string `form:"role" binding:"omitempty,oneof=user admin support"`
+	IsActive *bool  `form:"is_active"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset   int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// RoleRequest changes a user's role
+type RoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user admin support"`
+}
+
+// UserResponse is a user as seen by administrators
+type UserResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	FullName  string    `json:"full_name"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserListResponse is a page of users
+type UserListResponse struct {
+	Users  []UserResponse `json:"users"`
+	Total  int64          `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// CategoryUsageResponse summarizes how a system category is used
+type CategoryUsageResponse struct {
+	CategoryID       int64      `json:"category_id"`
+	Name             string     `json:"name"`
+	CategoryType     string     `json:"category_type"`
+	Icon             string     `json:"icon"`
+	TransactionCount int64      `json:"transaction_count"`
+	UserCount        int64      `json:"user_count"`
+	Total            int64      `json:"total"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+}
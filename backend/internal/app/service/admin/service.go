@@ -0,0 +1,261 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	ListUsers(ctx context.Context, query UserQuery) (*UserListResponse, error)
+	GetUser(ctx context.Context, id uuid.UUID) (*UserResponse, error)
+	DeactivateUser(ctx context.Context, actorID, id uuid.UUID) (*UserResponse, error)
+	ReactivateUser(ctx context.Context, actorID, id uuid.UUID) (*UserResponse, error)
+	SetRole(ctx context.Context, actorID, id uuid.UUID, req RoleRequest) (*UserResponse, error)
+	ForceLogout(ctx context.Context, id uuid.UUID) error
+	GetCategoryUsage(ctx context.Context) ([]CategoryUsageResponse, error)
+}
+
+type service struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	categoryRepo     repository.CategoryRepository
+	auditor          audit.Recorder
+	logger           *logger.Logger
+}
+
+func NewService(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	categoryRepo repository.CategoryRepository,
+	auditor audit.Recorder,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		categoryRepo:     categoryRepo,
+		auditor:          auditor,
+		logger:           logger,
+	}
+}
+
+func (s *service) ListUsers(ctx context.Context, query UserQuery) (*UserListResponse, error) {
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	filter := repository.UserFilter{
+		Query:    query.Query,
+		Role:     query.Role,
+		IsActive: query.IsActive,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+
+	users, err := s.userRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	responses := make([]UserResponse, len(users))
+	for i := range users {
+		responses[i] = *toResponse(&users[i])
+	}
+
+	return &UserListResponse{
+		Users:  responses,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}, nil
+}
+
+func (s *service) GetUser(ctx context.Context, id uuid.UUID) (*UserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(user), nil
+}
+
+// DeactivateUser blocks login, token refresh and API key use, and signs the
+// user out everywhere. Administrators cannot deactivate themselves.
+func (s *service) DeactivateUser(ctx context.Context, actorID, id uuid.UUID) (*UserResponse, error) {
+	if actorID == id {
+		return nil, apperror.Forbidden("you cannot deactivate your own account")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsActive {
+		before := audit.User(user)
+		user.IsActive = false
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to deactivate user: %w", err)
+		}
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     user.ID,
+			ActorID:    actorID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   user.ID,
+			Before:     before,
+			After:      audit.User(user),
+		})
+	}
+
+	if err := s.ForceLogout(ctx, id); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("User deactivated",
+		logger.String("user_id", id.String()),
+		logger.String("actor_id", actorID.String()),
+	)
+
+	return toResponse(user), nil
+}
+
+func (s *service) ReactivateUser(ctx context.Context, actorID, id uuid.UUID) (*UserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		before := audit.User(user)
+		user.IsActive = true
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to reactivate user: %w", err)
+		}
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     user.ID,
+			ActorID:    actorID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   user.ID,
+			Before:     before,
+			After:      audit.User(user),
+		})
+	}
+
+	return toResponse(user), nil
+}
+
+// SetRole changes a user's role. Requests are authorized with the stored
+// role, so the change applies to existing sessions at once. They are signed
+// out too, so no token keeps carrying the old role.
+func (s *service) SetRole(ctx context.Context, actorID, id uuid.UUID, req RoleRequest) (*UserResponse, error) {
+	if actorID == id && req.Role != entity.RoleAdmin {
+		return nil, apperror.Forbidden("you cannot remove your own admin role")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != req.Role {
+		before := audit.User(user)
+		user.Role = req.Role
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update role: %w", err)
+		}
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     user.ID,
+			ActorID:    actorID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityUser,
+			EntityID:   user.ID,
+			Before:     before,
+			After:      audit.User(user),
+		})
+
+		if err := s.ForceLogout(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return toResponse(user), nil
+}
+
+// ForceLogout refuses the access tokens issued to the user so far and revokes
+// every refresh token, ending all of the user's sessions.
+func (s *service) ForceLogout(ctx context.Context, id uuid.UUID) error {
+	if err := s.userRepo.RevokeSessions(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeByUserID(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) GetCategoryUsage(ctx context.Context) ([]CategoryUsageResponse, error) {
+	usage, err := s.categoryRepo.GetUsage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category usage: %w", err)
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	byID := make(map[int64]*entity.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+
+	responses := make([]CategoryUsageResponse, 0, len(usage))
+	for _, u := range usage {
+		category, ok := byID[u.CategoryID]
+		if !ok || !category.IsSystem {
+			continue
+		}
+
+		responses = append(responses, CategoryUsageResponse{
+			CategoryID:       u.CategoryID,
+			Name:             category.Name,
+			CategoryType:     category.CategoryType,
+			Icon:             category.Icon,
+			TransactionCount: u.TransactionCount,
+			UserCount:        u.UserCount,
+			Total:            u.Total,
+			LastUsedAt:       u.LastUsedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+func toResponse(user *entity.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		FullName:  user.FullName(),
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+	}
+}
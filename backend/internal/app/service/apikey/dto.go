@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateKeyRequest contains data for a new API key
+type CreateKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=transactions:read transactions:write cards:read cards:write"`
+	ExpiresInDays *int     `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // default 90
+}
+
+// KeyResponse contains API key metadata. Key holds the secret and is only
+// returned when the key is created.
+type KeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Key        string     `json:"key,omitempty"`
+}
+
+// Identity is the caller behind a valid API key
+type Identity struct {
+	KeyID  int64
+	UserID uuid.UUID
+	Scopes []string
+}
+
+// HasScope reports whether the key was granted scope
+func (i *Identity) HasScope(scope string) bool {
+	for _, granted := range i.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/pkg/password"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// KeyPrefix marks a bearer credential as an API key rather than a JWT
+	KeyPrefix = "pfn_"
+	// displayPrefixLength is how much of the key is kept in clear for display
+	displayPrefixLength = len(KeyPrefix) + 8
+	// defaultExpiryDays applies when a key is created without an expiry
+	defaultExpiryDays = 90
+	// lastUsedResolution limits last-used writes to one per key per interval
+	lastUsedResolution = time.Minute
+)
+
+type Service interface {
+	CreateKey(ctx context.Context, userID uuid.UUID, req CreateKeyRequest) (*KeyResponse, error)
+	GetKeys(ctx context.Context, userID uuid.UUID) ([]KeyResponse, error)
+	RevokeKey(ctx context.Context, keyID int64, userID uuid.UUID) error
+	// Authenticate resolves a raw key to its owner and scopes
+	Authenticate(ctx context.Context, rawKey string) (*Identity, error)
+}
+
+type service struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+	logger     *logger.Logger
+}
+
+func NewService(
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		logger:     logger,
+	}
+}
+
+func (s *service) CreateKey(ctx context.Context, userID uuid.UUID, req CreateKeyRequest) (*KeyResponse, error) {
+	rawKey, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	days := defaultExpiryDays
+	if req.ExpiresInDays != nil {
+		days = *req.ExpiresInDays
+	}
+	expiresAt := time.Now().AddDate(0, 0, days)
+
+	key := &entity.APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    rawKey[:displayPrefixLength],
+		KeyHash:   password.HashToken(rawKey),
+		Scopes:    strings.Join(uniqueScopes(req.Scopes), " "),
+		ExpiresAt: &expiresAt,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	resp := toResponse(key)
+	resp.Key = rawKey
+	return &resp, nil
+}
+
+func (s *service) GetKeys(ctx context.Context, userID uuid.UUID) ([]KeyResponse, error) {
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	responses := make([]KeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = toResponse(&keys[i])
+	}
+	return responses, nil
+}
+
+func (s *service) RevokeKey(ctx context.Context, keyID int64, userID uuid.UUID) error {
+	key, err := s.apiKeyRepo.FindByID(ctx, keyID)
+	if err != nil {
+		return err
+	}
+
+	if key.UserID != userID {
+		return apperror.Forbidden("unauthorized access to api key")
+	}
+
+	if err := s.apiKeyRepo.Delete(ctx, keyID); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, rawKey string) (*Identity, error) {
+	if !strings.HasPrefix(rawKey, KeyPrefix) {
+		return nil, apperror.Unauthorized("invalid api key")
+	}
+
+	key, err := s.apiKeyRepo.FindByHash(ctx, password.HashToken(rawKey))
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid api key")
+	}
+
+	now := time.Now()
+	if key.IsExpired(now) {
+		return nil, apperror.Unauthorized("api key has expired")
+	}
+
+	// Deactivated users lose API access along with their sessions
+	user, err := s.userRepo.FindByID(ctx, key.UserID)
+	if err != nil || !user.IsActive {
+		return nil, apperror.Unauthorized("account is deactivated")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			s.logger.Error("Failed to record api key usage", logger.Error(err))
+		}
+	}
+
+	return &Identity{
+		KeyID:  key.ID,
+		UserID: key.UserID,
+		Scopes: key.ScopeList(),
+	}, nil
+}
+
+func generateKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return KeyPrefix + hex.EncodeToString(b), nil
+}
+
+func uniqueScopes(scopes []string) []string {
+	unique := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
+
+func toResponse(key *entity.APIKey) KeyResponse {
+	return KeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditQuery filters a user's own audit history
+type AuditQuery struct {
+	EntityType string     `form:"entity_type" binding:"omitempty,oneof=card transaction category user"`
+	EntityID   string     `form:"entity_id" binding:"omitempty,max=64"`
+	Action     string     `form:"action" binding:"omitempty,oneof=create update delete restore"`
+	StartDate  *time.Time `form:"start_date" binding:"omitempty"`
+	EndDate    *time.Time `form:"end_date" binding:"omitempty"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+// AdminAuditQuery filters the whole audit trail
+type AdminAuditQuery struct {
+	AuditQuery
+	UserID    string `form:"user_id" binding:"omitempty,uuid"`
+	ActorID   string `form:"actor_id" binding:"omitempty,uuid"`
+	RequestID string `form:"request_id" binding:"omitempty,max=64"`
+}
+
+// AuditEntryResponse is one audit trail entry
+type AuditEntryResponse struct {
+	ID         int64           `json:"id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	ActorID    *uuid.UUID      `json:"actor_id,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestID  string          `json:"request_id,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditListResponse is a page of audit entries, newest first
+type AuditListResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Total   int64                `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
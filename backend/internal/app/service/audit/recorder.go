@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Change describes one create, update, delete or restore to record. Before is
+// nil for creates and restores and After for deletes; both should be snapshots from this package
+// so every entry for an entity type has the same shape.
+type Change struct {
+	UserID     uuid.UUID // whose data changed
+	ActorID    uuid.UUID // who changed it; uuid.Nil for system jobs
+	Action     string
+	EntityType string
+	EntityID   interface{}
+	Before     interface{}
+	After      interface{}
+}
+
+// Recorder appends changes to the audit trail. The request ID and client IP
+// are taken from the context. Recording never fails the caller: the change
+// has already happened, so errors are logged instead.
+type Recorder interface {
+	Record(ctx context.Context, change Change)
+}
+
+type recorder struct {
+	auditRepo repository.AuditLogRepository
+	logger    *logger.Logger
+}
+
+// NewRecorder creates a Recorder that stores entries in the database
+func NewRecorder(auditRepo repository.AuditLogRepository, logger *logger.Logger) Recorder {
+	return &recorder{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+func (r *recorder) Record(ctx context.Context, change Change) {
+	requestID, _ := ctx.Value(logger.RequestIDKey).(string)
+	clientIP, _ := ctx.Value(logger.ClientIPKey).(string)
+	entry := &entity.AuditLog{
+		UserID:     change.UserID,
+		Action:     change.Action,
+		EntityType: change.EntityType,
+		EntityID:   fmt.Sprint(change.EntityID),
+		RequestID:  requestID,
+		IPAddress:  clientIP,
+	}
+	if change.ActorID != uuid.Nil {
+		actorID := change.ActorID
+		entry.ActorID = &actorID
+	}
+
+	var err error
+	if entry.Before, err = marshalSnapshot(change.Before); err == nil {
+		entry.After, err = marshalSnapshot(change.After)
+	}
+	if err == nil {
+		// Outlive the request so a cancelled client cannot drop the entry
+		err = r.auditRepo.Create(context.WithoutCancel(ctx), entry)
+	}
+
+	if err != nil {
+		r.logger.Error("Failed to record audit log",
+			logger.String("entity_type", entry.EntityType),
+			logger.String("entity_id", entry.EntityID),
+			logger.String("action", entry.Action),
+			logger.String("request_id", entry.RequestID),
+			logger.Error(err),
+		)
+	}
+}
+
+func marshalSnapshot(snapshot interface{}) (*string, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
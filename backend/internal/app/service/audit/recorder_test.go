@@ -0,0 +1,96 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository is an AuditLogRepository that keeps entries in memory
+type memoryRepository struct {
+	repository.AuditLogRepository
+	entries []entity.AuditLog
+	filter  repository.AuditLogFilter
+}
+
+func (r *memoryRepository) Create(ctx context.Context, entry *entity.AuditLog) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func newRecorder(t *testing.T, repo *memoryRepository) audit.Recorder {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return audit.NewRecorder(repo, log)
+}
+
+func TestRecorder_Record(t *testing.T) {
+	repo := &memoryRepository{}
+	recorder := newRecorder(t, repo)
+
+	owner, actor := uuid.New(), uuid.New()
+	card := &entity.Card{ID: 42, UserID: owner, CardNumber: "4111111111111111", CardNumberLast4: "1111", Alias: "Daily"}
+	before := audit.Card(card)
+	card.Alias = "Groceries"
+
+	ctx := context.WithValue(context.Background(), logger.RequestIDKey, "req-1")
+	ctx = context.WithValue(ctx, logger.ClientIPKey, "203.0.113.7")
+	recorder.Record(ctx, audit.Change{
+		UserID:     owner,
+		ActorID:    actor,
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityCard,
+		EntityID:   card.ID,
+		Before:     before,
+		After:      audit.Card(card),
+	})
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Equal(t, owner, entry.UserID)
+	require.NotNil(t, entry.ActorID)
+	assert.Equal(t, actor, *entry.ActorID)
+	assert.Equal(t, "42", entry.EntityID)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, "203.0.113.7", entry.IPAddress)
+
+	require.NotNil(t, entry.Before)
+	require.NotNil(t, entry.After)
+	var beforeJSON, afterJSON map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(*entry.Before), &beforeJSON))
+	require.NoError(t, json.Unmarshal([]byte(*entry.After), &afterJSON))
+	assert.Equal(t, "Daily", beforeJSON["alias"])
+	assert.Equal(t, "Groceries", afterJSON["alias"])
+	assert.NotContains(t, *entry.After, "4111111111111111", "full card numbers are never recorded")
+}
+
+func TestRecorder_SystemChangeWithoutRequest(t *testing.T) {
+	repo := &memoryRepository{}
+	recorder := newRecorder(t, repo)
+
+	owner := uuid.New()
+	var missing *entity.Transaction
+	recorder.Record(context.Background(), audit.Change{
+		UserID:     owner,
+		Action:     entity.AuditActionCreate,
+		EntityType: entity.AuditEntityTransaction,
+		EntityID:   int64(7),
+		Before:     audit.Transaction(missing),
+		After:      audit.Transaction(&entity.Transaction{ID: 7, UserID: owner, Amount: 500}),
+	})
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	assert.Nil(t, entry.ActorID, "system changes have no actor")
+	assert.Nil(t, entry.Before, "a nil snapshot is stored as NULL")
+	assert.Empty(t, entry.RequestID)
+	assert.Empty(t, entry.IPAddress)
+}
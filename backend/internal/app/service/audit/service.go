@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// GetUserLog returns changes to the user's own data, whoever made them
+	GetUserLog(ctx context.Context, userID uuid.UUID, query AuditQuery) (*AuditListResponse, error)
+	// GetLog returns the whole trail for administrators
+	GetLog(ctx context.Context, query AdminAuditQuery) (*AuditListResponse, error)
+}
+
+type service struct {
+	auditRepo repository.AuditLogRepository
+}
+
+func NewService(auditRepo repository.AuditLogRepository) Service {
+	return &service{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *service) GetUserLog(ctx context.Context, userID uuid.UUID, query AuditQuery) (*AuditListResponse, error) {
+	filter := toFilter(query)
+	filter.UserID = &userID
+	return s.list(ctx, filter)
+}
+
+func (s *service) GetLog(ctx context.Context, query AdminAuditQuery) (*AuditListResponse, error) {
+	filter := toFilter(query.AuditQuery)
+	filter.RequestID = query.RequestID
+
+	if query.UserID != "" {
+		userID, err := uuid.Parse(query.UserID)
+		if err != nil {
+			return nil, apperror.Validation("invalid user_id").WithField("user_id", "must be a valid UUID")
+		}
+		filter.UserID = &userID
+	}
+	if query.ActorID != "" {
+		actorID, err := uuid.Parse(query.ActorID)
+		if err != nil {
+			return nil, apperror.Validation("invalid actor_id").WithField("actor_id", "must be a valid UUID")
+		}
+		filter.ActorID = &actorID
+	}
+
+	return s.list(ctx, filter)
+}
+
+func (s *service) list(ctx context.Context, filter repository.AuditLogFilter) (*AuditListResponse, error) {
+	entries, err := s.auditRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+
+	total, err := s.auditRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit log: %w", err)
+	}
+
+	responses := make([]AuditEntryResponse, 0, len(entries))
+	for i := range entries {
+		responses = append(responses, toResponse(&entries[i]))
+	}
+
+	return &AuditListResponse{
+		Entries: responses,
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}, nil
+}
+
+func toFilter(query AuditQuery) repository.AuditLogFilter {
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+	return repository.AuditLogFilter{
+		Action:     query.Action,
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		StartDate:  query.StartDate,
+		EndDate:    query.EndDate,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	}
+}
+
+func toResponse(entry *entity.AuditLog) AuditEntryResponse {
+	response := AuditEntryResponse{
+		ID:         entry.ID,
+		UserID:     entry.UserID,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		RequestID:  entry.RequestID,
+		IPAddress:  entry.IPAddress,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.Before != nil {
+		response.Before = json.RawMessage(*entry.Before)
+	}
+	if entry.After != nil {
+		response.After = json.RawMessage(*entry.After)
+	}
+	return response
+}
@@ -0,0 +1,171 @@
+package audit_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Find and Count apply the user, actor, request and entity filters like the
+// database, and remember the last filter they were given
+func (r *memoryRepository) Find(ctx context.Context, filter repository.AuditLogFilter) ([]entity.AuditLog, error) {
+	r.filter = filter
+	var entries []entity.AuditLog
+	for _, entry := range r.entries {
+		if matches(entry, filter) {
+			entries = append(entries, entry)
+		}
+	}
+	if filter.Offset >= len(entries) {
+		return nil, nil
+	}
+	entries = entries[filter.Offset:]
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+	return entries, nil
+}
+
+func (r *memoryRepository) Count(ctx context.Context, filter repository.AuditLogFilter) (int64, error) {
+	var count int64
+	for _, entry := range r.entries {
+		if matches(entry, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func matches(entry entity.AuditLog, filter repository.AuditLogFilter) bool {
+	switch {
+	case filter.UserID != nil && entry.UserID != *filter.UserID:
+		return false
+	case filter.ActorID != nil && (entry.ActorID == nil || *entry.ActorID != *filter.ActorID):
+		return false
+	case filter.RequestID != "" && entry.RequestID != filter.RequestID:
+		return false
+	case filter.EntityType != "" && entry.EntityType != filter.EntityType:
+		return false
+	case filter.Action != "" && entry.Action != filter.Action:
+		return false
+	}
+	return true
+}
+
+// newTrail holds three entries: Ann editing card 7, support editing the same
+// card, and Bob deleting transaction 9 of Bob's own
+func newTrail(ann, bob, support uuid.UUID) *memoryRepository {
+	before, after := `{"alias":"Daily"}`, `{"alias":"Groceries"}`
+	return &memoryRepository{entries: []entity.AuditLog{
+		{ID: 1, UserID: ann, ActorID: &ann, Action: entity.AuditActionUpdate, EntityType: entity.AuditEntityCard, EntityID: "7", RequestID: "req-1", Before: &before, After: &after},
+		{ID: 2, UserID: ann, ActorID: &support, Action: entity.AuditActionUpdate, EntityType: entity.AuditEntityCard, EntityID: "7", RequestID: "req-2"},
+		{ID: 3, UserID: bob, ActorID: &bob, Action: entity.AuditActionDelete, EntityType: entity.AuditEntityTransaction, EntityID: "9", RequestID: "req-3"},
+	}}
+}
+
+func entryIDs(resp *audit.AuditListResponse) []int64 {
+	var ids []int64
+	for _, entry := range resp.Entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestGetUserLog(t *testing.T) {
+	ann, bob, support := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		query     audit.AuditQuery
+		wantIDs   []int64
+		wantTotal int64
+	}{
+		{"changes to your data by anyone", ann, audit.AuditQuery{}, []int64{1, 2}, 2},
+		{"filtered by entity type", ann, audit.AuditQuery{EntityType: entity.AuditEntityTransaction}, nil, 0},
+		{"filters cannot reach other users", ann, audit.AuditQuery{Action: entity.AuditActionDelete}, nil, 0},
+		{"another user sees only their own", bob, audit.AuditQuery{}, []int64{3}, 1},
+		{"paged", ann, audit.AuditQuery{Limit: 1, Offset: 1}, []int64{2}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTrail(ann, bob, support)
+			service := audit.NewService(repo)
+
+			resp, err := service.GetUserLog(context.Background(), tt.userID, tt.query)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantIDs, entryIDs(resp))
+			assert.Equal(t, tt.wantTotal, resp.Total)
+			require.NotNil(t, repo.filter.UserID)
+			assert.Equal(t, tt.userID, *repo.filter.UserID)
+		})
+	}
+}
+
+func TestGetUserLog_Response(t *testing.T) {
+	ann := uuid.New()
+	service := audit.NewService(newTrail(ann, uuid.New(), uuid.New()))
+
+	resp, err := service.GetUserLog(context.Background(), ann, audit.AuditQuery{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 20, resp.Limit, "limit defaults to 20")
+	require.Len(t, resp.Entries, 2)
+	assert.JSONEq(t, `{"alias":"Daily"}`, string(resp.Entries[0].Before))
+	assert.JSONEq(t, `{"alias":"Groceries"}`, string(resp.Entries[0].After))
+	assert.Nil(t, resp.Entries[1].Before)
+	assert.Equal(t, "req-1", resp.Entries[0].RequestID)
+}
+
+func TestGetLog(t *testing.T) {
+	ann, bob, support := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		query     audit.AdminAuditQuery
+		wantIDs   []int64
+		wantErr   apperror.Code
+		wantField string
+	}{
+		{name: "whole trail", query: audit.AdminAuditQuery{}, wantIDs: []int64{1, 2, 3}},
+		{name: "by user", query: audit.AdminAuditQuery{UserID: bob.String()}, wantIDs: []int64{3}},
+		{name: "by actor", query: audit.AdminAuditQuery{ActorID: support.String()}, wantIDs: []int64{2}},
+		{name: "by request", query: audit.AdminAuditQuery{RequestID: "req-1"}, wantIDs: []int64{1}},
+		{
+			name:    "by user and entity type",
+			query:   audit.AdminAuditQuery{UserID: ann.String(), AuditQuery: audit.AuditQuery{EntityType: entity.AuditEntityTransaction}},
+			wantIDs: nil,
+		},
+		{name: "invalid user", query: audit.AdminAuditQuery{UserID: "ann"}, wantErr: apperror.CodeValidation, wantField: "user_id"},
+		{name: "invalid actor", query: audit.AdminAuditQuery{ActorID: "support"}, wantErr: apperror.CodeValidation, wantField: "actor_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := audit.NewService(newTrail(ann, bob, support))
+
+			resp, err := service.GetLog(context.Background(), tt.query)
+			if tt.wantErr != "" {
+				appErr, ok := apperror.As(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantErr, appErr.Code)
+				require.Len(t, appErr.Fields, 1)
+				assert.Equal(t, tt.wantField, appErr.Fields[0].Field)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantIDs, entryIDs(resp))
+			assert.Equal(t, int64(len(tt.wantIDs)), resp.Total)
+		})
+	}
+}
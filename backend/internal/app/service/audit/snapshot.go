@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"pfn-backend/internal/app/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CardSnapshot is the audited state of a card. The full card number is
+// never recorded.
+type CardSnapshot struct {
+	ID              int64     `json:"id"`
+	UserID          uuid.UUID `json:"user_id"`
+	AccountType     string    `json:"account_type"`
+	CardNumberLast4 string    `json:"card_number_last4,omitempty"`
+	HolderName      string    `json:"holder_name,omitempty"`
+	ExpiryDate      string    `json:"expiry_date,omitempty"`
+	CardType        string    `json:"card_type,omitempty"`
+	Alias           string    `json:"alias"`
+	Balance         int64     `json:"balance"`
+	CreditLimit     *int64    `json:"credit_limit,omitempty"`
+	Color           string    `json:"color"`
+	IsFrozen        bool      `json:"is_frozen"`
+	HouseholdID     *int64    `json:"household_id,omitempty"`
+}
+
+// Card snapshots a card
+func Card(card *entity.Card) *CardSnapshot {
+	if card == nil {
+		return nil
+	}
+	return &CardSnapshot{
+		ID:              card.ID,
+		UserID:          card.UserID,
+		AccountType:     card.AccountType,
+		CardNumberLast4: card.CardNumberLast4,
+		HolderName:      card.HolderName,
+		ExpiryDate:      card.ExpiryDate,
+		CardType:        card.CardType,
+		Alias:           card.Alias,
+		Balance:         card.Balance,
+		CreditLimit:     card.CreditLimit,
+		Color:           card.Color,
+		IsFrozen:        card.IsFrozen,
+		HouseholdID:     card.HouseholdID,
+	}
+}
+
+// TransactionSnapshot is the audited state of a transaction
+type TransactionSnapshot struct {
+	ID              int64           `json:"id"`
+	UserID          uuid.UUID       `json:"user_id"`
+	CardID          int64           `json:"card_id"`
+	CategoryID      *int64          `json:"category_id,omitempty"`
+	PayeeID         *int64          `json:"payee_id,omitempty"`
+	TransactionType string          `json:"transaction_type"`
+	Amount          int64           `json:"amount"`
+	TransactionDate time.Time       `json:"transaction_date"`
+	Description     string          `json:"description,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+	Splits          []SplitSnapshot `json:"splits,omitempty"`
+	CreatedBy       *uuid.UUID      `json:"created_by,omitempty"`
+}
+
+// SplitSnapshot is one split line of an audited transaction
+type SplitSnapshot struct {
+	CategoryID int64  `json:"category_id"`
+	Amount     int64  `json:"amount"`
+	Note       string `json:"note,omitempty"`
+}
+
+// Transaction snapshots a transaction with its split lines
+func Transaction(tx *entity.Transaction) *TransactionSnapshot {
+	if tx == nil {
+		return nil
+	}
+	snapshot := &TransactionSnapshot{
+		ID:              tx.ID,
+		UserID:          tx.UserID,
+		CardID:          tx.CardID,
+		CategoryID:      tx.CategoryID,
+		PayeeID:         tx.PayeeID,
+		TransactionType: tx.TransactionType,
+		Amount:          tx.Amount,
+		TransactionDate: tx.TransactionDate,
+		Description:     tx.Description,
+		Tags:            append([]string(nil), tx.Tags...),
+		CreatedBy:       tx.CreatedBy,
+	}
+	for _, split := range tx.Splits {
+		snapshot.Splits = append(snapshot.Splits, SplitSnapshot{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Note:       split.Note,
+		})
+	}
+	return snapshot
+}
+
+// UserSnapshot is the audited state of a user. Credentials are never
+// recorded; a password change shows up as PasswordChanged.
+type UserSnapshot struct {
+	ID              uuid.UUID `json:"id"`
+	Email           string    `json:"email"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Role            string    `json:"role"`
+	IsActive        bool      `json:"is_active"`
+	PasswordChanged bool      `json:"password_changed,omitempty"`
+}
+
+// User snapshots a user
+func User(user *entity.User) *UserSnapshot {
+	if user == nil {
+		return nil
+	}
+	return &UserSnapshot{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+	}
+}
@@ -14,6 +14,10 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+
+	// Client details for the sign-in notice, set by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 // RefreshTokenRequest contains refresh token
@@ -60,6 +64,7 @@ type UserData struct {
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
 	FullName  string    `json:"full_name"`
+	Role      string    `json:"role"`
 	IsActive  bool      `json:"is_active"`
 }
 
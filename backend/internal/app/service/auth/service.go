@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
 	"pfn-backend/internal/pkg/jwt"
 	"pfn-backend/internal/pkg/logger"
 	"pfn-backend/internal/pkg/password"
@@ -27,6 +30,8 @@ type service struct {
 	userRepo         repository.UserRepository
 	refreshTokenRepo repository.RefreshTokenRepository
 	jwtManager       *jwt.JWTManager
+	publisher        events.Publisher
+	auditor          audit.Recorder
 	logger           *logger.Logger
 }
 
@@ -34,12 +39,16 @@ func NewService(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
 	jwtManager *jwt.JWTManager,
+	publisher events.Publisher,
+	auditor audit.Recorder,
 	logger *logger.Logger,
 ) Service {
 	return &service{
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
 		jwtManager:       jwtManager,
+		publisher:        publisher,
+		auditor:          auditor,
 		logger:           logger,
 	}
 }
@@ -48,7 +57,8 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*AuthRespo
 	// Validate password strength
 	strength := password.ValidateStrength(req.Password)
 	if !strength.IsValid {
-		return nil, fmt.Errorf("password is too weak: %s", strength.Feedback[0])
+		return nil, apperror.Validation("password is too weak: %s", strength.Feedback[0]).
+			WithField("password", strength.Feedback[0])
 	}
 
 	// Check if user already exists
@@ -57,7 +67,7 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*AuthRespo
 		return nil, fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if exists {
-		return nil, fmt.Errorf("user with this email already exists")
+		return nil, apperror.Conflict("user with this email already exists")
 	}
 
 	// Hash password
@@ -73,15 +83,24 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*AuthRespo
 		PasswordHash: hashedPassword,
 		FirstName:    req.FirstName,
 		LastName:     req.LastName,
+		Role:         entity.RoleUser,
 		IsActive:     true,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     user.ID,
+		ActorID:    user.ID,
+		Action:     entity.AuditActionCreate,
+		EntityType: entity.AuditEntityUser,
+		EntityID:   user.ID,
+		After:      audit.User(user),
+	})
 
 	// Generate tokens
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
+	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
@@ -106,6 +125,7 @@ func (s *service) Register(ctx context.Context, req RegisterRequest) (*AuthRespo
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			FullName:  user.FullName(),
+			Role:      user.Role,
 			IsActive:  user.IsActive,
 		},
 		AccessToken:  tokenPair.AccessToken,
@@ -118,21 +138,21 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*AuthResponse, e
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, apperror.Unauthorized("invalid email or password")
 	}
 
 	// Check if user is active
 	if !user.IsActive {
-		return nil, fmt.Errorf("account is deactivated")
+		return nil, apperror.Forbidden("account is deactivated")
 	}
 
 	// Verify password
 	if err := password.Verify(req.Password, user.PasswordHash); err != nil {
-		return nil, fmt.Errorf("invalid email or password")
+		return nil, apperror.Unauthorized("invalid email or password")
 	}
 
 	// Generate tokens
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
+	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
@@ -150,6 +170,11 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*AuthResponse, e
 		s.logger.Error("Failed to store refresh token", logger.Error(err))
 	}
 
+	s.publisher.Publish(ctx, events.New(events.UserLoggedIn, user.ID, map[string]interface{}{
+		"ip_address": req.IPAddress,
+		"user_agent": req.UserAgent,
+	}))
+
 	return &AuthResponse{
 		User: UserData{
 			ID:        user.ID,
@@ -157,6 +182,7 @@ func (s *service) Login(ctx context.Context, req LoginRequest) (*AuthResponse, e
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			FullName:  user.FullName(),
+			Role:      user.Role,
 			IsActive:  user.IsActive,
 		},
 		AccessToken:  tokenPair.AccessToken,
@@ -169,24 +195,24 @@ func (s *service) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*T
 	// Validate refresh token
 	claims, err := s.jwtManager.ValidateRefreshToken(req.RefreshToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid refresh token: %w", err)
+		return nil, apperror.Unauthorized("invalid refresh token: %v", err)
 	}
 
 	// Check if token exists and is not revoked
 	refreshTokenHash := password.HashToken(req.RefreshToken)
 	storedToken, err := s.refreshTokenRepo.FindByToken(ctx, refreshTokenHash)
 	if err != nil {
-		return nil, fmt.Errorf("refresh token not found or expired")
+		return nil, apperror.Unauthorized("refresh token not found or expired")
 	}
 
 	// Get user
 	user, err := s.userRepo.FindByID(ctx, claims.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, apperror.Unauthorized("user not found")
 	}
 
 	if !user.IsActive {
-		return nil, fmt.Errorf("account is deactivated")
+		return nil, apperror.Forbidden("account is deactivated")
 	}
 
 	// Revoke old refresh token
@@ -195,7 +221,7 @@ func (s *service) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*T
 	}
 
 	// Generate new tokens
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
+	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Role)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
@@ -262,19 +288,20 @@ func (s *service) ResetPassword(ctx context.Context, req ResetPasswordRequest) (
 	// Validate reset token
 	claims, err := s.jwtManager.ValidateResetToken(req.Token)
 	if err != nil {
-		return nil, fmt.Errorf("invalid or expired reset token")
+		return nil, apperror.Unauthorized("invalid or expired reset token")
 	}
 
 	// Validate new password strength
 	strength := password.ValidateStrength(req.NewPassword)
 	if !strength.IsValid {
-		return nil, fmt.Errorf("password is too weak: %s", strength.Feedback[0])
+		return nil, apperror.Validation("password is too weak: %s", strength.Feedback[0]).
+			WithField("new_password", strength.Feedback[0])
 	}
 
 	// Get user
 	user, err := s.userRepo.FindByID(ctx, claims.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, apperror.NotFound("user not found")
 	}
 
 	// Hash new password
@@ -288,12 +315,27 @@ func (s *service) ResetPassword(ctx context.Context, req ResetPasswordRequest) (
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update password: %w", err)
 	}
+	after := audit.User(user)
+	after.PasswordChanged = true
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     user.ID,
+		ActorID:    user.ID,
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityUser,
+		EntityID:   user.ID,
+		Before:     audit.User(user),
+		After:      after,
+	})
 
 	// Revoke all refresh tokens for security
 	if err := s.refreshTokenRepo.RevokeByUserID(ctx, user.ID); err != nil {
 		s.logger.Error("Failed to revoke tokens after password reset", logger.Error(err))
 	}
 
+	s.publisher.Publish(ctx, events.New(events.PasswordChanged, user.ID, map[string]interface{}{
+		"via": "reset",
+	}))
+
 	return &MessageResponse{
 		Message: "Password has been reset successfully",
 	}, nil
@@ -303,18 +345,19 @@ func (s *service) ChangePassword(ctx context.Context, userID uuid.UUID, req Chan
 	// Get user
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found")
+		return apperror.NotFound("user not found")
 	}
 
 	// Verify old password
 	if err := password.Verify(req.OldPassword, user.PasswordHash); err != nil {
-		return fmt.Errorf("invalid current password")
+		return apperror.Validation("invalid current password").WithField("old_password", "is incorrect")
 	}
 
 	// Validate new password strength
 	strength := password.ValidateStrength(req.NewPassword)
 	if !strength.IsValid {
-		return fmt.Errorf("password is too weak: %s", strength.Feedback[0])
+		return apperror.Validation("password is too weak: %s", strength.Feedback[0]).
+			WithField("new_password", strength.Feedback[0])
 	}
 
 	// Hash new password
@@ -328,11 +371,26 @@ func (s *service) ChangePassword(ctx context.Context, userID uuid.UUID, req Chan
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
+	after := audit.User(user)
+	after.PasswordChanged = true
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     user.ID,
+		ActorID:    userID,
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityUser,
+		EntityID:   user.ID,
+		Before:     audit.User(user),
+		After:      after,
+	})
 
 	// Revoke all refresh tokens for security
 	if err := s.refreshTokenRepo.RevokeByUserID(ctx, userID); err != nil {
 		s.logger.Error("Failed to revoke tokens after password change", logger.Error(err))
 	}
 
+	s.publisher.Publish(ctx, events.New(events.PasswordChanged, userID, map[string]interface{}{
+		"via": "change",
+	}))
+
 	return nil
 }
@@ -0,0 +1,91 @@
+package bill
+
+import "time"
+
+// DateLayout is the format of bill dates
+const DateLayout = "2006-01-02"
+
+// Occurrence status constants
+const (
+	StatusUpcoming = "upcoming"
+	StatusDueSoon  = "due_soon"
+	StatusOverdue  = "overdue"
+	StatusPaid     = "paid"
+)
+
+// BillRequest contains bill data for create and update
+type BillRequest struct {
+	Name             string `json:"name" binding:"required,max=100"`
+	Amount           int64  `json:"amount" binding:"required,min=1"`
+	CardID           *int64 `json:"card_id"` // account the bill is usually paid from
+	CategoryID       *int64 `json:"category_id"`
+	DueDate          string `json:"due_date" binding:"required"` // YYYY-MM-DD
+	Recurrence       string `json:"recurrence" binding:"required,oneof=none weekly monthly quarterly yearly"`
+	RemindDaysBefore *int   `json:"remind_days_before" binding:"omitempty,min=0,max=30"`
+	IsActive         *bool  `json:"is_active"`
+}
+
+// PayBillRequest marks the current occurrence as paid. Either link an
+// existing transaction or let the bill create an expense on CardID.
+type PayBillRequest struct {
+	TransactionID *int64 `json:"transaction_id"`
+	CardID        *int64 `json:"card_id"`
+	PaidDate      string `json:"paid_date"` // YYYY-MM-DD, defaults to today
+}
+
+// UpcomingQuery contains calendar parameters
+type UpcomingQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=366"`
+}
+
+// PaymentResponse contains a paid occurrence of a bill
+type PaymentResponse struct {
+	ID            int64     `json:"id"`
+	DueDate       string    `json:"due_date"`
+	TransactionID *int64    `json:"transaction_id,omitempty"`
+	Amount        int64     `json:"amount"`
+	PaidAt        time.Time `json:"paid_at"`
+}
+
+// BillResponse contains bill data
+type BillResponse struct {
+	ID               int64             `json:"id"`
+	Name             string            `json:"name"`
+	Amount           int64             `json:"amount"`
+	CardID           *int64            `json:"card_id,omitempty"`
+	CategoryID       *int64            `json:"category_id,omitempty"`
+	DueDate          string            `json:"due_date"`
+	Recurrence       string            `json:"recurrence"`
+	RemindDaysBefore int               `json:"remind_days_before"`
+	IsActive         bool              `json:"is_active"`
+	Status           string            `json:"status"`
+	DaysUntilDue     int               `json:"days_until_due"`
+	Payments         []PaymentResponse `json:"payments,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+}
+
+// Occurrence is a single due date of a bill
+type Occurrence struct {
+	BillID       int64  `json:"bill_id"`
+	Name         string `json:"name"`
+	Amount       int64  `json:"amount"`
+	DueDate      string `json:"due_date"`
+	Status       string `json:"status"`
+	DaysUntilDue int    `json:"days_until_due"`
+}
+
+// CalendarDay groups the occurrences due on one day
+type CalendarDay struct {
+	Date  string       `json:"date"`
+	Total int64        `json:"total"`
+	Bills []Occurrence `json:"bills"`
+}
+
+// UpcomingResponse is a calendar of bills due in a window, plus overdue bills
+type UpcomingResponse struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	Overdue  []Occurrence  `json:"overdue"`
+	Days     []CalendarDay `json:"days"`
+	TotalDue int64         `json:"total_due"` // overdue plus due in the window
+}
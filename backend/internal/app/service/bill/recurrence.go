@@ -0,0 +1,51 @@
+package bill
+
+import (
+	"pfn-backend/internal/app/entity"
+	"time"
+)
+
+// NextDueDate returns the occurrence after due. Month-based recurrences fall
+// on anchorDay, clamped to the last day of shorter months, so a bill due on
+// the 31st is due on Feb 28 and again on Mar 31. A one-off bill has no next
+// occurrence and due is returned unchanged.
+func NextDueDate(due time.Time, recurrence string, anchorDay int) time.Time {
+	switch recurrence {
+	case entity.RecurrenceWeekly:
+		return due.AddDate(0, 0, 7)
+	case entity.RecurrenceMonthly:
+		return addMonths(due, 1, anchorDay)
+	case entity.RecurrenceQuarterly:
+		return addMonths(due, 3, anchorDay)
+	case entity.RecurrenceYearly:
+		return addMonths(due, 12, anchorDay)
+	default:
+		return due
+	}
+}
+
+// Occurrences returns the due dates of a bill from its next due date up to and including to
+func Occurrences(bill *entity.Bill, to time.Time) []time.Time {
+	var dates []time.Time
+	for due := bill.DueDate; !due.After(to); due = NextDueDate(due, bill.Recurrence, bill.AnchorDay) {
+		dates = append(dates, due)
+		if bill.Recurrence == entity.RecurrenceNone || bill.Recurrence == "" {
+			break
+		}
+	}
+	return dates
+}
+
+func addMonths(t time.Time, months, anchorDay int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, months, 0)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	day := anchorDay
+	if day <= 0 {
+		day = t.Day()
+	}
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,63 @@
+package bill_test
+
+import (
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/service/bill"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNextDueDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		due        time.Time
+		recurrence string
+		anchorDay  int
+		want       time.Time
+	}{
+		{"weekly", date(2026, time.January, 29), entity.RecurrenceWeekly, 29, date(2026, time.February, 5)},
+		{"monthly clamps to month end", date(2026, time.January, 31), entity.RecurrenceMonthly, 31, date(2026, time.February, 28)},
+		{"monthly returns to anchor day", date(2026, time.February, 28), entity.RecurrenceMonthly, 31, date(2026, time.March, 31)},
+		{"quarterly", date(2026, time.November, 30), entity.RecurrenceQuarterly, 30, date(2027, time.February, 28)},
+		{"yearly leap day", date(2024, time.February, 29), entity.RecurrenceYearly, 29, date(2025, time.February, 28)},
+		{"one-off does not move", date(2026, time.March, 1), entity.RecurrenceNone, 1, date(2026, time.March, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, bill.NextDueDate(tt.due, tt.recurrence, tt.anchorDay))
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	t.Run("monthly bill within range", func(t *testing.T) {
+		b := &entity.Bill{DueDate: date(2026, time.January, 15), Recurrence: entity.RecurrenceMonthly, AnchorDay: 15}
+
+		dates := bill.Occurrences(b, date(2026, time.March, 20))
+
+		assert.Equal(t, []time.Time{
+			date(2026, time.January, 15),
+			date(2026, time.February, 15),
+			date(2026, time.March, 15),
+		}, dates)
+	})
+
+	t.Run("one-off bill occurs once", func(t *testing.T) {
+		b := &entity.Bill{DueDate: date(2026, time.January, 15), Recurrence: entity.RecurrenceNone}
+
+		assert.Len(t, bill.Occurrences(b, date(2026, time.December, 31)), 1)
+	})
+
+	t.Run("bill due after range", func(t *testing.T) {
+		b := &entity.Bill{DueDate: date(2026, time.May, 1), Recurrence: entity.RecurrenceWeekly}
+
+		assert.Empty(t, bill.Occurrences(b, date(2026, time.April, 30)))
+	})
+}
@@ -0,0 +1,466 @@
+package bill
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultRemindDaysBefore is used when a bill does not set its own reminder window
+	defaultRemindDaysBefore = 3
+	// defaultUpcomingDays is the calendar window when none is requested
+	defaultUpcomingDays = 30
+	// maxRemindDaysBefore bounds the lookahead of the reminder job
+	maxRemindDaysBefore = 30
+)
+
+type Service interface {
+	CreateBill(ctx context.Context, userID uuid.UUID, req BillRequest) (*BillResponse, error)
+	GetBills(ctx context.Context, userID uuid.UUID) ([]BillResponse, error)
+	GetBill(ctx context.Context, billID int64, userID uuid.UUID) (*BillResponse, error)
+	UpdateBill(ctx context.Context, billID int64, userID uuid.UUID, req BillRequest) (*BillResponse, error)
+	DeleteBill(ctx context.Context, billID int64, userID uuid.UUID) error
+	PayBill(ctx context.Context, billID int64, userID uuid.UUID, req PayBillRequest) (*BillResponse, error)
+	GetUpcoming(ctx context.Context, userID uuid.UUID, query UpcomingQuery) (*UpcomingResponse, error)
+	GetOverdue(ctx context.Context, userID uuid.UUID) ([]BillResponse, error)
+	// SendReminders notifies users of bills coming due and bills gone overdue
+	SendReminders(ctx context.Context) error
+}
+
+type service struct {
+	billRepo     repository.BillRepository
+	cardRepo     repository.CardRepository
+	categoryRepo repository.CategoryRepository
+	txRepo       repository.TransactionRepository
+	txService    transaction.Service
+	notifier     notification.Notifier
+	logger       *logger.Logger
+}
+
+func NewService(
+	billRepo repository.BillRepository,
+	cardRepo repository.CardRepository,
+	categoryRepo repository.CategoryRepository,
+	txRepo repository.TransactionRepository,
+	txService transaction.Service,
+	notifier notification.Notifier,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		billRepo:     billRepo,
+		cardRepo:     cardRepo,
+		categoryRepo: categoryRepo,
+		txRepo:       txRepo,
+		txService:    txService,
+		notifier:     notifier,
+		logger:       logger,
+	}
+}
+
+func (s *service) CreateBill(ctx context.Context, userID uuid.UUID, req BillRequest) (*BillResponse, error) {
+	bill := &entity.Bill{UserID: userID}
+	if err := s.applyRequest(ctx, bill, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.billRepo.Create(ctx, bill); err != nil {
+		return nil, fmt.Errorf("failed to create bill: %w", err)
+	}
+
+	return toResponse(bill, today()), nil
+}
+
+func (s *service) GetBills(ctx context.Context, userID uuid.UUID) ([]BillResponse, error) {
+	bills, err := s.billRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+
+	now := today()
+	responses := make([]BillResponse, len(bills))
+	for i := range bills {
+		responses[i] = *toResponse(&bills[i], now)
+	}
+	return responses, nil
+}
+
+func (s *service) GetBill(ctx context.Context, billID int64, userID uuid.UUID) (*BillResponse, error) {
+	bill, err := s.findUserBill(ctx, billID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(bill, today()), nil
+}
+
+func (s *service) UpdateBill(ctx context.Context, billID int64, userID uuid.UUID, req BillRequest) (*BillResponse, error) {
+	bill, err := s.findUserBill(ctx, billID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(ctx, bill, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.billRepo.Update(ctx, bill); err != nil {
+		return nil, fmt.Errorf("failed to update bill: %w", err)
+	}
+
+	return toResponse(bill, today()), nil
+}
+
+func (s *service) DeleteBill(ctx context.Context, billID int64, userID uuid.UUID) error {
+	if _, err := s.findUserBill(ctx, billID, userID); err != nil {
+		return err
+	}
+
+	if err := s.billRepo.Delete(ctx, billID); err != nil {
+		return fmt.Errorf("failed to delete bill: %w", err)
+	}
+	return nil
+}
+
+func (s *service) PayBill(ctx context.Context, billID int64, userID uuid.UUID, req PayBillRequest) (*BillResponse, error) {
+	bill, err := s.findUserBill(ctx, billID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bill.IsActive {
+		return nil, apperror.Conflict("bill is not active")
+	}
+
+	paidDate := today()
+	if req.PaidDate != "" {
+		paidDate, err = time.Parse(DateLayout, req.PaidDate)
+		if err != nil {
+			return nil, apperror.Validation("invalid paid_date format, expected YYYY-MM-DD").WithField("paid_date", "must be YYYY-MM-DD")
+		}
+	}
+
+	payment := &entity.BillPayment{
+		BillID:  bill.ID,
+		DueDate: bill.DueDate,
+		Amount:  bill.Amount,
+		PaidAt:  time.Now(),
+	}
+
+	var posted *transaction.TransactionResponse
+	if req.TransactionID != nil {
+		// Link a transaction that already paid the bill
+		tx, err := s.txRepo.FindByID(ctx, *req.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if tx.UserID != userID {
+			return nil, apperror.Forbidden("unauthorized access to transaction")
+		}
+		payment.TransactionID = &tx.ID
+		payment.Amount = tx.Amount
+	} else {
+		cardID := req.CardID
+		if cardID == nil {
+			cardID = bill.CardID
+		}
+		if cardID == nil {
+			return nil, apperror.Validation("card_id is required to pay a bill without a card").WithField("card_id", "is required")
+		}
+
+		tx, err := s.txService.CreateTransaction(ctx, userID, transaction.CreateTransactionRequest{
+			CardID:          *cardID,
+			CategoryID:      bill.CategoryID,
+			TransactionType: entity.TransactionTypeExpense,
+			Amount:          bill.Amount,
+			TransactionDate: paidDate,
+			Description:     bill.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record payment: %w", err)
+		}
+		payment.TransactionID = &tx.ID
+		posted = tx
+	}
+
+	// Move on to the next occurrence; one-off bills are done
+	if bill.Recurrence == entity.RecurrenceNone {
+		bill.IsActive = false
+	} else {
+		bill.DueDate = NextDueDate(bill.DueDate, bill.Recurrence, bill.AnchorDay)
+	}
+	if err := s.billRepo.RecordPayment(ctx, bill, payment); err != nil {
+		// Take back the expense posted for a payment that was not recorded
+		if posted != nil {
+			if deleteErr := s.txService.DeleteTransaction(ctx, posted.ID, userID, &posted.Version); deleteErr != nil {
+				s.logger.Error("Failed to delete unrecorded bill payment",
+					logger.Int("transaction_id", int(posted.ID)), logger.Error(deleteErr))
+			}
+		}
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	bill.Payments = append([]entity.BillPayment{*payment}, bill.Payments...)
+	return toResponse(bill, today()), nil
+}
+
+func (s *service) GetUpcoming(ctx context.Context, userID uuid.UUID, query UpcomingQuery) (*UpcomingResponse, error) {
+	days := query.Days
+	if days == 0 {
+		days = defaultUpcomingDays
+	}
+
+	bills, err := s.billRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+
+	from := today()
+	to := from.AddDate(0, 0, days)
+	response := &UpcomingResponse{
+		From:    from.Format(DateLayout),
+		To:      to.Format(DateLayout),
+		Overdue: []Occurrence{},
+		Days:    []CalendarDay{},
+	}
+
+	byDate := make(map[string]*CalendarDay)
+	var dates []string
+	for i := range bills {
+		bill := &bills[i]
+		if !bill.IsActive {
+			continue
+		}
+
+		for _, due := range Occurrences(bill, to) {
+			occurrence := toOccurrence(bill, due, from)
+			response.TotalDue += bill.Amount
+
+			if occurrence.Status == StatusOverdue {
+				response.Overdue = append(response.Overdue, occurrence)
+				continue
+			}
+
+			key := occurrence.DueDate
+			day, ok := byDate[key]
+			if !ok {
+				day = &CalendarDay{Date: key}
+				byDate[key] = day
+				dates = append(dates, key)
+			}
+			day.Bills = append(day.Bills, occurrence)
+			day.Total += bill.Amount
+		}
+	}
+
+	// YYYY-MM-DD sorts lexically
+	sort.Strings(dates)
+	for _, date := range dates {
+		response.Days = append(response.Days, *byDate[date])
+	}
+
+	return response, nil
+}
+
+func (s *service) GetOverdue(ctx context.Context, userID uuid.UUID) ([]BillResponse, error) {
+	bills, err := s.billRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+
+	now := today()
+	responses := []BillResponse{}
+	for i := range bills {
+		if bills[i].IsActive && bills[i].DueDate.Before(now) {
+			responses = append(responses, *toResponse(&bills[i], now))
+		}
+	}
+	return responses, nil
+}
+
+func (s *service) SendReminders(ctx context.Context) error {
+	now := today()
+	bills, err := s.billRepo.FindDue(ctx, now.AddDate(0, 0, maxRemindDaysBefore))
+	if err != nil {
+		return fmt.Errorf("failed to get due bills: %w", err)
+	}
+
+	failed := 0
+	for i := range bills {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		bill := &bills[i]
+		due := bill.DueDate
+		var msg *notification.Message
+
+		switch {
+		case due.Before(now) && !sameDate(bill.OverdueFor, due):
+			msg = &notification.Message{
+				Type:  entity.NotificationTypeBillOverdue,
+				Title: fmt.Sprintf("%s is overdue", bill.Name),
+				Body:  fmt.Sprintf("%s of %d was due on %s.", bill.Name, bill.Amount, due.Format(DateLayout)),
+			}
+			bill.OverdueFor = &due
+		case !due.Before(now) && daysBetween(now, due) <= bill.RemindDaysBefore && !sameDate(bill.RemindedFor, due):
+			msg = &notification.Message{
+				Type:  entity.NotificationTypeBillDue,
+				Title: fmt.Sprintf("%s is due soon", bill.Name),
+				Body:  fmt.Sprintf("%s of %d is due on %s.", bill.Name, bill.Amount, due.Format(DateLayout)),
+			}
+			bill.RemindedFor = &due
+		default:
+			continue
+		}
+
+		if err := s.notifier.Notify(ctx, bill.UserID, *msg); err != nil {
+			failed++
+			s.logger.Error("Failed to send bill reminder", logger.Int("bill_id", int(bill.ID)), logger.Error(err))
+			continue
+		}
+		if err := s.billRepo.Update(ctx, bill); err != nil {
+			failed++
+			s.logger.Error("Failed to mark bill reminder sent", logger.Int("bill_id", int(bill.ID)), logger.Error(err))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to send %d of %d bill reminders", failed, len(bills))
+	}
+	return nil
+}
+
+func (s *service) findUserBill(ctx context.Context, billID int64, userID uuid.UUID) (*entity.Bill, error) {
+	bill, err := s.billRepo.FindByID(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bill.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to bill")
+	}
+	return bill, nil
+}
+
+// applyRequest validates a bill request and copies it onto the bill
+func (s *service) applyRequest(ctx context.Context, bill *entity.Bill, req BillRequest) error {
+	dueDate, err := time.Parse(DateLayout, req.DueDate)
+	if err != nil {
+		return apperror.Validation("invalid due_date format, expected YYYY-MM-DD").WithField("due_date", "must be YYYY-MM-DD")
+	}
+
+	if req.CardID != nil {
+		card, err := s.cardRepo.FindByID(ctx, *req.CardID)
+		if err != nil {
+			return fmt.Errorf("card not found: %w", err)
+		}
+		if card.UserID != bill.UserID {
+			return apperror.Forbidden("unauthorized access to card")
+		}
+	}
+
+	if req.CategoryID != nil {
+		if _, err := s.categoryRepo.FindByID(ctx, *req.CategoryID); err != nil {
+			return fmt.Errorf("category not found: %w", err)
+		}
+	}
+
+	bill.Name = req.Name
+	bill.Amount = req.Amount
+	bill.CardID = req.CardID
+	bill.CategoryID = req.CategoryID
+	bill.Recurrence = req.Recurrence
+	bill.IsActive = req.IsActive == nil || *req.IsActive
+
+	bill.RemindDaysBefore = defaultRemindDaysBefore
+	if req.RemindDaysBefore != nil {
+		bill.RemindDaysBefore = *req.RemindDaysBefore
+	}
+
+	if !bill.DueDate.Equal(dueDate) {
+		bill.DueDate = dueDate
+		bill.AnchorDay = dueDate.Day()
+	}
+
+	return nil
+}
+
+func toOccurrence(bill *entity.Bill, due, now time.Time) Occurrence {
+	return Occurrence{
+		BillID:       bill.ID,
+		Name:         bill.Name,
+		Amount:       bill.Amount,
+		DueDate:      due.Format(DateLayout),
+		Status:       status(due, now, bill.RemindDaysBefore),
+		DaysUntilDue: daysBetween(now, due),
+	}
+}
+
+func status(due, now time.Time, remindDaysBefore int) string {
+	switch {
+	case due.Before(now):
+		return StatusOverdue
+	case daysBetween(now, due) <= remindDaysBefore:
+		return StatusDueSoon
+	default:
+		return StatusUpcoming
+	}
+}
+
+func toResponse(bill *entity.Bill, now time.Time) *BillResponse {
+	resp := &BillResponse{
+		ID:               bill.ID,
+		Name:             bill.Name,
+		Amount:           bill.Amount,
+		CardID:           bill.CardID,
+		CategoryID:       bill.CategoryID,
+		DueDate:          bill.DueDate.Format(DateLayout),
+		Recurrence:       bill.Recurrence,
+		RemindDaysBefore: bill.RemindDaysBefore,
+		IsActive:         bill.IsActive,
+		Status:           status(bill.DueDate, now, bill.RemindDaysBefore),
+		DaysUntilDue:     daysBetween(now, bill.DueDate),
+		CreatedAt:        bill.CreatedAt,
+	}
+
+	// A finished one-off bill has nothing left to pay
+	if !bill.IsActive && len(bill.Payments) > 0 {
+		resp.Status = StatusPaid
+	}
+
+	for _, payment := range bill.Payments {
+		resp.Payments = append(resp.Payments, PaymentResponse{
+			ID:            payment.ID,
+			DueDate:       payment.DueDate.Format(DateLayout),
+			TransactionID: payment.TransactionID,
+			Amount:        payment.Amount,
+			PaidAt:        payment.PaidAt,
+		})
+	}
+
+	return resp
+}
+
+func sameDate(a *time.Time, b time.Time) bool {
+	return a != nil && a.Format(DateLayout) == b.Format(DateLayout)
+}
+
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
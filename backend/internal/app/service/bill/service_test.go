@@ -0,0 +1,137 @@
+package bill_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/bill"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedBills is a BillRepository over a map. Like the database, it records a
+// payment only while its occurrence is still due.
+type storedBills struct {
+	repository.BillRepository
+	bills    map[int64]*entity.Bill
+	payments []entity.BillPayment
+}
+
+func (r *storedBills) FindByID(ctx context.Context, id int64) (*entity.Bill, error) {
+	b, ok := r.bills[id]
+	if !ok {
+		return nil, apperror.NotFound("bill not found")
+	}
+	found := *b
+	return &found, nil
+}
+
+func (r *storedBills) RecordPayment(ctx context.Context, b *entity.Bill, payment *entity.BillPayment) error {
+	stored := r.bills[b.ID]
+	if !stored.IsActive || !stored.DueDate.Equal(payment.DueDate) {
+		return apperror.Conflict("bill occurrence was already paid")
+	}
+	stored.DueDate, stored.IsActive = b.DueDate, b.IsActive
+	payment.ID = int64(len(r.payments) + 1)
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+// postings is a transaction.Service that keeps the expenses it posts
+type postings struct {
+	transaction.Service
+	posted map[int64]transaction.CreateTransactionRequest
+	nextID int64
+}
+
+func (s *postings) CreateTransaction(ctx context.Context, userID uuid.UUID, req transaction.CreateTransactionRequest) (*transaction.TransactionResponse, error) {
+	s.nextID++
+	s.posted[s.nextID] = req
+	return &transaction.TransactionResponse{ID: s.nextID, Version: 1}, nil
+}
+
+func (s *postings) DeleteTransaction(ctx context.Context, id int64, userID uuid.UUID, expectedVersion *int64) error {
+	delete(s.posted, id)
+	return nil
+}
+
+func newBillService(t *testing.T, bills repository.BillRepository, txService *postings) bill.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return bill.NewService(bills, nil, nil, nil, txService, nil, log)
+}
+
+func TestPayBill(t *testing.T) {
+	owner := uuid.New()
+	card := int64(1)
+	due := date(2026, time.March, 31)
+
+	tests := []struct {
+		name         string
+		recurrence   string
+		wantDueDate  time.Time
+		wantIsActive bool
+	}{
+		{"monthly bill moves to the next occurrence", entity.RecurrenceMonthly, date(2026, time.April, 30), true},
+		{"one-off bill is done", entity.RecurrenceNone, due, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bills := &storedBills{bills: map[int64]*entity.Bill{
+				1: {ID: 1, UserID: owner, Name: "Rent", Amount: 500000, CardID: &card, DueDate: due, Recurrence: tt.recurrence, AnchorDay: 31, IsActive: true},
+			}}
+			txService := &postings{posted: make(map[int64]transaction.CreateTransactionRequest)}
+			service := newBillService(t, bills, txService)
+
+			resp, err := service.PayBill(context.Background(), 1, owner, bill.PayBillRequest{})
+			require.NoError(t, err)
+
+			require.Len(t, bills.payments, 1)
+			assert.True(t, due.Equal(bills.payments[0].DueDate))
+			require.Contains(t, txService.posted, *bills.payments[0].TransactionID)
+			assert.Equal(t, int64(500000), txService.posted[1].Amount)
+			assert.True(t, tt.wantDueDate.Equal(bills.bills[1].DueDate))
+			assert.Equal(t, tt.wantIsActive, bills.bills[1].IsActive)
+			assert.Equal(t, tt.wantIsActive, resp.IsActive)
+		})
+	}
+}
+
+func TestPayBill_PaidMeanwhile(t *testing.T) {
+	owner := uuid.New()
+	card := int64(1)
+	bills := &storedBills{bills: map[int64]*entity.Bill{
+		1: {ID: 1, UserID: owner, Name: "Rent", Amount: 500000, CardID: &card, DueDate: date(2026, time.March, 31), Recurrence: entity.RecurrenceMonthly, AnchorDay: 31, IsActive: true},
+	}}
+	txService := &postings{posted: make(map[int64]transaction.CreateTransactionRequest)}
+	// Another request pays the occurrence after this one read the bill
+	racing := &racingBills{storedBills: bills, race: func() {
+		bills.bills[1].DueDate = date(2026, time.April, 30)
+	}}
+	service := newBillService(t, racing, txService)
+
+	_, err := service.PayBill(context.Background(), 1, owner, bill.PayBillRequest{})
+	assert.True(t, apperror.Is(err, apperror.CodeConflict))
+	assert.Empty(t, bills.payments)
+	assert.Empty(t, txService.posted)
+}
+
+// racingBills runs race before recording a payment, as a concurrent payment
+// of the same occurrence would
+type racingBills struct {
+	*storedBills
+	race func()
+}
+
+func (r *racingBills) RecordPayment(ctx context.Context, b *entity.Bill, payment *entity.BillPayment) error {
+	r.race()
+	return r.storedBills.RecordPayment(ctx, b, payment)
+}
@@ -6,35 +6,69 @@ import (
 	"github.com/google/uuid"
 )
 
-// CreateCardRequest contains card creation data
+// CreateCardRequest contains card creation data. CardType is detected from
+// the card number when omitted.
 type CreateCardRequest struct {
-	CardNumber string `json:"card_number" binding:"required,len=16"`
+	CardNumber string `json:"card_number" binding:"required,min=12,max=23"`
 	HolderName string `json:"holder_name" binding:"required"`
 	ExpiryDate string `json:"expiry_date" binding:"required,len=7"` // MM/YYYY
-	CardType   string `json:"card_type" binding:"required,oneof=Visa MasterCard"`
+	CardType   string `json:"card_type" binding:"omitempty,oneof=Visa MasterCard Amex JCB UnionPay Discover"`
 	Alias      string `json:"alias" binding:"omitempty"`
 	Balance    int64  `json:"balance" binding:"omitempty,min=0"`
 	Color      string `json:"color" binding:"omitempty"`
 }
 
+// CreateAccountRequest contains account creation data for any account type
+type CreateAccountRequest struct {
+	AccountType string       `json:"account_type" binding:"required,oneof=Card Cash Checking Savings Credit Loan"`
+	Name        string       `json:"name" binding:"required,max=100"`
+	Balance     int64        `json:"balance" binding:"omitempty,min=0"` // amount owed for Credit and Loan accounts
+	CreditLimit *int64       `json:"credit_limit" binding:"omitempty,min=0"`
+	Card        *CardDetails `json:"card" binding:"omitempty"`
+	Color       string       `json:"color" binding:"omitempty"`
+}
+
+// CardDetails contains payment card data attached to an account
+type CardDetails struct {
+	CardNumber string `json:"card_number" binding:"required,min=12,max=23"`
+	HolderName string `json:"holder_name" binding:"required"`
+	ExpiryDate string `json:"expiry_date" binding:"required,len=7"` // MM/YYYY
+	CardType   string `json:"card_type" binding:"omitempty,oneof=Visa MasterCard Amex JCB UnionPay Discover"`
+}
+
 // UpdateCardRequest contains card update data
 type UpdateCardRequest struct {
-	Alias string `json:"alias" binding:"omitempty"`
-	Color string `json:"color" binding:"omitempty"`
+	Alias       string `json:"alias" binding:"omitempty"`
+	Color       string `json:"color" binding:"omitempty"`
+	CreditLimit *int64 `json:"credit_limit" binding:"omitempty,min=0"`
+}
+
+// CardListFilter contains card listing parameters
+type CardListFilter struct {
+	AccountType        *string `form:"account_type" binding:"omitempty,oneof=Card Cash Checking Savings Credit Loan"`
+	ExpiringWithinDays *int    `form:"expiring_within_days" binding:"omitempty,min=1,max=365"`
+	CardsOnly          bool    `form:"-"` // only accounts backed by a payment card
 }
 
-// CardResponse contains card data (without full card number)
+// CardResponse contains account data (without full card number)
 type CardResponse struct {
 	ID              int64     `json:"id"`
 	UserID          uuid.UUID `json:"user_id"`
-	CardNumberLast4 string    `json:"card_number_last4"`
-	HolderName      string    `json:"holder_name"`
-	ExpiryDate      string    `json:"expiry_date"`
-	CardType        string    `json:"card_type"`
+	AccountType     string    `json:"account_type"`
+	CardNumberLast4 string    `json:"card_number_last4,omitempty"`
+	HolderName      string    `json:"holder_name,omitempty"`
+	ExpiryDate      string    `json:"expiry_date,omitempty"`
+	CardType        string    `json:"card_type,omitempty"`
 	Alias           string    `json:"alias,omitempty"`
 	Balance         int64     `json:"balance"`
+	IsLiability     bool      `json:"is_liability"`
+	CreditLimit     *int64    `json:"credit_limit,omitempty"`
+	AvailableCredit *int64    `json:"available_credit,omitempty"`
 	Color           string    `json:"color"`
 	IsFrozen        bool      `json:"is_frozen"`
+	HouseholdID     *int64    `json:"household_id,omitempty"`
+	IsExpired       bool      `json:"is_expired"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+	Version         int64     `json:"version"` // sent back as the ETag
 }
@@ -5,68 +5,160 @@ import (
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/cardutil"
+	"pfn-backend/internal/pkg/events"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Service interface {
 	CreateCard(ctx context.Context, userID uuid.UUID, req CreateCardRequest) (*CardResponse, error)
-	GetUserCards(ctx context.Context, userID uuid.UUID) ([]CardResponse, error)
+	CreateAccount(ctx context.Context, userID uuid.UUID, req CreateAccountRequest) (*CardResponse, error)
+	GetUserCards(ctx context.Context, userID uuid.UUID, filter CardListFilter) ([]CardResponse, error)
 	GetCard(ctx context.Context, cardID int64, userID uuid.UUID) (*CardResponse, error)
-	UpdateCard(ctx context.Context, cardID int64, userID uuid.UUID, req UpdateCardRequest) (*CardResponse, error)
+	// UpdateCard applies the changes only if the card is still at
+	// expectedVersion, when one is given. Without it, an update that loses a
+	// race with another write is re-applied to the fresh card.
+	UpdateCard(ctx context.Context, cardID int64, userID uuid.UUID, req UpdateCardRequest, expectedVersion *int64) (*CardResponse, error)
 	ToggleFreeze(ctx context.Context, cardID int64, userID uuid.UUID) (*CardResponse, error)
-	DeleteCard(ctx context.Context, cardID int64, userID uuid.UUID) error
+	DeleteCard(ctx context.Context, cardID int64, userID uuid.UUID, expectedVersion *int64) error
 }
 
+// maxUpdateAttempts bounds how often an update or delete that lost a race is
+// retried
+const maxUpdateAttempts = 3
+
 type service struct {
-	cardRepo repository.CardRepository
+	cardRepo  repository.CardRepository
+	access    access.Service
+	suggester suggestion.Service
+	publisher events.Publisher
+	auditor   audit.Recorder
 }
 
-func NewService(cardRepo repository.CardRepository) Service {
+func NewService(cardRepo repository.CardRepository, access access.Service, suggester suggestion.Service, publisher events.Publisher, auditor audit.Recorder) Service {
 	return &service{
-		cardRepo: cardRepo,
+		cardRepo:  cardRepo,
+		access:    access,
+		suggester: suggester,
+		publisher: publisher,
+		auditor:   auditor,
 	}
 }
 
 func (s *service) CreateCard(ctx context.Context, userID uuid.UUID, req CreateCardRequest) (*CardResponse, error) {
-	// Extract last 4 digits
-	last4 := req.CardNumber[len(req.CardNumber)-4:]
+	card := &entity.Card{
+		UserID:      userID,
+		AccountType: entity.AccountTypeCard,
+		Alias:       req.Alias,
+		Balance:     req.Balance,
+		Color:       defaultColor(req.Color),
+		IsFrozen:    false,
+	}
 
-	// Set default color if not provided
-	color := req.Color
-	if color == "" {
-		color = "from-[#667eea] to-[#764ba2]"
+	if err := applyCardDetails(card, CardDetails{
+		CardNumber: req.CardNumber,
+		HolderName: req.HolderName,
+		ExpiryDate: req.ExpiryDate,
+		CardType:   req.CardType,
+	}); err != nil {
+		return nil, err
+	}
+
+	// The ledger is reconciled against the opening balance
+	card.InitialBalance = card.Balance
+
+	if err := s.cardRepo.Create(ctx, card); err != nil {
+		return nil, fmt.Errorf("failed to create card: %w", err)
+	}
+	s.recordCreate(ctx, userID, card)
+
+	return s.toResponse(card), nil
+}
+
+func (s *service) CreateAccount(ctx context.Context, userID uuid.UUID, req CreateAccountRequest) (*CardResponse, error) {
+	if req.AccountType == entity.AccountTypeCard && req.Card == nil {
+		return nil, apperror.Validation("card details are required for card accounts").WithField("card", "is required")
+	}
+	if req.AccountType == entity.AccountTypeCredit && req.CreditLimit == nil {
+		return nil, apperror.Validation("credit limit is required for credit accounts").WithField("credit_limit", "is required")
+	}
+	if req.AccountType != entity.AccountTypeCredit && req.CreditLimit != nil {
+		return nil, apperror.Validation("credit limit is only supported for credit accounts")
 	}
 
 	card := &entity.Card{
-		UserID:          userID,
-		CardNumber:      req.CardNumber, // In production, this should be encrypted
-		CardNumberLast4: last4,
-		HolderName:      req.HolderName,
-		ExpiryDate:      req.ExpiryDate,
-		CardType:        req.CardType,
-		Alias:           req.Alias,
-		Balance:         req.Balance,
-		Color:           color,
-		IsFrozen:        false,
+		UserID:      userID,
+		AccountType: req.AccountType,
+		Alias:       req.Name,
+		Balance:     req.Balance,
+		CreditLimit: req.CreditLimit,
+		Color:       defaultColor(req.Color),
+		IsFrozen:    false,
 	}
 
+	// Liabilities store the amount owed as a negative balance
+	if card.IsLiability() {
+		card.Balance = -req.Balance
+	}
+
+	if available := card.AvailableCredit(); available != nil && *available < 0 {
+		return nil, apperror.Validation("balance owed exceeds credit limit")
+	}
+
+	if req.Card != nil {
+		if err := applyCardDetails(card, *req.Card); err != nil {
+			return nil, err
+		}
+	}
+
+	// The ledger is reconciled against the opening balance
+	card.InitialBalance = card.Balance
+
 	if err := s.cardRepo.Create(ctx, card); err != nil {
-		return nil, fmt.Errorf("failed to create card: %w", err)
+		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
+	s.recordCreate(ctx, userID, card)
 
 	return s.toResponse(card), nil
 }
 
-func (s *service) GetUserCards(ctx context.Context, userID uuid.UUID) ([]CardResponse, error) {
+func (s *service) GetUserCards(ctx context.Context, userID uuid.UUID, filter CardListFilter) ([]CardResponse, error) {
 	cards, err := s.cardRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user cards: %w", err)
 	}
 
-	responses := make([]CardResponse, len(cards))
-	for i, card := range cards {
-		responses[i] = *s.toResponse(&card)
+	// Cards shared with the user through a household are listed after their own
+	shared, err := s.cardRepo.FindSharedWithUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared cards: %w", err)
+	}
+	cards = append(cards, shared...)
+
+	now := time.Now()
+	responses := make([]CardResponse, 0, len(cards))
+	for _, card := range cards {
+		if filter.CardsOnly && !card.HasCardDetails() {
+			continue
+		}
+		if filter.AccountType != nil && card.AccountType != *filter.AccountType {
+			continue
+		}
+
+		// Only list cards expiring soon when requested
+		if filter.ExpiringWithinDays != nil {
+			window := time.Duration(*filter.ExpiringWithinDays) * 24 * time.Hour
+			if !cardutil.ExpiresWithin(card.ExpiryDate, now, window) {
+				continue
+			}
+		}
+		responses = append(responses, *s.toResponse(&card))
 	}
 
 	return responses, nil
@@ -78,25 +170,38 @@ func (s *service) GetCard(ctx context.Context, cardID int64, userID uuid.UUID) (
 		return nil, fmt.Errorf("failed to get card: %w", err)
 	}
 
-	// Check ownership
-	if card.UserID != userID {
-		return nil, fmt.Errorf("unauthorized access to card")
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionView); err != nil {
+		return nil, err
 	}
 
 	return s.toResponse(card), nil
 }
 
-func (s *service) UpdateCard(ctx context.Context, cardID int64, userID uuid.UUID, req UpdateCardRequest) (*CardResponse, error) {
+func (s *service) UpdateCard(ctx context.Context, cardID int64, userID uuid.UUID, req UpdateCardRequest, expectedVersion *int64) (*CardResponse, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := s.updateCard(ctx, cardID, userID, req, expectedVersion)
+		if expectedVersion == nil && apperror.Is(err, apperror.CodeStale) && attempt < maxUpdateAttempts {
+			continue
+		}
+		return resp, err
+	}
+}
+
+func (s *service) updateCard(ctx context.Context, cardID int64, userID uuid.UUID, req UpdateCardRequest, expectedVersion *int64) (*CardResponse, error) {
 	card, err := s.cardRepo.FindByID(ctx, cardID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get card: %w", err)
 	}
 
-	// Check ownership
-	if card.UserID != userID {
-		return nil, fmt.Errorf("unauthorized access to card")
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionManage); err != nil {
+		return nil, err
+	}
+	if err := checkVersion(card, expectedVersion); err != nil {
+		return nil, err
 	}
 
+	before := audit.Card(card)
+
 	// Update fields
 	if req.Alias != "" {
 		card.Alias = req.Alias
@@ -104,10 +209,31 @@ func (s *service) UpdateCard(ctx context.Context, cardID int64, userID uuid.UUID
 	if req.Color != "" {
 		card.Color = req.Color
 	}
+	if req.CreditLimit != nil {
+		if card.AccountType != entity.AccountTypeCredit {
+			return nil, apperror.Validation("credit limit is only supported for credit accounts")
+		}
+		card.CreditLimit = req.CreditLimit
+
+		// Posting checks the same bound, so the limit must cover what is owed
+		if available := card.AvailableCredit(); *available < 0 {
+			return nil, apperror.Validation("credit limit is below the balance owed").
+				WithField("credit_limit", "must cover the balance owed")
+		}
+	}
 
 	if err := s.cardRepo.Update(ctx, card); err != nil {
 		return nil, fmt.Errorf("failed to update card: %w", err)
 	}
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     card.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityCard,
+		EntityID:   card.ID,
+		Before:     before,
+		After:      audit.Card(card),
+	})
 
 	return s.toResponse(card), nil
 }
@@ -118,11 +244,12 @@ func (s *service) ToggleFreeze(ctx context.Context, cardID int64, userID uuid.UU
 		return nil, fmt.Errorf("failed to get card: %w", err)
 	}
 
-	// Check ownership
-	if card.UserID != userID {
-		return nil, fmt.Errorf("unauthorized access to card")
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionManage); err != nil {
+		return nil, err
 	}
 
+	before := audit.Card(card)
+
 	if err := s.cardRepo.ToggleFreeze(ctx, cardID); err != nil {
 		return nil, fmt.Errorf("failed to toggle freeze: %w", err)
 	}
@@ -132,41 +259,145 @@ func (s *service) ToggleFreeze(ctx context.Context, cardID int64, userID uuid.UU
 	if err != nil {
 		return nil, fmt.Errorf("failed to reload card: %w", err)
 	}
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     card.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityCard,
+		EntityID:   card.ID,
+		Before:     before,
+		After:      audit.Card(card),
+	})
+
+	eventType := events.CardUnfrozen
+	if card.IsFrozen {
+		eventType = events.CardFrozen
+	}
+	s.publisher.Publish(ctx, events.New(eventType, userID, map[string]interface{}{
+		"card_id": card.ID,
+		"last4":   card.CardNumberLast4,
+		"alias":   card.Alias,
+	}))
 
 	return s.toResponse(card), nil
 }
 
-func (s *service) DeleteCard(ctx context.Context, cardID int64, userID uuid.UUID) error {
+func (s *service) DeleteCard(ctx context.Context, cardID int64, userID uuid.UUID, expectedVersion *int64) error {
+	for attempt := 1; ; attempt++ {
+		err := s.deleteCard(ctx, cardID, userID, expectedVersion)
+		if expectedVersion == nil && apperror.Is(err, apperror.CodeStale) && attempt < maxUpdateAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+func (s *service) deleteCard(ctx context.Context, cardID int64, userID uuid.UUID, expectedVersion *int64) error {
 	card, err := s.cardRepo.FindByID(ctx, cardID)
 	if err != nil {
 		return fmt.Errorf("failed to get card: %w", err)
 	}
 
-	// Check ownership
-	if card.UserID != userID {
-		return fmt.Errorf("unauthorized access to card")
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionManage); err != nil {
+		return err
+	}
+	if err := checkVersion(card, expectedVersion); err != nil {
+		return err
 	}
 
-	if err := s.cardRepo.Delete(ctx, cardID); err != nil {
+	if err := s.cardRepo.Delete(ctx, cardID, card.Version); err != nil {
 		return fmt.Errorf("failed to delete card: %w", err)
 	}
+	// The card's transactions went with it; retrain suggestions without them
+	s.suggester.Invalidate(card.UserID)
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     card.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionDelete,
+		EntityType: entity.AuditEntityCard,
+		EntityID:   card.ID,
+		Before:     audit.Card(card),
+	})
+
+	return nil
+}
+
+func (s *service) recordCreate(ctx context.Context, userID uuid.UUID, card *entity.Card) {
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     userID,
+		ActorID:    userID,
+		Action:     entity.AuditActionCreate,
+		EntityType: entity.AuditEntityCard,
+		EntityID:   card.ID,
+		After:      audit.Card(card),
+	})
+}
+
+// applyCardDetails validates payment card data and copies it onto the account
+func applyCardDetails(card *entity.Card, details CardDetails) error {
+	// Validate card number and detect brand
+	cardNumber, brand, err := cardutil.Validate(details.CardNumber)
+	if err != nil {
+		return apperror.Validation("%s", err.Error())
+	}
+
+	if details.CardType != "" && details.CardType != string(brand) {
+		return apperror.Validation("card type %s does not match card number (detected %s)", details.CardType, brand)
+	}
+
+	// Validate expiry date
+	if _, err := cardutil.ParseExpiry(details.ExpiryDate); err != nil {
+		return apperror.Validation("%s", err.Error())
+	}
+	if cardutil.IsExpired(details.ExpiryDate, time.Now()) {
+		return apperror.Validation("card is expired")
+	}
+
+	card.CardNumber = cardNumber // In production, this should be encrypted
+	card.CardNumberLast4 = cardNumber[len(cardNumber)-4:]
+	card.HolderName = details.HolderName
+	card.ExpiryDate = details.ExpiryDate
+	card.CardType = string(brand)
 
 	return nil
 }
 
+func defaultColor(color string) string {
+	if color == "" {
+		return "from-[#667eea] to-[#764ba2]"
+	}
+	return color
+}
+
 func (s *service) toResponse(card *entity.Card) *CardResponse {
 	return &CardResponse{
 		ID:              card.ID,
 		UserID:          card.UserID,
+		AccountType:     card.AccountType,
 		CardNumberLast4: card.CardNumberLast4,
 		HolderName:      card.HolderName,
 		ExpiryDate:      card.ExpiryDate,
 		CardType:        card.CardType,
 		Alias:           card.Alias,
 		Balance:         card.Balance,
+		IsLiability:     card.IsLiability(),
+		CreditLimit:     card.CreditLimit,
+		AvailableCredit: card.AvailableCredit(),
 		Color:           card.Color,
 		IsFrozen:        card.IsFrozen,
+		HouseholdID:     card.HouseholdID,
+		IsExpired:       cardutil.IsExpired(card.ExpiryDate, time.Now()),
 		CreatedAt:       card.CreatedAt,
 		UpdatedAt:       card.UpdatedAt,
+		Version:         card.Version,
 	}
 }
+
+// checkVersion rejects a change the client based on an older version of
+// the card
+func checkVersion(card *entity.Card, expectedVersion *int64) error {
+	if expectedVersion != nil && *expectedVersion != card.Version {
+		return apperror.Stale("card has changed since version %d", *expectedVersion)
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package card_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedCards is a CardRepository over a map that enforces versions like
+// the database does. beforeUpdate and beforeDelete run ahead of each Update
+// and Delete to simulate a concurrent write.
+type versionedCards struct {
+	repository.CardRepository
+	cards        map[int64]*entity.Card
+	beforeUpdate func()
+	beforeDelete func()
+	updates      int
+}
+
+func (r *versionedCards) FindByID(ctx context.Context, id int64) (*entity.Card, error) {
+	c, ok := r.cards[id]
+	if !ok {
+		return nil, apperror.NotFound("card not found")
+	}
+	found := *c
+	return &found, nil
+}
+
+func (r *versionedCards) Update(ctx context.Context, c *entity.Card) error {
+	r.updates++
+	if r.beforeUpdate != nil {
+		r.beforeUpdate()
+	}
+	if r.cards[c.ID].Version != c.Version {
+		return apperror.Stale("card was changed by another request")
+	}
+	c.Version++
+	stored := *c
+	r.cards[c.ID] = &stored
+	return nil
+}
+
+func (r *versionedCards) Create(ctx context.Context, c *entity.Card) error {
+	c.ID = int64(len(r.cards) + 1)
+	stored := *c
+	r.cards[c.ID] = &stored
+	return nil
+}
+
+func (r *versionedCards) Delete(ctx context.Context, id int64, version int64) error {
+	if r.beforeDelete != nil {
+		r.beforeDelete()
+	}
+	if r.cards[id].Version != version {
+		return apperror.Stale("card was changed by another request")
+	}
+	delete(r.cards, id)
+	return nil
+}
+
+// discardAuditLog drops every entry
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (discardAuditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	return nil
+}
+
+type noSuggestions struct{ suggestion.Service }
+
+func (noSuggestions) Invalidate(userID uuid.UUID) {}
+
+func newService(t *testing.T, cards *versionedCards) card.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return card.NewService(cards, access.NewService(nil), noSuggestions{}, events.NewBus(log), audit.NewRecorder(discardAuditLog{}, log))
+}
+
+func version(v int64) *int64 {
+	return &v
+}
+
+func TestUpdateCard_RetriesLostRace(t *testing.T) {
+	owner := uuid.New()
+	cards := &versionedCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Alias: "Old", Balance: 10000, Version: 4},
+	}}
+	// A transaction posts to the card between the first read and write
+	cards.beforeUpdate = func() {
+		cards.beforeUpdate = nil
+		cards.cards[1].Balance -= 2500
+		cards.cards[1].Version++
+	}
+	service := newService(t, cards)
+
+	resp, err := service.UpdateCard(context.Background(), 1, owner, card.UpdateCardRequest{Alias: "New"}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, cards.updates)
+	assert.Equal(t, "New", resp.Alias)
+	assert.Equal(t, int64(7500), resp.Balance)
+	assert.Equal(t, int64(6), resp.Version)
+	assert.Equal(t, int64(7500), cards.cards[1].Balance)
+}
+
+func TestUpdateCard_ExpectedVersion(t *testing.T) {
+	owner := uuid.New()
+	cards := &versionedCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Alias: "Old", Version: 4},
+	}}
+	service := newService(t, cards)
+
+	_, err := service.UpdateCard(context.Background(), 1, owner, card.UpdateCardRequest{Alias: "New"}, version(3))
+	assert.True(t, apperror.Is(err, apperror.CodeStale))
+	assert.Equal(t, "Old", cards.cards[1].Alias)
+
+	resp, err := service.UpdateCard(context.Background(), 1, owner, card.UpdateCardRequest{Alias: "New"}, version(4))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.Version)
+}
+
+func TestUpdateCard_ExpectedVersionLosesRace(t *testing.T) {
+	owner := uuid.New()
+	cards := &versionedCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Alias: "Old", Version: 4},
+	}}
+	cards.beforeUpdate = func() {
+		cards.cards[1].Version++
+	}
+	service := newService(t, cards)
+
+	// The client's version was current when read, but not when written
+	_, err := service.UpdateCard(context.Background(), 1, owner, card.UpdateCardRequest{Alias: "New"}, version(4))
+	assert.True(t, apperror.Is(err, apperror.CodeStale))
+	assert.Equal(t, 1, cards.updates)
+	assert.Equal(t, "Old", cards.cards[1].Alias)
+}
+
+func TestDeleteCard_ExpectedVersion(t *testing.T) {
+	owner := uuid.New()
+	cards := &versionedCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Version: 2},
+	}}
+	service := newService(t, cards)
+
+	err := service.DeleteCard(context.Background(), 1, owner, version(1))
+	assert.True(t, apperror.Is(err, apperror.CodeStale))
+	assert.Contains(t, cards.cards, int64(1))
+
+	require.NoError(t, service.DeleteCard(context.Background(), 1, owner, version(2)))
+	assert.NotContains(t, cards.cards, int64(1))
+}
+
+func TestDeleteCard_LosesRace(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name            string
+		expectedVersion *int64
+		wantErr         bool
+	}{
+		{"without a version the delete is retried", nil, false},
+		{"the client's version is not current when written", version(2), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := &versionedCards{cards: map[int64]*entity.Card{
+				1: {ID: 1, UserID: owner, Balance: 10000, Version: 2},
+			}}
+			// A transaction posts to the card between the first read and delete
+			cards.beforeDelete = func() {
+				cards.beforeDelete = nil
+				cards.cards[1].Balance -= 2500
+				cards.cards[1].Version++
+			}
+			service := newService(t, cards)
+
+			err := service.DeleteCard(context.Background(), 1, owner, tt.expectedVersion)
+			if tt.wantErr {
+				assert.True(t, apperror.Is(err, apperror.CodeStale))
+				assert.Contains(t, cards.cards, int64(1))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotContains(t, cards.cards, int64(1))
+		})
+	}
+}
+
+func limit(amount int64) *int64 {
+	return &amount
+}
+
+func TestCreateAccount(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name          string
+		req           card.CreateAccountRequest
+		wantErr       string
+		wantBalance   int64
+		wantLiability bool
+		wantAvailable *int64
+	}{
+		{
+			name:        "cash keeps a positive balance",
+			req:         card.CreateAccountRequest{AccountType: entity.AccountTypeCash, Name: "Wallet", Balance: 50000},
+			wantBalance: 50000,
+		},
+		{
+			name:          "credit stores the amount owed as negative",
+			req:           card.CreateAccountRequest{AccountType: entity.AccountTypeCredit, Name: "Visa", Balance: 30000, CreditLimit: limit(100000)},
+			wantBalance:   -30000,
+			wantLiability: true,
+			wantAvailable: limit(70000),
+		},
+		{
+			name:          "loan is a liability without credit",
+			req:           card.CreateAccountRequest{AccountType: entity.AccountTypeLoan, Name: "Mortgage", Balance: 9000000},
+			wantBalance:   -9000000,
+			wantLiability: true,
+		},
+		{
+			name:    "card account needs card details",
+			req:     card.CreateAccountRequest{AccountType: entity.AccountTypeCard, Name: "Debit"},
+			wantErr: "card details are required for card accounts",
+		},
+		{
+			name:    "credit account needs a limit",
+			req:     card.CreateAccountRequest{AccountType: entity.AccountTypeCredit, Name: "Visa"},
+			wantErr: "credit limit is required for credit accounts",
+		},
+		{
+			name:    "limit on a non-credit account",
+			req:     card.CreateAccountRequest{AccountType: entity.AccountTypeSavings, Name: "Rainy day", CreditLimit: limit(1000)},
+			wantErr: "credit limit is only supported for credit accounts",
+		},
+		{
+			name:    "balance owed above the limit",
+			req:     card.CreateAccountRequest{AccountType: entity.AccountTypeCredit, Name: "Visa", Balance: 120000, CreditLimit: limit(100000)},
+			wantErr: "balance owed exceeds credit limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := &versionedCards{cards: map[int64]*entity.Card{}}
+			service := newService(t, cards)
+
+			resp, err := service.CreateAccount(context.Background(), owner, tt.req)
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, apperror.CodeValidation))
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, cards.cards)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantBalance, resp.Balance)
+			assert.Equal(t, tt.wantLiability, resp.IsLiability)
+			assert.Equal(t, tt.wantAvailable, resp.AvailableCredit)
+			assert.Equal(t, tt.wantBalance, cards.cards[resp.ID].InitialBalance)
+		})
+	}
+}
+
+func TestUpdateCard_CreditLimit(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name          string
+		accountType   string
+		creditLimit   int64
+		wantErr       bool
+		wantAvailable int64
+	}{
+		{"limit above the balance owed", entity.AccountTypeCredit, 50000, false, 20000},
+		{"limit equal to the balance owed", entity.AccountTypeCredit, 30000, false, 0},
+		{"limit below the balance owed", entity.AccountTypeCredit, 29999, true, 0},
+		{"limit on a non-credit account", entity.AccountTypeChecking, 50000, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := &versionedCards{cards: map[int64]*entity.Card{
+				1: {ID: 1, UserID: owner, AccountType: tt.accountType, Balance: -30000, CreditLimit: limit(100000)},
+			}}
+			service := newService(t, cards)
+
+			resp, err := service.UpdateCard(context.Background(), 1, owner, card.UpdateCardRequest{CreditLimit: limit(tt.creditLimit)}, nil)
+			if tt.wantErr {
+				assert.True(t, apperror.Is(err, apperror.CodeValidation))
+				assert.Equal(t, int64(100000), *cards.cards[1].CreditLimit)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAvailable, *resp.AvailableCredit)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package duplicate
+
+import "time"
+
+// TransactionInfo contains the fields used to compare duplicates
+type TransactionInfo struct {
+	ID              int64     `json:"id"`
+	CardID          int64     `json:"card_id"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          int64     `json:"amount"`
+	TransactionDate time.Time `json:"transaction_date"`
+	Description     string    `json:"description,omitempty"`
+}
+
+// Match is an existing transaction that probably duplicates another one
+type Match struct {
+	TransactionInfo
+	Similarity float64 `json:"similarity"`
+}
+
+// PairResponse is a pair of probable duplicates
+type PairResponse struct {
+	Transaction TransactionInfo `json:"transaction"`
+	Duplicate   TransactionInfo `json:"duplicate"`
+	Similarity  float64         `json:"similarity"`
+	DaysApart   int             `json:"days_apart"`
+}
+
+// MergeRequest keeps one transaction and removes its duplicate
+type MergeRequest struct {
+	KeepID      int64 `json:"keep_id" binding:"required"`
+	DuplicateID int64 `json:"duplicate_id" binding:"required,nefield=KeepID"`
+}
+
+// MergeResponse contains the result of merging duplicates
+type MergeResponse struct {
+	KeptID            int64 `json:"kept_id"`
+	RemovedID         int64 `json:"removed_id"`
+	BalanceCorrection int64 `json:"balance_correction"` // applied to the card balance
+}
+
+// DismissRequest marks two transactions as not being duplicates
+type DismissRequest struct {
+	TransactionID int64 `json:"transaction_id" binding:"required"`
+	DuplicateID   int64 `json:"duplicate_id" binding:"required,nefield=TransactionID"`
+}
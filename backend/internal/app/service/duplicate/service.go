@@ -0,0 +1,274 @@
+package duplicate
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/merchant"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Detect returns existing transactions that probably duplicate tx
+	Detect(ctx context.Context, tx *entity.Transaction) ([]Match, error)
+	ListDuplicates(ctx context.Context, userID uuid.UUID) ([]PairResponse, error)
+	Merge(ctx context.Context, userID uuid.UUID, req MergeRequest) (*MergeResponse, error)
+	Dismiss(ctx context.Context, userID uuid.UUID, req DismissRequest) error
+}
+
+type service struct {
+	duplicateRepo repository.DuplicateRepository
+	txRepo        repository.TransactionRepository
+	suggester     suggestion.Service
+	publisher     events.Publisher
+	auditor       audit.Recorder
+	windowDays    int
+	minSimilarity float64
+}
+
+func NewService(
+	duplicateRepo repository.DuplicateRepository,
+	txRepo repository.TransactionRepository,
+	suggester suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
+	cfg config.DuplicatesConfig,
+) Service {
+	return &service{
+		duplicateRepo: duplicateRepo,
+		txRepo:        txRepo,
+		suggester:     suggester,
+		publisher:     publisher,
+		auditor:       auditor,
+		windowDays:    cfg.WindowDays,
+		minSimilarity: cfg.MinSimilarity,
+	}
+}
+
+func (s *service) Detect(ctx context.Context, tx *entity.Transaction) ([]Match, error) {
+	window := time.Duration(s.windowDays) * 24 * time.Hour
+	candidates, err := s.duplicateRepo.FindCandidates(ctx, tx.UserID, repository.DuplicateCandidateFilter{
+		CardID:          tx.CardID,
+		Amount:          tx.Amount,
+		TransactionType: tx.TransactionType,
+		From:            tx.TransactionDate.Add(-window),
+		To:              tx.TransactionDate.Add(window),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for i := range candidates {
+		if candidates[i].ID == tx.ID {
+			continue
+		}
+		similarity := merchant.Similarity(tx.Description, candidates[i].Description)
+		if similarity < s.minSimilarity {
+			continue
+		}
+		matches = append(matches, Match{
+			TransactionInfo: toInfo(&candidates[i]),
+			Similarity:      similarity,
+		})
+	}
+	return matches, nil
+}
+
+func (s *service) ListDuplicates(ctx context.Context, userID uuid.UUID) ([]PairResponse, error) {
+	pairs, err := s.duplicateRepo.FindPairs(ctx, userID, s.windowDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicates: %w", err)
+	}
+
+	ids := make([]int64, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		ids = append(ids, pair.FirstID, pair.SecondID)
+	}
+	transactions, err := s.txRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	byID := make(map[int64]*entity.Transaction, len(transactions))
+	for i := range transactions {
+		byID[transactions[i].ID] = &transactions[i]
+	}
+
+	responses := []PairResponse{}
+	for _, pair := range pairs {
+		first, second := byID[pair.FirstID], byID[pair.SecondID]
+		if first == nil || second == nil {
+			continue
+		}
+
+		similarity := merchant.Similarity(first.Description, second.Description)
+		if similarity < s.minSimilarity {
+			continue
+		}
+
+		responses = append(responses, PairResponse{
+			Transaction: toInfo(first),
+			Duplicate:   toInfo(second),
+			Similarity:  similarity,
+			DaysApart:   daysApart(first.TransactionDate, second.TransactionDate),
+		})
+	}
+	return responses, nil
+}
+
+func (s *service) Merge(ctx context.Context, userID uuid.UUID, req MergeRequest) (*MergeResponse, error) {
+	keep, err := s.findUserTransaction(ctx, req.KeepID, userID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.findUserTransaction(ctx, req.DuplicateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if keep.CardID != duplicate.CardID || keep.Amount != duplicate.Amount || keep.TransactionType != duplicate.TransactionType {
+		return nil, apperror.Conflict("transactions differ in card, amount or type and cannot be merged")
+	}
+
+	// Carry over details only the duplicate has, and remove the duplicate
+	// along with its effect on the card balance in the same database transaction
+	before := audit.Transaction(keep)
+	learned := *keep
+	updated := fillMissing(keep, duplicate)
+	changes := []repository.BulkChange{{Delete: duplicate}}
+	if updated {
+		changes = []repository.BulkChange{{Update: keep}, {Delete: duplicate}}
+	}
+	failures, err := s.txRepo.ApplyBulk(ctx, changes, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge duplicate: %w", err)
+	}
+	for _, failure := range failures {
+		if failure != nil {
+			return nil, failure
+		}
+	}
+
+	if updated {
+		s.suggester.Forget(keep.UserID, &learned)
+		s.suggester.Learn(keep.UserID, keep)
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     keep.UserID,
+			ActorID:    userID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityTransaction,
+			EntityID:   keep.ID,
+			Before:     before,
+			After:      audit.Transaction(keep),
+		})
+		s.publisher.Publish(ctx, events.New(events.TransactionUpdated, userID, map[string]interface{}{
+			"transaction_id": keep.ID,
+			"reason":         "duplicate_merge",
+		}))
+	}
+
+	s.suggester.Forget(duplicate.UserID, duplicate)
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     duplicate.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionDelete,
+		EntityType: entity.AuditEntityTransaction,
+		EntityID:   duplicate.ID,
+		Before:     audit.Transaction(duplicate),
+	})
+	s.publisher.Publish(ctx, events.New(events.TransactionDeleted, userID, map[string]interface{}{
+		"transaction_id": duplicate.ID,
+		"card_id":        duplicate.CardID,
+		"reason":         "duplicate_merge",
+		"merged_into":    keep.ID,
+	}))
+
+	return &MergeResponse{
+		KeptID:            keep.ID,
+		RemovedID:         duplicate.ID,
+		BalanceCorrection: -duplicate.BalanceChange(),
+	}, nil
+}
+
+func (s *service) Dismiss(ctx context.Context, userID uuid.UUID, req DismissRequest) error {
+	first, err := s.findUserTransaction(ctx, req.TransactionID, userID)
+	if err != nil {
+		return err
+	}
+	second, err := s.findUserTransaction(ctx, req.DuplicateID, userID)
+	if err != nil {
+		return err
+	}
+
+	if first.ID > second.ID {
+		first, second = second, first
+	}
+
+	return s.duplicateRepo.Dismiss(ctx, &entity.DuplicateDismissal{
+		UserID:        userID,
+		TransactionID: first.ID,
+		DuplicateID:   second.ID,
+	})
+}
+
+func (s *service) findUserTransaction(ctx context.Context, id int64, userID uuid.UUID) (*entity.Transaction, error) {
+	tx, err := s.txRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to transaction")
+	}
+	return tx, nil
+}
+
+// fillMissing copies category, payee, description and tags from the
+// duplicate where keep has none. It reports whether keep changed.
+func fillMissing(keep, duplicate *entity.Transaction) bool {
+	changed := false
+	if keep.CategoryID == nil && !keep.IsSplit() && duplicate.CategoryID != nil {
+		keep.CategoryID = duplicate.CategoryID
+		changed = true
+	}
+	if keep.PayeeID == nil && duplicate.PayeeID != nil {
+		keep.PayeeID = duplicate.PayeeID
+		changed = true
+	}
+	if keep.Description == "" && duplicate.Description != "" {
+		keep.Description = duplicate.Description
+		changed = true
+	}
+	if merged := keep.Tags.Merge(duplicate.Tags...); len(merged) != len(keep.Tags) {
+		keep.Tags = merged
+		changed = true
+	}
+	return changed
+}
+
+func daysApart(a, b time.Time) int {
+	days := int(a.Sub(b).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+func toInfo(tx *entity.Transaction) TransactionInfo {
+	return TransactionInfo{
+		ID:              tx.ID,
+		CardID:          tx.CardID,
+		TransactionType: tx.TransactionType,
+		Amount:          tx.Amount,
+		TransactionDate: tx.TransactionDate,
+		Description:     tx.Description,
+	}
+}
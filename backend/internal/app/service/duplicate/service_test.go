@@ -0,0 +1,250 @@
+package duplicate_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/duplicate"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTransactions is a TransactionRepository over a map that also tracks
+// card balances. ApplyBulk checks versions and rolls back like the database.
+type memoryTransactions struct {
+	repository.TransactionRepository
+	txs      map[int64]*entity.Transaction
+	balances map[int64]int64
+}
+
+func (r *memoryTransactions) FindByID(ctx context.Context, id int64) (*entity.Transaction, error) {
+	tx, ok := r.txs[id]
+	if !ok {
+		return nil, apperror.NotFound("transaction not found")
+	}
+	found := *tx
+	return &found, nil
+}
+
+func (r *memoryTransactions) ApplyBulk(ctx context.Context, changes []repository.BulkChange, atomic bool) ([]error, error) {
+	txs := make(map[int64]*entity.Transaction, len(r.txs))
+	for id, tx := range r.txs {
+		txs[id] = tx
+	}
+	balances := make(map[int64]int64, len(r.balances))
+	for id, balance := range r.balances {
+		balances[id] = balance
+	}
+
+	failures := make([]error, len(changes))
+	for i, change := range changes {
+		switch {
+		case change.Update != nil:
+			stored, ok := txs[change.Update.ID]
+			if !ok || stored.Version != change.Update.Version {
+				failures[i] = apperror.Stale("transaction was modified by another request")
+				return failures, nil
+			}
+			updated := *change.Update
+			updated.Version++
+			txs[updated.ID] = &updated
+		case change.Delete != nil:
+			stored, ok := txs[change.Delete.ID]
+			if !ok {
+				failures[i] = apperror.NotFound("transaction not found")
+				return failures, nil
+			}
+			if stored.Version != change.Delete.Version {
+				failures[i] = apperror.Stale("transaction was changed by another request")
+				return failures, nil
+			}
+			delete(txs, change.Delete.ID)
+			balances[change.Delete.CardID] -= change.Delete.BalanceChange()
+		}
+	}
+	r.txs, r.balances = txs, balances
+	return failures, nil
+}
+
+// forgotten records the transactions removed from suggestions
+type forgotten struct {
+	suggestion.Service
+	ids []int64
+}
+
+func (f *forgotten) Learn(userID uuid.UUID, tx *entity.Transaction) {}
+
+func (f *forgotten) Forget(userID uuid.UUID, tx *entity.Transaction) {
+	f.ids = append(f.ids, tx.ID)
+}
+
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (discardAuditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	return nil
+}
+
+func TestMerge_CorrectsBalance(t *testing.T) {
+	owner := uuid.New()
+	groceries := int64(3)
+
+	tests := []struct {
+		name           string
+		keep           entity.Transaction
+		duplicate      entity.Transaction
+		wantCorrection int64
+		wantErr        apperror.Code
+		wantCategory   *int64
+	}{
+		{
+			name:           "expense duplicate is refunded",
+			keep:           entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+			duplicate:      entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+			wantCorrection: 2500,
+		},
+		{
+			name:           "income duplicate is taken back",
+			keep:           entity.Transaction{TransactionType: entity.TransactionTypeIncome, Amount: 90000},
+			duplicate:      entity.Transaction{TransactionType: entity.TransactionTypeIncome, Amount: 90000},
+			wantCorrection: -90000,
+		},
+		{
+			name:           "category carries over to the kept transaction",
+			keep:           entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+			duplicate:      entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2500, CategoryID: &groceries},
+			wantCorrection: 2500,
+			wantCategory:   &groceries,
+		},
+		{
+			name:      "different amounts are not merged",
+			keep:      entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+			duplicate: entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2600},
+			wantErr:   apperror.CodeConflict,
+		},
+		{
+			name:      "different types are not merged",
+			keep:      entity.Transaction{TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+			duplicate: entity.Transaction{TransactionType: entity.TransactionTypeIncome, Amount: 2500},
+			wantErr:   apperror.CodeConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.keep.ID, tt.keep.UserID, tt.keep.CardID = 1, owner, 1
+			tt.duplicate.ID, tt.duplicate.UserID, tt.duplicate.CardID = 2, owner, 1
+			txs := &memoryTransactions{
+				txs:      map[int64]*entity.Transaction{1: &tt.keep, 2: &tt.duplicate},
+				balances: map[int64]int64{1: 10000},
+			}
+			suggester := &forgotten{}
+
+			log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+			require.NoError(t, err)
+			service := duplicate.NewService(nil, txs, suggester, events.NewBus(log),
+				audit.NewRecorder(discardAuditLog{}, log), config.DuplicatesConfig{WindowDays: 3, MinSimilarity: 0.5})
+
+			resp, err := service.Merge(context.Background(), owner, duplicate.MergeRequest{KeepID: 1, DuplicateID: 2})
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Len(t, txs.txs, 2)
+				assert.Equal(t, int64(10000), txs.balances[1])
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCorrection, resp.BalanceCorrection)
+			assert.Equal(t, 10000+tt.wantCorrection, txs.balances[1])
+			assert.NotContains(t, txs.txs, int64(2))
+			assert.Equal(t, tt.wantCategory, txs.txs[1].CategoryID)
+			assert.Contains(t, suggester.ids, int64(2))
+		})
+	}
+}
+
+func TestMerge_OtherUsersTransactions(t *testing.T) {
+	owner := uuid.New()
+	txs := &memoryTransactions{txs: map[int64]*entity.Transaction{
+		1: {ID: 1, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+		2: {ID: 2, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+	}, balances: map[int64]int64{1: 10000}}
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	service := duplicate.NewService(nil, txs, &forgotten{}, events.NewBus(log),
+		audit.NewRecorder(discardAuditLog{}, log), config.DuplicatesConfig{})
+
+	_, err = service.Merge(context.Background(), uuid.New(), duplicate.MergeRequest{KeepID: 1, DuplicateID: 2})
+	assert.True(t, apperror.Is(err, apperror.CodeForbidden))
+	assert.Len(t, txs.txs, 2)
+	assert.Equal(t, int64(10000), txs.balances[1])
+}
+
+func TestMerge_Concurrent(t *testing.T) {
+	owner := uuid.New()
+	groceries := int64(3)
+
+	tests := []struct {
+		name    string
+		race    func(txs *memoryTransactions)
+		wantErr apperror.Code
+	}{
+		{
+			name: "duplicate removed meanwhile",
+			race: func(txs *memoryTransactions) {
+				delete(txs.txs, 2)
+			},
+			wantErr: apperror.CodeNotFound,
+		},
+		{
+			name: "kept transaction edited meanwhile",
+			race: func(txs *memoryTransactions) {
+				edited := *txs.txs[1]
+				edited.Version++
+				txs.txs[1] = &edited
+			},
+			wantErr: apperror.CodeStale,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := &memoryTransactions{txs: map[int64]*entity.Transaction{
+				1: {ID: 1, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+				2: {ID: 2, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500, CategoryID: &groceries},
+			}, balances: map[int64]int64{1: 10000}}
+			log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+			require.NoError(t, err)
+			racing := &racingTransactions{memoryTransactions: txs, race: tt.race}
+			service := duplicate.NewService(nil, racing, &forgotten{}, events.NewBus(log),
+				audit.NewRecorder(discardAuditLog{}, log), config.DuplicatesConfig{})
+
+			_, err = service.Merge(context.Background(), owner, duplicate.MergeRequest{KeepID: 1, DuplicateID: 2})
+			assert.True(t, apperror.Is(err, tt.wantErr))
+			assert.Nil(t, txs.txs[1].CategoryID)
+			assert.Equal(t, int64(10000), txs.balances[1])
+		})
+	}
+}
+
+// racingTransactions runs race before applying the merge, as a concurrent
+// request would between the reads and the writes
+type racingTransactions struct {
+	*memoryTransactions
+	race func(txs *memoryTransactions)
+}
+
+func (r *racingTransactions) ApplyBulk(ctx context.Context, changes []repository.BulkChange, atomic bool) ([]error, error) {
+	r.race(r.memoryTransactions)
+	return r.memoryTransactions.ApplyBulk(ctx, changes, atomic)
+}
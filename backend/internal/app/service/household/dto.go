@@ -0,0 +1,72 @@
+package household
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HouseholdRequest contains household create/update data
+type HouseholdRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// InviteRequest invites an email address into a household
+type InviteRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+// MemberRoleRequest changes a member's role
+type MemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+// ShareCardRequest shares one of the caller's cards into a household
+type ShareCardRequest struct {
+	CardID int64 `json:"card_id" binding:"required"`
+}
+
+// HouseholdResponse contains a household as seen by one of its members
+type HouseholdResponse struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	OwnerID     uuid.UUID            `json:"owner_id"`
+	Role        string               `json:"role"` // the caller's role
+	Members     []MemberResponse     `json:"members"`
+	Cards       []SharedCardResponse `json:"cards,omitempty"`
+	Invitations []InvitationResponse `json:"invitations,omitempty"` // pending, owners only
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// MemberResponse contains a household member
+type MemberResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email"`
+	FullName string    `json:"full_name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// SharedCardResponse contains a card shared into a household
+type SharedCardResponse struct {
+	ID              int64     `json:"id"`
+	OwnerID         uuid.UUID `json:"owner_id"`
+	AccountType     string    `json:"account_type"`
+	CardNumberLast4 string    `json:"card_number_last4,omitempty"`
+	Alias           string    `json:"alias,omitempty"`
+	Balance         int64     `json:"balance"`
+	IsFrozen        bool      `json:"is_frozen"`
+}
+
+// InvitationResponse contains a household invitation
+type InvitationResponse struct {
+	ID            int64     `json:"id"`
+	HouseholdID   int64     `json:"household_id"`
+	HouseholdName string    `json:"household_name,omitempty"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	InvitedBy     uuid.UUID `json:"invited_by"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
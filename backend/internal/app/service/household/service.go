@@ -0,0 +1,515 @@
+package household
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// invitationExpiry is how long an invitation can be accepted
+const invitationExpiry = 7 * 24 * time.Hour
+
+// maxUpdateAttempts bounds how often a card update that lost a race is retried
+const maxUpdateAttempts = 3
+
+type Service interface {
+	CreateHousehold(ctx context.Context, userID uuid.UUID, req HouseholdRequest) (*HouseholdResponse, error)
+	GetHouseholds(ctx context.Context, userID uuid.UUID) ([]HouseholdResponse, error)
+	GetHousehold(ctx context.Context, householdID int64, userID uuid.UUID) (*HouseholdResponse, error)
+	UpdateHousehold(ctx context.Context, householdID int64, userID uuid.UUID, req HouseholdRequest) (*HouseholdResponse, error)
+	DeleteHousehold(ctx context.Context, householdID int64, userID uuid.UUID) error
+
+	Invite(ctx context.Context, householdID int64, userID uuid.UUID, req InviteRequest) (*InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, householdID, invitationID int64, userID uuid.UUID) error
+	GetInvitations(ctx context.Context, userID uuid.UUID) ([]InvitationResponse, error)
+	AcceptInvitation(ctx context.Context, invitationID int64, userID uuid.UUID) (*HouseholdResponse, error)
+	DeclineInvitation(ctx context.Context, invitationID int64, userID uuid.UUID) error
+
+	UpdateMember(ctx context.Context, householdID int64, memberID, userID uuid.UUID, req MemberRoleRequest) (*HouseholdResponse, error)
+	RemoveMember(ctx context.Context, householdID int64, memberID, userID uuid.UUID) error
+
+	ShareCard(ctx context.Context, householdID int64, userID uuid.UUID, req ShareCardRequest) (*HouseholdResponse, error)
+	UnshareCard(ctx context.Context, householdID, cardID int64, userID uuid.UUID) error
+}
+
+type service struct {
+	householdRepo repository.HouseholdRepository
+	cardRepo      repository.CardRepository
+	userRepo      repository.UserRepository
+	access        access.Service
+	notifier      notification.Notifier
+	auditor       audit.Recorder
+	logger        *logger.Logger
+}
+
+func NewService(
+	householdRepo repository.HouseholdRepository,
+	cardRepo repository.CardRepository,
+	userRepo repository.UserRepository,
+	access access.Service,
+	notifier notification.Notifier,
+	auditor audit.Recorder,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		householdRepo: householdRepo,
+		cardRepo:      cardRepo,
+		userRepo:      userRepo,
+		access:        access,
+		notifier:      notifier,
+		auditor:       auditor,
+		logger:        logger,
+	}
+}
+
+func (s *service) CreateHousehold(ctx context.Context, userID uuid.UUID, req HouseholdRequest) (*HouseholdResponse, error) {
+	household := &entity.Household{
+		Name:    req.Name,
+		OwnerID: userID,
+		Members: []entity.HouseholdMember{
+			{UserID: userID, Role: entity.HouseholdRoleOwner},
+		},
+	}
+
+	if err := s.householdRepo.Create(ctx, household); err != nil {
+		return nil, fmt.Errorf("failed to create household: %w", err)
+	}
+
+	return s.GetHousehold(ctx, household.ID, userID)
+}
+
+func (s *service) GetHouseholds(ctx context.Context, userID uuid.UUID) ([]HouseholdResponse, error) {
+	households, err := s.householdRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get households: %w", err)
+	}
+
+	responses := make([]HouseholdResponse, len(households))
+	for i := range households {
+		responses[i] = *toResponse(&households[i], userID)
+	}
+
+	return responses, nil
+}
+
+func (s *service) GetHousehold(ctx context.Context, householdID int64, userID uuid.UUID) (*HouseholdResponse, error) {
+	household, member, err := s.findMemberHousehold(ctx, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toResponse(household, userID)
+
+	cards, err := s.cardRepo.FindByHouseholdID(ctx, householdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared cards: %w", err)
+	}
+	resp.Cards = make([]SharedCardResponse, len(cards))
+	for i, card := range cards {
+		resp.Cards[i] = SharedCardResponse{
+			ID:              card.ID,
+			OwnerID:         card.UserID,
+			AccountType:     card.AccountType,
+			CardNumberLast4: card.CardNumberLast4,
+			Alias:           card.Alias,
+			Balance:         card.Balance,
+			IsFrozen:        card.IsFrozen,
+		}
+	}
+
+	if member.Role == entity.HouseholdRoleOwner {
+		invitations, err := s.householdRepo.FindInvitationsByHouseholdID(ctx, householdID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invitations: %w", err)
+		}
+		resp.Invitations = make([]InvitationResponse, len(invitations))
+		for i := range invitations {
+			resp.Invitations[i] = *toInvitationResponse(&invitations[i])
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *service) UpdateHousehold(ctx context.Context, householdID int64, userID uuid.UUID, req HouseholdRequest) (*HouseholdResponse, error) {
+	household, err := s.findOwnedHousehold(ctx, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	household.Name = req.Name
+	if err := s.householdRepo.Update(ctx, household); err != nil {
+		return nil, fmt.Errorf("failed to update household: %w", err)
+	}
+
+	return s.GetHousehold(ctx, householdID, userID)
+}
+
+// DeleteHousehold removes the household; shared cards go back to being
+// visible to their owners only
+func (s *service) DeleteHousehold(ctx context.Context, householdID int64, userID uuid.UUID) error {
+	if _, err := s.findOwnedHousehold(ctx, householdID, userID); err != nil {
+		return err
+	}
+
+	if err := s.householdRepo.Delete(ctx, householdID); err != nil {
+		return fmt.Errorf("failed to delete household: %w", err)
+	}
+
+	return nil
+}
+
+// Invite addresses an invitation to an email address. Invitees who already
+// have an account are notified; others find the invitation in
+// GetInvitations once they sign up with that email.
+func (s *service) Invite(ctx context.Context, householdID int64, userID uuid.UUID, req InviteRequest) (*InvitationResponse, error) {
+	household, err := s.findOwnedHousehold(ctx, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	for _, member := range household.Members {
+		if strings.EqualFold(member.User.Email, email) {
+			return nil, apperror.Conflict("user is already a member of this household")
+		}
+	}
+
+	pending, err := s.householdRepo.FindInvitationsByHouseholdID(ctx, householdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	for _, invitation := range pending {
+		if strings.EqualFold(invitation.Email, email) {
+			return nil, apperror.Conflict("an invitation for this email is already pending")
+		}
+	}
+
+	invitation := &entity.HouseholdInvitation{
+		HouseholdID: householdID,
+		Email:       email,
+		Role:        req.Role,
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().Add(invitationExpiry),
+	}
+	if err := s.householdRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	s.logger.Info("Household invitation created",
+		logger.String("email", email),
+		logger.String("household", household.Name),
+		logger.Any("invitation_id", invitation.ID),
+	)
+
+	if invitee, err := s.userRepo.FindByEmail(ctx, email); err == nil {
+		if err := s.notifier.Notify(ctx, invitee.ID, notification.Message{
+			Type:  entity.NotificationTypeHouseholdInvitation,
+			Title: "Household invitation",
+			Body:  fmt.Sprintf("You have been invited to join %s as %s", household.Name, req.Role),
+		}); err != nil {
+			s.logger.Error("Failed to notify invitee", logger.Error(err))
+		}
+	}
+
+	resp := toInvitationResponse(invitation)
+	resp.HouseholdName = household.Name
+	return resp, nil
+}
+
+func (s *service) RevokeInvitation(ctx context.Context, householdID, invitationID int64, userID uuid.UUID) error {
+	if _, err := s.findOwnedHousehold(ctx, householdID, userID); err != nil {
+		return err
+	}
+
+	invitation, err := s.householdRepo.FindInvitationByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.HouseholdID != householdID {
+		return apperror.NotFound("invitation not found")
+	}
+
+	if err := s.householdRepo.DeleteInvitation(ctx, invitationID); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetInvitations returns the pending invitations addressed to the user's email
+func (s *service) GetInvitations(ctx context.Context, userID uuid.UUID) ([]InvitationResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	invitations, err := s.householdRepo.FindInvitationsByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	responses := make([]InvitationResponse, len(invitations))
+	for i := range invitations {
+		responses[i] = *toInvitationResponse(&invitations[i])
+		responses[i].HouseholdName = invitations[i].Household.Name
+	}
+
+	return responses, nil
+}
+
+func (s *service) AcceptInvitation(ctx context.Context, invitationID int64, userID uuid.UUID) (*HouseholdResponse, error) {
+	invitation, err := s.findUserInvitation(ctx, invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.householdRepo.FindMember(ctx, invitation.HouseholdID, userID); err == nil {
+		return nil, apperror.Conflict("you are already a member of this household")
+	}
+
+	if err := s.householdRepo.AddMember(ctx, &entity.HouseholdMember{
+		HouseholdID: invitation.HouseholdID,
+		UserID:      userID,
+		Role:        invitation.Role,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to join household: %w", err)
+	}
+
+	now := time.Now()
+	invitation.AcceptedAt = &now
+	if err := s.householdRepo.UpdateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	return s.GetHousehold(ctx, invitation.HouseholdID, userID)
+}
+
+func (s *service) DeclineInvitation(ctx context.Context, invitationID int64, userID uuid.UUID) error {
+	if _, err := s.findUserInvitation(ctx, invitationID, userID); err != nil {
+		return err
+	}
+
+	if err := s.householdRepo.DeleteInvitation(ctx, invitationID); err != nil {
+		return fmt.Errorf("failed to decline invitation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) UpdateMember(ctx context.Context, householdID int64, memberID, userID uuid.UUID, req MemberRoleRequest) (*HouseholdResponse, error) {
+	if _, err := s.findOwnedHousehold(ctx, householdID, userID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.householdRepo.FindMember(ctx, householdID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == entity.HouseholdRoleOwner {
+		return nil, apperror.Forbidden("the owner's role cannot be changed")
+	}
+
+	member.Role = req.Role
+	if err := s.householdRepo.UpdateMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+
+	return s.GetHousehold(ctx, householdID, userID)
+}
+
+// RemoveMember lets owners remove members and members leave. The owner
+// cannot leave; deleting the household is the way out.
+func (s *service) RemoveMember(ctx context.Context, householdID int64, memberID, userID uuid.UUID) error {
+	household, _, err := s.findMemberHousehold(ctx, householdID, userID)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID && household.OwnerID != userID {
+		return apperror.Forbidden("only the household owner can remove members")
+	}
+	if memberID == household.OwnerID {
+		return apperror.Conflict("the owner cannot leave the household")
+	}
+
+	if _, err := s.householdRepo.FindMember(ctx, householdID, memberID); err != nil {
+		return err
+	}
+
+	if err := s.householdRepo.RemoveMember(ctx, householdID, memberID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ShareCard(ctx context.Context, householdID int64, userID uuid.UUID, req ShareCardRequest) (*HouseholdResponse, error) {
+	if _, _, err := s.findMemberHousehold(ctx, householdID, userID); err != nil {
+		return nil, err
+	}
+
+	err := s.updateCard(ctx, req.CardID, userID, func(card *entity.Card) error {
+		if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionManage); err != nil {
+			return err
+		}
+		if card.HouseholdID != nil && *card.HouseholdID != householdID {
+			return apperror.Conflict("card is already shared with another household")
+		}
+		card.HouseholdID = &householdID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetHousehold(ctx, householdID, userID)
+}
+
+// UnshareCard is allowed for the card's owner and the household owner
+func (s *service) UnshareCard(ctx context.Context, householdID, cardID int64, userID uuid.UUID) error {
+	household, _, err := s.findMemberHousehold(ctx, householdID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.updateCard(ctx, cardID, userID, func(card *entity.Card) error {
+		if card.HouseholdID == nil || *card.HouseholdID != householdID {
+			return apperror.Conflict("card is not shared with this household")
+		}
+		if card.UserID != userID && household.OwnerID != userID {
+			return apperror.Forbidden("unauthorized access to card")
+		}
+		card.HouseholdID = nil
+		return nil
+	})
+}
+
+// updateCard loads a card, lets change check and modify it, and saves it. If
+// another write to the card got in between, it starts over from a fresh read.
+func (s *service) updateCard(ctx context.Context, cardID int64, userID uuid.UUID, change func(card *entity.Card) error) error {
+	for attempt := 1; ; attempt++ {
+		card, err := s.cardRepo.FindByID(ctx, cardID)
+		if err != nil {
+			return err
+		}
+
+		before := audit.Card(card)
+		if err := change(card); err != nil {
+			return err
+		}
+
+		if err := s.cardRepo.Update(ctx, card); err != nil {
+			if apperror.Is(err, apperror.CodeStale) && attempt < maxUpdateAttempts {
+				continue
+			}
+			return fmt.Errorf("failed to update card: %w", err)
+		}
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     card.UserID,
+			ActorID:    userID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityCard,
+			EntityID:   card.ID,
+			Before:     before,
+			After:      audit.Card(card),
+		})
+		return nil
+	}
+}
+
+// findMemberHousehold loads a household the user belongs to
+func (s *service) findMemberHousehold(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.Household, *entity.HouseholdMember, error) {
+	household, err := s.householdRepo.FindByID(ctx, householdID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range household.Members {
+		if household.Members[i].UserID == userID {
+			return household, &household.Members[i], nil
+		}
+	}
+
+	return nil, nil, apperror.NotFound("household not found")
+}
+
+// findOwnedHousehold loads a household the user owns
+func (s *service) findOwnedHousehold(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.Household, error) {
+	household, member, err := s.findMemberHousehold(ctx, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role != entity.HouseholdRoleOwner {
+		return nil, apperror.Forbidden("only the household owner can do this")
+	}
+
+	return household, nil
+}
+
+// findUserInvitation loads a pending invitation addressed to the user
+func (s *service) findUserInvitation(ctx context.Context, invitationID int64, userID uuid.UUID) (*entity.HouseholdInvitation, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	invitation, err := s.householdRepo.FindInvitationByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(invitation.Email, user.Email) || !invitation.IsPending(time.Now()) {
+		return nil, apperror.NotFound("invitation not found")
+	}
+
+	return invitation, nil
+}
+
+func toResponse(household *entity.Household, userID uuid.UUID) *HouseholdResponse {
+	resp := &HouseholdResponse{
+		ID:        household.ID,
+		Name:      household.Name,
+		OwnerID:   household.OwnerID,
+		Members:   make([]MemberResponse, len(household.Members)),
+		CreatedAt: household.CreatedAt,
+	}
+
+	for i, member := range household.Members {
+		if member.UserID == userID {
+			resp.Role = member.Role
+		}
+		resp.Members[i] = MemberResponse{
+			UserID:   member.UserID,
+			Email:    member.User.Email,
+			FullName: member.User.FullName(),
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		}
+	}
+
+	return resp
+}
+
+func toInvitationResponse(invitation *entity.HouseholdInvitation) *InvitationResponse {
+	return &InvitationResponse{
+		ID:          invitation.ID,
+		HouseholdID: invitation.HouseholdID,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		InvitedBy:   invitation.InvitedBy,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
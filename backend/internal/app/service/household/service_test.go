@@ -0,0 +1,464 @@
+package household_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/household"
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryHouseholds is a HouseholdRepository over a single household. Removing
+// a member unshares their cards like the database transaction does.
+type memoryHouseholds struct {
+	repository.HouseholdRepository
+	household   *entity.Household
+	invitations map[int64]*entity.HouseholdInvitation
+	users       *accounts
+	cards       *sharedCards
+}
+
+func (r *memoryHouseholds) FindByID(ctx context.Context, id int64) (*entity.Household, error) {
+	if id != r.household.ID {
+		return nil, apperror.NotFound("household not found")
+	}
+	found := *r.household
+	found.Members = append([]entity.HouseholdMember(nil), r.household.Members...)
+	return &found, nil
+}
+
+func (r *memoryHouseholds) FindMember(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.HouseholdMember, error) {
+	for _, member := range r.household.Members {
+		if member.HouseholdID == householdID && member.UserID == userID {
+			return &member, nil
+		}
+	}
+	return nil, apperror.NotFound("household member not found")
+}
+
+func (r *memoryHouseholds) AddMember(ctx context.Context, member *entity.HouseholdMember) error {
+	member.User = *r.users.users[member.UserID]
+	r.household.Members = append(r.household.Members, *member)
+	return nil
+}
+
+func (r *memoryHouseholds) UpdateMember(ctx context.Context, member *entity.HouseholdMember) error {
+	for i := range r.household.Members {
+		if r.household.Members[i].UserID == member.UserID {
+			r.household.Members[i].Role = member.Role
+		}
+	}
+	return nil
+}
+
+func (r *memoryHouseholds) RemoveMember(ctx context.Context, householdID int64, userID uuid.UUID) error {
+	for _, card := range r.cards.cards {
+		if card.HouseholdID != nil && *card.HouseholdID == householdID && card.UserID == userID {
+			card.HouseholdID = nil
+		}
+	}
+	var members []entity.HouseholdMember
+	for _, member := range r.household.Members {
+		if member.UserID != userID {
+			members = append(members, member)
+		}
+	}
+	r.household.Members = members
+	return nil
+}
+
+func (r *memoryHouseholds) CreateInvitation(ctx context.Context, invitation *entity.HouseholdInvitation) error {
+	invitation.ID = int64(len(r.invitations) + 1)
+	stored := *invitation
+	r.invitations[invitation.ID] = &stored
+	return nil
+}
+
+func (r *memoryHouseholds) FindInvitationByID(ctx context.Context, id int64) (*entity.HouseholdInvitation, error) {
+	invitation, ok := r.invitations[id]
+	if !ok {
+		return nil, apperror.NotFound("invitation not found")
+	}
+	found := *invitation
+	return &found, nil
+}
+
+func (r *memoryHouseholds) FindInvitationsByHouseholdID(ctx context.Context, householdID int64) ([]entity.HouseholdInvitation, error) {
+	var invitations []entity.HouseholdInvitation
+	for _, invitation := range r.invitations {
+		if invitation.HouseholdID == householdID && invitation.IsPending(time.Now()) {
+			invitations = append(invitations, *invitation)
+		}
+	}
+	return invitations, nil
+}
+
+func (r *memoryHouseholds) UpdateInvitation(ctx context.Context, invitation *entity.HouseholdInvitation) error {
+	stored := *invitation
+	r.invitations[invitation.ID] = &stored
+	return nil
+}
+
+// sharedCards is a CardRepository over a map
+type sharedCards struct {
+	repository.CardRepository
+	cards map[int64]*entity.Card
+}
+
+func (r *sharedCards) FindByHouseholdID(ctx context.Context, householdID int64) ([]entity.Card, error) {
+	var cards []entity.Card
+	for id := int64(1); id <= int64(len(r.cards)); id++ {
+		if card := r.cards[id]; card.HouseholdID != nil && *card.HouseholdID == householdID {
+			cards = append(cards, *card)
+		}
+	}
+	return cards, nil
+}
+
+// accounts is a UserRepository over a map
+type accounts struct {
+	repository.UserRepository
+	users map[uuid.UUID]*entity.User
+}
+
+func (r *accounts) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperror.NotFound("user not found")
+	}
+	return user, nil
+}
+
+func (r *accounts) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, apperror.NotFound("user not found")
+}
+
+// inbox records the notifications sent to each user
+type inbox struct {
+	messages map[uuid.UUID][]notification.Message
+}
+
+func (n *inbox) Notify(ctx context.Context, userID uuid.UUID, msg notification.Message) error {
+	n.messages[userID] = append(n.messages[userID], msg)
+	return nil
+}
+
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (discardAuditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	return nil
+}
+
+// fixture is household 1 owned by Ann, with Ben as editor and Cam as viewer.
+// Ann and Ben each share a card; Dee has an account but no membership.
+type fixture struct {
+	ann, ben, cam, dee uuid.UUID
+	households         *memoryHouseholds
+	cards              *sharedCards
+	inbox              *inbox
+	service            household.Service
+}
+
+func newFixture(t *testing.T) *fixture {
+	f := &fixture{ann: uuid.New(), ben: uuid.New(), cam: uuid.New(), dee: uuid.New()}
+	users := &accounts{users: map[uuid.UUID]*entity.User{
+		f.ann: {ID: f.ann, Email: "ann@example.com", FirstName: "Ann"},
+		f.ben: {ID: f.ben, Email: "ben@example.com", FirstName: "Ben"},
+		f.cam: {ID: f.cam, Email: "cam@example.com", FirstName: "Cam"},
+		f.dee: {ID: f.dee, Email: "dee@example.com", FirstName: "Dee"},
+	}}
+	householdID := int64(1)
+	f.cards = &sharedCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: f.ann, HouseholdID: &householdID},
+		2: {ID: 2, UserID: f.ben, HouseholdID: &householdID},
+	}}
+	f.households = &memoryHouseholds{
+		household: &entity.Household{ID: householdID, Name: "Home", OwnerID: f.ann, Members: []entity.HouseholdMember{
+			{HouseholdID: householdID, UserID: f.ann, Role: entity.HouseholdRoleOwner, User: *users.users[f.ann]},
+			{HouseholdID: householdID, UserID: f.ben, Role: entity.HouseholdRoleEditor, User: *users.users[f.ben]},
+			{HouseholdID: householdID, UserID: f.cam, Role: entity.HouseholdRoleViewer, User: *users.users[f.cam]},
+		}},
+		invitations: make(map[int64]*entity.HouseholdInvitation),
+		users:       users,
+		cards:       f.cards,
+	}
+	f.inbox = &inbox{messages: make(map[uuid.UUID][]notification.Message)}
+
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	f.service = household.NewService(f.households, f.cards, users, access.NewService(f.households),
+		f.inbox, audit.NewRecorder(discardAuditLog{}, log), log)
+	return f
+}
+
+// invite stores an invitation for email as if Ann had sent it
+func (f *fixture) invite(email string, expiresAt time.Time, acceptedAt *time.Time) int64 {
+	invitation := &entity.HouseholdInvitation{
+		HouseholdID: 1,
+		Email:       email,
+		Role:        entity.HouseholdRoleViewer,
+		InvitedBy:   f.ann,
+		ExpiresAt:   expiresAt,
+		AcceptedAt:  acceptedAt,
+	}
+	_ = f.households.CreateInvitation(context.Background(), invitation)
+	return invitation.ID
+}
+
+func TestInvite(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     func(f *fixture) uuid.UUID
+		email      string
+		wantErr    apperror.Code
+		wantNotify func(f *fixture) uuid.UUID
+	}{
+		{
+			name:       "user with an account is notified",
+			caller:     func(f *fixture) uuid.UUID { return f.ann },
+			email:      " Dee@Example.com",
+			wantNotify: func(f *fixture) uuid.UUID { return f.dee },
+		},
+		{
+			name:   "email without an account",
+			caller: func(f *fixture) uuid.UUID { return f.ann },
+			email:  "eve@example.com",
+		},
+		{
+			name:    "current member",
+			caller:  func(f *fixture) uuid.UUID { return f.ann },
+			email:   "BEN@example.com",
+			wantErr: apperror.CodeConflict,
+		},
+		{
+			name:    "invitation already pending",
+			caller:  func(f *fixture) uuid.UUID { return f.ann },
+			email:   "pending@example.com",
+			wantErr: apperror.CodeConflict,
+		},
+		{
+			name:    "editor cannot invite",
+			caller:  func(f *fixture) uuid.UUID { return f.ben },
+			email:   "eve@example.com",
+			wantErr: apperror.CodeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.invite("pending@example.com", time.Now().Add(time.Hour), nil)
+
+			resp, err := f.service.Invite(context.Background(), 1, tt.caller(f), household.InviteRequest{
+				Email: tt.email,
+				Role:  entity.HouseholdRoleEditor,
+			})
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Len(t, f.households.invitations, 1)
+				assert.Empty(t, f.inbox.messages)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, strings.ToLower(strings.TrimSpace(tt.email)), resp.Email)
+			assert.Equal(t, "Home", resp.HouseholdName)
+			assert.Contains(t, f.households.invitations, resp.ID)
+			if tt.wantNotify == nil {
+				assert.Empty(t, f.inbox.messages)
+				return
+			}
+			messages := f.inbox.messages[tt.wantNotify(f)]
+			require.Len(t, messages, 1)
+			assert.Equal(t, entity.NotificationTypeHouseholdInvitation, messages[0].Type)
+		})
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	accepted := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		expiresAt  time.Time
+		acceptedAt *time.Time
+		caller     func(f *fixture) uuid.UUID
+		wantErr    apperror.Code
+	}{
+		{
+			name:      "invitee joins with the invited role",
+			expiresAt: time.Now().Add(time.Hour),
+			caller:    func(f *fixture) uuid.UUID { return f.dee },
+		},
+		{
+			name:      "invitation for someone else",
+			expiresAt: time.Now().Add(time.Hour),
+			caller:    func(f *fixture) uuid.UUID { return f.cam },
+			wantErr:   apperror.CodeNotFound,
+		},
+		{
+			name:      "expired invitation",
+			expiresAt: time.Now().Add(-time.Minute),
+			caller:    func(f *fixture) uuid.UUID { return f.dee },
+			wantErr:   apperror.CodeNotFound,
+		},
+		{
+			name:       "accepted invitation",
+			expiresAt:  time.Now().Add(time.Hour),
+			acceptedAt: &accepted,
+			caller:     func(f *fixture) uuid.UUID { return f.dee },
+			wantErr:    apperror.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			id := f.invite("DEE@example.com", tt.expiresAt, tt.acceptedAt)
+
+			resp, err := f.service.AcceptInvitation(context.Background(), id, tt.caller(f))
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Len(t, f.households.household.Members, 3)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, entity.HouseholdRoleViewer, resp.Role)
+			assert.Len(t, resp.Members, 4)
+			assert.NotNil(t, f.households.invitations[id].AcceptedAt)
+
+			_, err = f.service.AcceptInvitation(context.Background(), id, f.dee)
+			assert.True(t, apperror.Is(err, apperror.CodeNotFound))
+		})
+	}
+}
+
+func TestUpdateMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  func(f *fixture) uuid.UUID
+		member  func(f *fixture) uuid.UUID
+		wantErr apperror.Code
+	}{
+		{
+			name:   "owner changes a member's role",
+			caller: func(f *fixture) uuid.UUID { return f.ann },
+			member: func(f *fixture) uuid.UUID { return f.ben },
+		},
+		{
+			name:    "owner's role cannot change",
+			caller:  func(f *fixture) uuid.UUID { return f.ann },
+			member:  func(f *fixture) uuid.UUID { return f.ann },
+			wantErr: apperror.CodeForbidden,
+		},
+		{
+			name:    "editor cannot change roles",
+			caller:  func(f *fixture) uuid.UUID { return f.ben },
+			member:  func(f *fixture) uuid.UUID { return f.ben },
+			wantErr: apperror.CodeForbidden,
+		},
+		{
+			name:    "user outside the household",
+			caller:  func(f *fixture) uuid.UUID { return f.ann },
+			member:  func(f *fixture) uuid.UUID { return f.dee },
+			wantErr: apperror.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			memberID := tt.member(f)
+
+			resp, err := f.service.UpdateMember(context.Background(), 1, memberID, tt.caller(f), household.MemberRoleRequest{
+				Role: entity.HouseholdRoleViewer,
+			})
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Equal(t, entity.HouseholdRoleEditor, f.households.household.Members[1].Role)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, member := range resp.Members {
+				if member.UserID == memberID {
+					assert.Equal(t, entity.HouseholdRoleViewer, member.Role)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		caller  func(f *fixture) uuid.UUID
+		member  func(f *fixture) uuid.UUID
+		wantErr apperror.Code
+	}{
+		{
+			name:   "owner removes a member",
+			caller: func(f *fixture) uuid.UUID { return f.ann },
+			member: func(f *fixture) uuid.UUID { return f.ben },
+		},
+		{
+			name:   "member leaves",
+			caller: func(f *fixture) uuid.UUID { return f.ben },
+			member: func(f *fixture) uuid.UUID { return f.ben },
+		},
+		{
+			name:    "member cannot remove others",
+			caller:  func(f *fixture) uuid.UUID { return f.cam },
+			member:  func(f *fixture) uuid.UUID { return f.ben },
+			wantErr: apperror.CodeForbidden,
+		},
+		{
+			name:    "owner cannot leave",
+			caller:  func(f *fixture) uuid.UUID { return f.ann },
+			member:  func(f *fixture) uuid.UUID { return f.ann },
+			wantErr: apperror.CodeConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			err := f.service.RemoveMember(context.Background(), 1, tt.member(f), tt.caller(f))
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Len(t, f.households.household.Members, 3)
+				assert.NotNil(t, f.cards.cards[2].HouseholdID)
+				return
+			}
+			require.NoError(t, err)
+
+			// Ben's card leaves with him; Ann's stays shared
+			assert.Nil(t, f.cards.cards[2].HouseholdID)
+			resp, err := f.service.GetHousehold(context.Background(), 1, f.ann)
+			require.NoError(t, err)
+			assert.Len(t, resp.Members, 2)
+			require.Len(t, resp.Cards, 1)
+			assert.Equal(t, int64(1), resp.Cards[0].ID)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package idempotency
+
+// StoredResponse is the response recorded for a completed request
+type StoredResponse struct {
+	StatusCode int
+	Body       []byte
+}
@@ -0,0 +1,121 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxKeyLength is the longest Idempotency-Key accepted
+const MaxKeyLength = 255
+
+type Service interface {
+	// Begin claims key for a request. It returns the stored response when the
+	// same request already completed under the key, and nil when the caller
+	// should run the request and then call Complete or Release.
+	Begin(ctx context.Context, userID uuid.UUID, key, method, path string, body []byte) (*StoredResponse, error)
+	// Complete stores the response replayed for retries until the key expires
+	Complete(ctx context.Context, userID uuid.UUID, key string, statusCode int, body []byte) error
+	// Release forgets the key so a retry runs the request again
+	Release(ctx context.Context, userID uuid.UUID, key string) error
+	// PurgeExpired removes keys past their TTL
+	PurgeExpired(ctx context.Context) error
+}
+
+type service struct {
+	repo   repository.IdempotencyKeyRepository
+	ttl    time.Duration
+	logger *logger.Logger
+}
+
+func NewService(repo repository.IdempotencyKeyRepository, ttl time.Duration, logger *logger.Logger) Service {
+	return &service{
+		repo:   repo,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+func (s *service) Begin(ctx context.Context, userID uuid.UUID, key, method, path string, body []byte) (*StoredResponse, error) {
+	if key == "" || len(key) > MaxKeyLength {
+		return nil, apperror.Validation("Idempotency-Key must be 1 to %d characters", MaxKeyLength).
+			WithField("Idempotency-Key", fmt.Sprintf("must be 1 to %d characters", MaxKeyLength))
+	}
+
+	hash := requestHash(method, path, body)
+	reserved, err := s.repo.Reserve(ctx, &entity.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: hash,
+		ExpiresAt:   time.Now().Add(s.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	record, err := s.repo.FindByKey(ctx, userID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if record.RequestHash != hash {
+		return nil, apperror.Conflict("Idempotency-Key was already used with a different request")
+	}
+	if !record.IsCompleted() {
+		return nil, apperror.Conflict("a request with this Idempotency-Key is still in progress")
+	}
+
+	return &StoredResponse{
+		StatusCode: record.StatusCode,
+		Body:       record.ResponseBody,
+	}, nil
+}
+
+func (s *service) Complete(ctx context.Context, userID uuid.UUID, key string, statusCode int, body []byte) error {
+	record, err := s.repo.FindByKey(ctx, userID, key)
+	if err != nil {
+		return fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return s.repo.Complete(ctx, record.ID, statusCode, body)
+}
+
+func (s *service) Release(ctx context.Context, userID uuid.UUID, key string) error {
+	record, err := s.repo.FindByKey(ctx, userID, key)
+	if err != nil {
+		return fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return s.repo.Delete(ctx, record.ID)
+}
+
+func (s *service) PurgeExpired(ctx context.Context) error {
+	purged, err := s.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		s.logger.Info("Purged expired idempotency keys", logger.Int("keys", int(purged)))
+	}
+	return nil
+}
+
+// requestHash fingerprints a request so a reused key with a different
+// payload or endpoint is caught
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,141 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/idempotency"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeys is an IdempotencyKeyRepository over a map keyed by user and key
+type memoryKeys struct {
+	repository.IdempotencyKeyRepository
+	nextID int64
+	keys   map[string]*entity.IdempotencyKey
+}
+
+func newMemoryKeys() *memoryKeys {
+	return &memoryKeys{keys: map[string]*entity.IdempotencyKey{}}
+}
+
+func (r *memoryKeys) Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	id := key.UserID.String() + "/" + key.Key
+	if existing, ok := r.keys[id]; ok && existing.ExpiresAt.After(time.Now()) {
+		return false, nil
+	}
+	r.nextID++
+	stored := *key
+	stored.ID = r.nextID
+	r.keys[id] = &stored
+	return true, nil
+}
+
+func (r *memoryKeys) FindByKey(ctx context.Context, userID uuid.UUID, key string) (*entity.IdempotencyKey, error) {
+	record, ok := r.keys[userID.String()+"/"+key]
+	if !ok {
+		return nil, apperror.NotFound("idempotency key not found")
+	}
+	found := *record
+	return &found, nil
+}
+
+func (r *memoryKeys) Complete(ctx context.Context, id int64, statusCode int, body []byte) error {
+	for _, record := range r.keys {
+		if record.ID == id {
+			record.StatusCode = statusCode
+			record.ResponseBody = body
+		}
+	}
+	return nil
+}
+
+func (r *memoryKeys) Delete(ctx context.Context, id int64) error {
+	for k, record := range r.keys {
+		if record.ID == id {
+			delete(r.keys, k)
+		}
+	}
+	return nil
+}
+
+func newService(t *testing.T, keys *memoryKeys) idempotency.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return idempotency.NewService(keys, time.Hour, log)
+}
+
+func TestBegin_ReplaysCompletedRequest(t *testing.T) {
+	ctx := context.Background()
+	user := uuid.New()
+	service := newService(t, newMemoryKeys())
+	body := []byte(`{"card_id":1,"amount":2500}`)
+
+	stored, err := service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/transactions", body)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+	require.NoError(t, service.Complete(ctx, user, "abc", http.StatusCreated, []byte(`{"id":7}`)))
+
+	stored, err = service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/transactions", body)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, http.StatusCreated, stored.StatusCode)
+	assert.JSONEq(t, `{"id":7}`, string(stored.Body))
+
+	// Keys are scoped to the user
+	stored, err = service.Begin(ctx, uuid.New(), "abc", http.MethodPost, "/api/v1/transactions", body)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_ConflictingReuse(t *testing.T) {
+	ctx := context.Background()
+	user := uuid.New()
+	service := newService(t, newMemoryKeys())
+
+	_, err := service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/transactions", []byte(`{"amount":2500}`))
+	require.NoError(t, err)
+
+	// Still in flight
+	_, err = service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/transactions", []byte(`{"amount":2500}`))
+	assert.True(t, apperror.Is(err, apperror.CodeConflict))
+
+	require.NoError(t, service.Complete(ctx, user, "abc", http.StatusCreated, []byte(`{"id":7}`)))
+
+	_, err = service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/transactions", []byte(`{"amount":9900}`))
+	assert.True(t, apperror.Is(err, apperror.CodeConflict))
+	_, err = service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/cards", []byte(`{"amount":2500}`))
+	assert.True(t, apperror.Is(err, apperror.CodeConflict))
+}
+
+func TestRelease_AllowsRetry(t *testing.T) {
+	ctx := context.Background()
+	user := uuid.New()
+	keys := newMemoryKeys()
+	service := newService(t, keys)
+	body := []byte(`{"alias":"Main"}`)
+
+	_, err := service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/cards", body)
+	require.NoError(t, err)
+	require.NoError(t, service.Release(ctx, user, "abc"))
+	assert.Empty(t, keys.keys)
+
+	stored, err := service.Begin(ctx, user, "abc", http.MethodPost, "/api/v1/cards", body)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestBegin_RejectsInvalidKey(t *testing.T) {
+	service := newService(t, newMemoryKeys())
+
+	_, err := service.Begin(context.Background(), uuid.New(), string(make([]byte, idempotency.MaxKeyLength+1)), http.MethodPost, "/api/v1/cards", nil)
+	assert.True(t, apperror.Is(err, apperror.CodeValidation))
+}
@@ -0,0 +1,36 @@
+package networth
+
+import "time"
+
+// DateLayout is the format of the from/to query parameters
+const DateLayout = "2006-01-02"
+
+// HistoryQuery contains net worth history parameters (YYYY-MM-DD)
+type HistoryQuery struct {
+	From string `form:"from" binding:"omitempty"`
+	To   string `form:"to" binding:"omitempty"`
+}
+
+// AccountBalance contains an account balance within a snapshot
+type AccountBalance struct {
+	CardID      int64  `json:"card_id"`
+	AccountType string `json:"account_type"`
+	Balance     int64  `json:"balance"`
+}
+
+// SnapshotResponse contains net worth for a single day
+type SnapshotResponse struct {
+	Date             time.Time        `json:"date"`
+	TotalAssets      int64            `json:"total_assets"`
+	TotalLiabilities int64            `json:"total_liabilities"`
+	NetWorth         int64            `json:"net_worth"`
+	Computed         bool             `json:"computed"` // recomputed from the ledger, no stored snapshot
+	Accounts         []AccountBalance `json:"accounts"`
+}
+
+// HistoryResponse contains net worth over a date range
+type HistoryResponse struct {
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Snapshots []SnapshotResponse `json:"snapshots"`
+}
@@ -0,0 +1,302 @@
+package networth
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxHistoryDays limits the size of a history request
+const MaxHistoryDays = 366
+
+type Service interface {
+	TakeSnapshot(ctx context.Context, userID uuid.UUID) (*SnapshotResponse, error)
+	TakeDailySnapshots(ctx context.Context) error
+	GetHistory(ctx context.Context, userID uuid.UUID, query HistoryQuery) (*HistoryResponse, error)
+	Recompute(ctx context.Context, userID uuid.UUID, query HistoryQuery) (*HistoryResponse, error)
+}
+
+type service struct {
+	netWorthRepo repository.NetWorthRepository
+	cardRepo     repository.CardRepository
+	txRepo       repository.TransactionRepository
+	userRepo     repository.UserRepository
+	logger       *logger.Logger
+}
+
+func NewService(
+	netWorthRepo repository.NetWorthRepository,
+	cardRepo repository.CardRepository,
+	txRepo repository.TransactionRepository,
+	userRepo repository.UserRepository,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		netWorthRepo: netWorthRepo,
+		cardRepo:     cardRepo,
+		txRepo:       txRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
+
+// TakeSnapshot records today's balances for a user, replacing an earlier snapshot of the same day
+func (s *service) TakeSnapshot(ctx context.Context, userID uuid.UUID) (*SnapshotResponse, error) {
+	cards, err := s.cardRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	balances := make(map[int64]int64, len(cards))
+	for _, card := range cards {
+		balances[card.ID] = card.Balance
+	}
+
+	snapshot := buildSnapshot(userID, today(), cards, balances)
+	if err := s.netWorthRepo.Save(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return toResponse(snapshot, false), nil
+}
+
+// TakeDailySnapshots records today's snapshot for every active user
+func (s *service) TakeDailySnapshots(ctx context.Context) error {
+	userIDs, err := s.userRepo.FindActiveIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	failed := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := s.TakeSnapshot(ctx, userID); err != nil {
+			failed++
+			s.logger.Error("Failed to take net worth snapshot", logger.String("user_id", userID.String()), logger.Error(err))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to take %d of %d net worth snapshots", failed, len(userIDs))
+	}
+	return nil
+}
+
+// GetHistory returns stored snapshots, filling missing days from the transaction ledger
+func (s *service) GetHistory(ctx context.Context, userID uuid.UUID, query HistoryQuery) (*HistoryResponse, error) {
+	from, to, err := parseRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.netWorthRepo.FindByUserID(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+
+	computed, err := s.computeMissing(ctx, userID, from, to, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]SnapshotResponse, 0, len(stored)+len(computed))
+	for i := range stored {
+		snapshots = append(snapshots, *toResponse(&stored[i], false))
+	}
+	for i := range computed {
+		snapshots = append(snapshots, *toResponse(&computed[i], true))
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Date.Before(snapshots[j].Date)
+	})
+
+	return &HistoryResponse{
+		From:      from,
+		To:        to,
+		Snapshots: snapshots,
+	}, nil
+}
+
+// Recompute stores snapshots rebuilt from the transaction ledger for days that have none
+func (s *service) Recompute(ctx context.Context, userID uuid.UUID, query HistoryQuery) (*HistoryResponse, error) {
+	from, to, err := parseRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.netWorthRepo.FindByUserID(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+
+	computed, err := s.computeMissing(ctx, userID, from, to, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range computed {
+		if err := s.netWorthRepo.Save(ctx, &computed[i]); err != nil {
+			return nil, fmt.Errorf("failed to save snapshot: %w", err)
+		}
+	}
+
+	return s.GetHistory(ctx, userID, query)
+}
+
+// computeMissing rebuilds end-of-day balances for days in [from, to] without a
+// stored snapshot by walking back from current balances through the ledger.
+func (s *service) computeMissing(ctx context.Context, userID uuid.UUID, from, to time.Time, stored []entity.NetWorthSnapshot) ([]entity.NetWorthSnapshot, error) {
+	existing := make(map[time.Time]bool, len(stored))
+	for _, snapshot := range stored {
+		existing[truncateDay(snapshot.SnapshotDate)] = true
+	}
+
+	cards, err := s.cardRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	// Accounts deleted since still count on the days before their deletion
+	deleted, err := s.cardRepo.FindDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted accounts: %w", err)
+	}
+	for _, card := range deleted {
+		if card.DeletedAt.Time.After(from) {
+			cards = append(cards, card)
+		}
+	}
+
+	changes, err := s.txRepo.GetDailyBalanceChanges(ctx, userID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger: %w", err)
+	}
+
+	balances := make(map[int64]int64, len(cards))
+	for _, card := range cards {
+		balances[card.ID] = card.Balance
+	}
+
+	var computed []entity.NetWorthSnapshot
+	next := 0
+	for day := today(); !day.Before(from); day = day.AddDate(0, 0, -1) {
+		// Undo everything posted after this day
+		for next < len(changes) && truncateDay(changes[next].TransactionDate).After(day) {
+			balances[changes[next].CardID] -= changes[next].Change
+			next++
+		}
+
+		if day.After(to) || existing[day] {
+			continue
+		}
+		computed = append(computed, *buildSnapshot(userID, day, cards, balances))
+	}
+
+	return computed, nil
+}
+
+// buildSnapshot totals account balances into assets and liabilities
+func buildSnapshot(userID uuid.UUID, day time.Time, cards []entity.Card, balances map[int64]int64) *entity.NetWorthSnapshot {
+	snapshot := &entity.NetWorthSnapshot{
+		UserID:       userID,
+		SnapshotDate: day,
+		Items:        make([]entity.NetWorthSnapshotItem, 0, len(cards)),
+	}
+
+	endOfDay := day.AddDate(0, 0, 1)
+	for _, card := range cards {
+		// Accounts opened after this day did not exist yet, and accounts
+		// deleted on or before it no longer did
+		if !card.CreatedAt.IsZero() && !card.CreatedAt.Before(endOfDay) {
+			continue
+		}
+		if card.DeletedAt.Valid && card.DeletedAt.Time.Before(endOfDay) {
+			continue
+		}
+
+		balance := balances[card.ID]
+		snapshot.Items = append(snapshot.Items, entity.NetWorthSnapshotItem{
+			CardID:      card.ID,
+			AccountType: card.AccountType,
+			Balance:     balance,
+		})
+
+		if card.IsLiability() {
+			snapshot.TotalLiabilities -= balance
+		} else {
+			snapshot.TotalAssets += balance
+		}
+	}
+	snapshot.NetWorth = snapshot.TotalAssets - snapshot.TotalLiabilities
+
+	return snapshot
+}
+
+func parseRange(query HistoryQuery) (time.Time, time.Time, error) {
+	to := today()
+	if query.To != "" {
+		parsed, err := time.Parse(DateLayout, query.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.Validation("invalid to date format, expected YYYY-MM-DD").WithField("to", "must be YYYY-MM-DD")
+		}
+		if parsed.Before(to) {
+			to = parsed
+		}
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if query.From != "" {
+		parsed, err := time.Parse(DateLayout, query.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.Validation("invalid from date format, expected YYYY-MM-DD").WithField("from", "must be YYYY-MM-DD")
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, apperror.Validation("from must not be after to")
+	}
+	if to.Sub(from) > MaxHistoryDays*24*time.Hour {
+		return time.Time{}, time.Time{}, apperror.Validation("date range must not exceed %d days", MaxHistoryDays)
+	}
+
+	return from, to, nil
+}
+
+func today() time.Time {
+	return truncateDay(time.Now())
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toResponse(snapshot *entity.NetWorthSnapshot, computed bool) *SnapshotResponse {
+	accounts := make([]AccountBalance, len(snapshot.Items))
+	for i, item := range snapshot.Items {
+		accounts[i] = AccountBalance{
+			CardID:      item.CardID,
+			AccountType: item.AccountType,
+			Balance:     item.Balance,
+		}
+	}
+
+	return &SnapshotResponse{
+		Date:             truncateDay(snapshot.SnapshotDate),
+		TotalAssets:      snapshot.TotalAssets,
+		TotalLiabilities: snapshot.TotalLiabilities,
+		NetWorth:         snapshot.NetWorth,
+		Computed:         computed,
+		Accounts:         accounts,
+	}
+}
@@ -0,0 +1,204 @@
+package networth_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/networth"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// storedSnapshots is a NetWorthRepository over a slice
+type storedSnapshots struct {
+	repository.NetWorthRepository
+	snapshots []entity.NetWorthSnapshot
+}
+
+func (r *storedSnapshots) FindByUserID(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]entity.NetWorthSnapshot, error) {
+	return r.snapshots, nil
+}
+
+// listedCards is a CardRepository that only lists the user's live and
+// deleted cards
+type listedCards struct {
+	repository.CardRepository
+	cards []entity.Card
+}
+
+func (r *listedCards) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Card, error) {
+	var cards []entity.Card
+	for _, card := range r.cards {
+		if !card.DeletedAt.Valid {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+func (r *listedCards) FindDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Card, error) {
+	var cards []entity.Card
+	for _, card := range r.cards {
+		if card.DeletedAt.Valid {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+// ledger is a TransactionRepository that only knows daily balance changes,
+// newest first like the database returns them
+type ledger struct {
+	repository.TransactionRepository
+	changes []repository.DailyBalanceChange
+}
+
+func (r *ledger) GetDailyBalanceChanges(ctx context.Context, userID uuid.UUID, after time.Time) ([]repository.DailyBalanceChange, error) {
+	var changes []repository.DailyBalanceChange
+	for _, change := range r.changes {
+		if change.TransactionDate.After(after) {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func newService(t *testing.T, snapshots []entity.NetWorthSnapshot, cards []entity.Card, changes []repository.DailyBalanceChange) networth.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return networth.NewService(&storedSnapshots{snapshots: snapshots}, &listedCards{cards: cards}, &ledger{changes: changes}, nil, log)
+}
+
+func daysAgo(n int) time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -n)
+}
+
+func TestGetHistory_WalksBackThroughLedger(t *testing.T) {
+	owner := uuid.New()
+	longAgo := daysAgo(100)
+
+	tests := []struct {
+		name      string
+		snapshots []entity.NetWorthSnapshot
+		cards     []entity.Card
+		changes   []repository.DailyBalanceChange
+		// net worth from three days ago up to today
+		netWorth []int64
+		computed []bool
+	}{
+		{
+			name:     "no transactions keeps current balances",
+			cards:    []entity.Card{{ID: 1, AccountType: entity.AccountTypeChecking, Balance: 10000, CreatedAt: longAgo}},
+			netWorth: []int64{10000, 10000, 10000, 10000},
+			computed: []bool{true, true, true, true},
+		},
+		{
+			name: "undoes transactions posted after each day",
+			cards: []entity.Card{
+				{ID: 1, AccountType: entity.AccountTypeChecking, Balance: 10000, CreatedAt: longAgo},
+				{ID: 2, AccountType: entity.AccountTypeCash, Balance: 500, CreatedAt: longAgo},
+			},
+			changes: []repository.DailyBalanceChange{
+				{CardID: 1, TransactionDate: daysAgo(0).Add(9 * time.Hour), Change: -2500},
+				{CardID: 2, TransactionDate: daysAgo(1), Change: 300},
+				{CardID: 1, TransactionDate: daysAgo(2).Add(18 * time.Hour), Change: 4000},
+			},
+			netWorth: []int64{8700, 12700, 13000, 10500},
+			computed: []bool{true, true, true, true},
+		},
+		{
+			name: "subtracts liabilities",
+			cards: []entity.Card{
+				{ID: 1, AccountType: entity.AccountTypeSavings, Balance: 10000, CreatedAt: longAgo},
+				{ID: 2, AccountType: entity.AccountTypeCredit, Balance: -3000, CreatedAt: longAgo},
+			},
+			changes: []repository.DailyBalanceChange{
+				{CardID: 2, TransactionDate: daysAgo(1), Change: -1000},
+			},
+			netWorth: []int64{8000, 8000, 7000, 7000},
+			computed: []bool{true, true, true, true},
+		},
+		{
+			name: "skips accounts opened later",
+			cards: []entity.Card{
+				{ID: 1, AccountType: entity.AccountTypeChecking, Balance: 10000, CreatedAt: longAgo},
+				{ID: 2, AccountType: entity.AccountTypeCash, Balance: 2000, CreatedAt: daysAgo(1).Add(12 * time.Hour)},
+			},
+			changes: []repository.DailyBalanceChange{
+				{CardID: 2, TransactionDate: daysAgo(1).Add(12 * time.Hour), Change: 2000},
+			},
+			netWorth: []int64{10000, 10000, 12000, 12000},
+			computed: []bool{true, true, true, true},
+		},
+		{
+			name: "counts accounts until they were deleted",
+			cards: []entity.Card{
+				{ID: 1, AccountType: entity.AccountTypeChecking, Balance: 10000, CreatedAt: longAgo},
+				{ID: 2, AccountType: entity.AccountTypeCash, Balance: 3000, CreatedAt: longAgo,
+					DeletedAt: gorm.DeletedAt{Time: daysAgo(1).Add(10 * time.Hour), Valid: true}},
+				{ID: 3, AccountType: entity.AccountTypeCash, Balance: 5000, CreatedAt: longAgo,
+					DeletedAt: gorm.DeletedAt{Time: daysAgo(10), Valid: true}},
+			},
+			changes: []repository.DailyBalanceChange{
+				{CardID: 2, TransactionDate: daysAgo(2), Change: 1000},
+			},
+			netWorth: []int64{12000, 13000, 10000, 10000},
+			computed: []bool{true, true, true, true},
+		},
+		{
+			name: "keeps stored snapshots",
+			snapshots: []entity.NetWorthSnapshot{
+				{UserID: owner, SnapshotDate: daysAgo(2), TotalAssets: 9999, NetWorth: 9999},
+			},
+			cards:    []entity.Card{{ID: 1, AccountType: entity.AccountTypeChecking, Balance: 10000, CreatedAt: longAgo}},
+			netWorth: []int64{10000, 9999, 10000, 10000},
+			computed: []bool{true, false, true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newService(t, tt.snapshots, tt.cards, tt.changes)
+
+			resp, err := service.GetHistory(context.Background(), owner, networth.HistoryQuery{
+				From: daysAgo(3).Format(networth.DateLayout),
+			})
+			require.NoError(t, err)
+
+			require.Len(t, resp.Snapshots, len(tt.netWorth))
+			for i, snapshot := range resp.Snapshots {
+				assert.Equal(t, daysAgo(3-i), snapshot.Date)
+				assert.Equal(t, tt.netWorth[i], snapshot.NetWorth, "net worth %d days ago", 3-i)
+				assert.Equal(t, tt.computed[i], snapshot.Computed, "computed %d days ago", 3-i)
+			}
+		})
+	}
+}
+
+func TestGetHistory_RejectsInvalidRange(t *testing.T) {
+	service := newService(t, nil, nil, nil)
+
+	tests := []struct {
+		name  string
+		query networth.HistoryQuery
+	}{
+		{"malformed from", networth.HistoryQuery{From: "01/02/2026"}},
+		{"malformed to", networth.HistoryQuery{To: "yesterday"}},
+		{"from after to", networth.HistoryQuery{From: daysAgo(1).Format(networth.DateLayout), To: daysAgo(2).Format(networth.DateLayout)}},
+		{"range too long", networth.HistoryQuery{From: daysAgo(networth.MaxHistoryDays + 1).Format(networth.DateLayout)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetHistory(context.Background(), uuid.New(), tt.query)
+			assert.Error(t, err)
+		})
+	}
+}
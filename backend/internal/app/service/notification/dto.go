@@ -0,0 +1,49 @@
+package notification
+
+import "time"
+
+// NotificationFilter contains notification list parameters
+type NotificationFilter struct {
+	Unread bool   `form:"unread"`
+	Type   string `form:"type" binding:"omitempty,max=50"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// MarkRequest sets the read state of notifications. Without ids every
+// notification of the user is updated.
+type MarkRequest struct {
+	IDs  []int64 `json:"ids" binding:"omitempty,max=500"`
+	Read *bool   `json:"read" binding:"required"`
+}
+
+// ReadRequest sets the read state of a single notification
+type ReadRequest struct {
+	Read *bool `json:"read" binding:"required"`
+}
+
+// NotificationResponse contains notification data
+type NotificationResponse struct {
+	ID        int64      `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationListResponse contains paginated notifications
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Total         int64                  `json:"total"`
+	UnreadCount   int64                  `json:"unread_count"`
+	Limit         int                    `json:"limit"`
+	Offset        int                    `json:"offset"`
+}
+
+// MarkResponse reports how many notifications changed state
+type MarkResponse struct {
+	Updated     int64 `json:"updated"`
+	UnreadCount int64 `json:"unread_count"`
+}
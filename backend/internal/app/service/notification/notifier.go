@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+
+	"github.com/google/uuid"
+)
+
+// Message is a notification to deliver to a user
+type Message struct {
+	Type  string
+	Title string
+	Body  string
+}
+
+// Notifier delivers notifications to users. Callers do not know whether a
+// message ends up in the app, an email or anywhere else.
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, msg Message) error
+}
+
+type inAppNotifier struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewInAppNotifier creates a Notifier that stores notifications for the in-app inbox
+func NewInAppNotifier(notificationRepo repository.NotificationRepository) Notifier {
+	return &inAppNotifier{notificationRepo: notificationRepo}
+}
+
+func (n *inAppNotifier) Notify(ctx context.Context, userID uuid.UUID, msg Message) error {
+	notification := &entity.Notification{
+		UserID:  userID,
+		Type:    msg.Type,
+		Title:   msg.Title,
+		Message: msg.Body,
+	}
+	if err := n.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to store notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetNotifications(ctx context.Context, userID uuid.UUID, filter NotificationFilter) (*NotificationListResponse, error)
+	MarkNotifications(ctx context.Context, userID uuid.UUID, req MarkRequest) (*MarkResponse, error)
+	MarkNotification(ctx context.Context, notificationID int64, userID uuid.UUID, req ReadRequest) (*MarkResponse, error)
+	// HandleEvent turns user-facing events into notifications; subscribe it to the event bus
+	HandleEvent(ctx context.Context, event events.Event) error
+}
+
+type service struct {
+	notificationRepo repository.NotificationRepository
+	notifier         Notifier
+}
+
+func NewService(notificationRepo repository.NotificationRepository, notifier Notifier) Service {
+	return &service{
+		notificationRepo: notificationRepo,
+		notifier:         notifier,
+	}
+}
+
+func (s *service) GetNotifications(ctx context.Context, userID uuid.UUID, filter NotificationFilter) (*NotificationListResponse, error) {
+	if filter.Limit == 0 {
+		filter.Limit = 20
+	}
+
+	repoFilter := repository.NotificationFilter{
+		UnreadOnly: filter.Unread,
+		Type:       filter.Type,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}
+
+	notifications, err := s.notificationRepo.FindByUserID(ctx, userID, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	total, err := s.notificationRepo.Count(ctx, userID, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	unread, err := s.unreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]NotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = toResponse(&notifications[i])
+	}
+
+	return &NotificationListResponse{
+		Notifications: responses,
+		Total:         total,
+		UnreadCount:   unread,
+		Limit:         filter.Limit,
+		Offset:        filter.Offset,
+	}, nil
+}
+
+func (s *service) MarkNotifications(ctx context.Context, userID uuid.UUID, req MarkRequest) (*MarkResponse, error) {
+	return s.mark(ctx, userID, req.IDs, *req.Read)
+}
+
+func (s *service) MarkNotification(ctx context.Context, notificationID int64, userID uuid.UUID, req ReadRequest) (*MarkResponse, error) {
+	return s.mark(ctx, userID, []int64{notificationID}, *req.Read)
+}
+
+func (s *service) mark(ctx context.Context, userID uuid.UUID, ids []int64, read bool) (*MarkResponse, error) {
+	// Scoped by user, so other users' ids are silently ignored
+	updated, err := s.notificationRepo.SetRead(ctx, userID, ids, read)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notifications: %w", err)
+	}
+
+	unread, err := s.unreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkResponse{Updated: updated, UnreadCount: unread}, nil
+}
+
+func (s *service) HandleEvent(ctx context.Context, event events.Event) error {
+	msg, ok := messageFor(event)
+	if !ok {
+		return nil
+	}
+	return s.notifier.Notify(ctx, event.UserID, msg)
+}
+
+// messageFor returns the notification for an event, if users should hear about it
+func messageFor(event events.Event) (Message, bool) {
+	switch event.Type {
+	case events.UserLoggedIn:
+		body := "Your account was signed in to"
+		if ip, _ := event.Data["ip_address"].(string); ip != "" {
+			body += " from " + ip
+		}
+		if agent, _ := event.Data["user_agent"].(string); agent != "" {
+			body += " (" + agent + ")"
+		}
+		return Message{
+			Type:  entity.NotificationTypeNewSignIn,
+			Title: "New sign-in",
+			Body:  body + ". If this wasn't you, change your password.",
+		}, true
+	case events.PasswordChanged:
+		return Message{
+			Type:  entity.NotificationTypePasswordChanged,
+			Title: "Password changed",
+			Body:  "Your password was changed and other sessions were signed out.",
+		}, true
+	case events.CardFrozen:
+		return Message{
+			Type:  entity.NotificationTypeCardFrozen,
+			Title: "Card frozen",
+			Body:  fmt.Sprintf("%s was frozen. New transactions on it will be declined.", cardLabel(event)),
+		}, true
+	case events.CardUnfrozen:
+		return Message{
+			Type:  entity.NotificationTypeCardUnfrozen,
+			Title: "Card unfrozen",
+			Body:  fmt.Sprintf("%s accepts transactions again.", cardLabel(event)),
+		}, true
+	case events.FrozenCardAttempt:
+		return Message{
+			Type:  entity.NotificationTypeFrozenCardAttempt,
+			Title: "Transaction declined on frozen card",
+			Body:  fmt.Sprintf("A transaction of %v was attempted on %s while it is frozen.", event.Data["amount"], cardLabel(event)),
+		}, true
+	}
+	return Message{}, false
+}
+
+func cardLabel(event events.Event) string {
+	if last4, _ := event.Data["last4"].(string); last4 != "" {
+		return "Card ending in " + last4
+	}
+	if alias, _ := event.Data["alias"].(string); alias != "" {
+		return alias
+	}
+	return "Your card"
+}
+
+func (s *service) unreadCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	unread, err := s.notificationRepo.Count(ctx, userID, repository.NotificationFilter{UnreadOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return unread, nil
+}
+
+func toResponse(notification *entity.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Read:      notification.IsRead(),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package payee
+
+import "time"
+
+// CreatePayeeRequest contains payee creation data
+type CreatePayeeRequest struct {
+	Name    string         `json:"name" binding:"required,max=100"`
+	Aliases []AliasRequest `json:"aliases" binding:"omitempty,dive"`
+}
+
+// UpdatePayeeRequest contains payee update data
+type UpdatePayeeRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// AliasRequest maps descriptions to a payee
+type AliasRequest struct {
+	Pattern     string `json:"pattern" binding:"required,max=255"`
+	PatternType string `json:"pattern_type" binding:"omitempty,oneof=contains regex"`
+}
+
+// MergePayeesRequest merges the source payees into the target payee
+type MergePayeesRequest struct {
+	SourceIDs []int64 `json:"source_ids" binding:"required,min=1"`
+}
+
+// SplitPayeeRequest moves aliases and transactions of a payee to a new payee.
+// Transactions matching a moved alias move with it. Transaction IDs that do
+// not belong to the payee are skipped.
+type SplitPayeeRequest struct {
+	Name           string  `json:"name" binding:"required,max=100"`
+	AliasIDs       []int64 `json:"alias_ids"`
+	TransactionIDs []int64 `json:"transaction_ids"`
+}
+
+// PayeeStatsQuery contains payee analytics parameters
+type PayeeStatsQuery struct {
+	StartDate *time.Time `form:"start_date" binding:"omitempty"`
+	EndDate   *time.Time `form:"end_date" binding:"omitempty"`
+}
+
+// AliasResponse contains payee alias data
+type AliasResponse struct {
+	ID          int64  `json:"id"`
+	Pattern     string `json:"pattern"`
+	PatternType string `json:"pattern_type"`
+}
+
+// PayeeResponse contains payee data
+type PayeeResponse struct {
+	ID             int64           `json:"id"`
+	Name           string          `json:"name"`
+	NormalizedName string          `json:"normalized_name"`
+	Aliases        []AliasResponse `json:"aliases"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// PayeeStatResponse contains spending analytics for a payee
+type PayeeStatResponse struct {
+	PayeeID          int64     `json:"payee_id"`
+	Name             string    `json:"name"`
+	TotalExpense     int64     `json:"total_expense"`
+	TotalIncome      int64     `json:"total_income"`
+	TransactionCount int64     `json:"transaction_count"`
+	AverageAmount    int64     `json:"average_amount"` // (expense + income) / count
+	FirstDate        time.Time `json:"first_date"`
+	LastDate         time.Time `json:"last_date"`
+}
+
+// SplitPayeeResponse contains the result of splitting a payee
+type SplitPayeeResponse struct {
+	Payee             PayeeResponse `json:"payee"`
+	MovedTransactions int64         `json:"moved_transactions"`
+}
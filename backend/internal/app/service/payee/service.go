@@ -0,0 +1,537 @@
+package payee
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/merchant"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// splitBatchSize is the number of transactions scanned per page when splitting a payee
+const splitBatchSize = 500
+
+// maxCachedPatterns bounds the compiled regex aliases kept between calls
+const maxCachedPatterns = 1000
+
+type Service interface {
+	CreatePayee(ctx context.Context, userID uuid.UUID, req CreatePayeeRequest) (*PayeeResponse, error)
+	GetPayees(ctx context.Context, userID uuid.UUID) ([]PayeeResponse, error)
+	GetPayee(ctx context.Context, payeeID int64, userID uuid.UUID) (*PayeeResponse, error)
+	UpdatePayee(ctx context.Context, payeeID int64, userID uuid.UUID, req UpdatePayeeRequest) (*PayeeResponse, error)
+	DeletePayee(ctx context.Context, payeeID int64, userID uuid.UUID) error
+	AddAlias(ctx context.Context, payeeID int64, userID uuid.UUID, req AliasRequest) (*PayeeResponse, error)
+	DeleteAlias(ctx context.Context, payeeID, aliasID int64, userID uuid.UUID) error
+	MergePayees(ctx context.Context, targetID int64, userID uuid.UUID, req MergePayeesRequest) (*PayeeResponse, error)
+	SplitPayee(ctx context.Context, payeeID int64, userID uuid.UUID, req SplitPayeeRequest) (*SplitPayeeResponse, error)
+	GetStats(ctx context.Context, userID uuid.UUID, payeeID *int64, query PayeeStatsQuery) ([]PayeeStatResponse, error)
+	// Resolve links a new transaction to a payee by alias or normalized
+	// description, creating the payee when none matches.
+	Resolve(ctx context.Context, tx *entity.Transaction) error
+}
+
+type service struct {
+	payeeRepo repository.PayeeRepository
+	txRepo    repository.TransactionRepository
+	auditor   audit.Recorder
+
+	// Regex aliases compiled by pattern, so resolving does not recompile
+	// them for every transaction
+	mu       sync.RWMutex
+	patterns map[string]*regexp.Regexp
+}
+
+func NewService(
+	payeeRepo repository.PayeeRepository,
+	txRepo repository.TransactionRepository,
+	auditor audit.Recorder,
+) Service {
+	return &service{
+		payeeRepo: payeeRepo,
+		txRepo:    txRepo,
+		auditor:   auditor,
+		patterns:  make(map[string]*regexp.Regexp),
+	}
+}
+
+func (s *service) CreatePayee(ctx context.Context, userID uuid.UUID, req CreatePayeeRequest) (*PayeeResponse, error) {
+	aliases := make([]entity.PayeeAlias, len(req.Aliases))
+	for i, aliasReq := range req.Aliases {
+		alias, err := newAlias(aliasReq)
+		if err != nil {
+			return nil, err
+		}
+		aliases[i] = *alias
+	}
+
+	payee := &entity.Payee{
+		UserID:         userID,
+		Name:           req.Name,
+		NormalizedName: normalizedKey(req.Name),
+		Aliases:        aliases,
+	}
+
+	if err := s.payeeRepo.Create(ctx, payee); err != nil {
+		return nil, fmt.Errorf("failed to create payee: %w", err)
+	}
+
+	return toResponse(payee), nil
+}
+
+func (s *service) GetPayees(ctx context.Context, userID uuid.UUID) ([]PayeeResponse, error) {
+	payees, err := s.payeeRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payees: %w", err)
+	}
+
+	responses := make([]PayeeResponse, len(payees))
+	for i := range payees {
+		responses[i] = *toResponse(&payees[i])
+	}
+	return responses, nil
+}
+
+func (s *service) GetPayee(ctx context.Context, payeeID int64, userID uuid.UUID) (*PayeeResponse, error) {
+	payee, err := s.findUserPayee(ctx, payeeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(payee), nil
+}
+
+func (s *service) UpdatePayee(ctx context.Context, payeeID int64, userID uuid.UUID, req UpdatePayeeRequest) (*PayeeResponse, error) {
+	payee, err := s.findUserPayee(ctx, payeeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousKey := payee.NormalizedName
+	payee.Name = req.Name
+	payee.NormalizedName = normalizedKey(req.Name)
+	if err := s.payeeRepo.Update(ctx, payee); err != nil {
+		return nil, err
+	}
+
+	// Keep routing descriptions of the previous name to the payee
+	if previousKey != "" && previousKey != payee.NormalizedName && !hasAlias(payee, previousKey) {
+		alias := &entity.PayeeAlias{
+			PayeeID:     payee.ID,
+			Pattern:     previousKey,
+			PatternType: entity.PatternTypeContains,
+		}
+		if err := s.payeeRepo.CreateAlias(ctx, alias); err != nil {
+			return nil, fmt.Errorf("failed to add alias: %w", err)
+		}
+		payee.Aliases = append(payee.Aliases, *alias)
+	}
+
+	return toResponse(payee), nil
+}
+
+func (s *service) DeletePayee(ctx context.Context, payeeID int64, userID uuid.UUID) error {
+	if _, err := s.findUserPayee(ctx, payeeID, userID); err != nil {
+		return err
+	}
+
+	unlinked, err := s.payeeRepo.Delete(ctx, payeeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete payee: %w", err)
+	}
+	s.recordMoves(ctx, userID, unlinked, nil)
+	return nil
+}
+
+func (s *service) AddAlias(ctx context.Context, payeeID int64, userID uuid.UUID, req AliasRequest) (*PayeeResponse, error) {
+	payee, err := s.findUserPayee(ctx, payeeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	alias, err := newAlias(req)
+	if err != nil {
+		return nil, err
+	}
+	alias.PayeeID = payee.ID
+
+	if err := s.payeeRepo.CreateAlias(ctx, alias); err != nil {
+		return nil, fmt.Errorf("failed to add alias: %w", err)
+	}
+
+	payee.Aliases = append(payee.Aliases, *alias)
+	return toResponse(payee), nil
+}
+
+func (s *service) DeleteAlias(ctx context.Context, payeeID, aliasID int64, userID uuid.UUID) error {
+	payee, err := s.findUserPayee(ctx, payeeID, userID)
+	if err != nil {
+		return err
+	}
+
+	if findAlias(payee, aliasID) == nil {
+		return apperror.NotFound("alias not found")
+	}
+
+	if err := s.payeeRepo.DeleteAlias(ctx, aliasID); err != nil {
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+	return nil
+}
+
+func (s *service) MergePayees(ctx context.Context, targetID int64, userID uuid.UUID, req MergePayeesRequest) (*PayeeResponse, error) {
+	target, err := s.findUserPayee(ctx, targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*entity.Payee, 0, len(req.SourceIDs))
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == targetID {
+			return nil, apperror.Validation("cannot merge a payee into itself")
+		}
+		source, err := s.findUserPayee(ctx, sourceID, userID)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	moved, err := s.payeeRepo.Merge(ctx, target.ID, req.SourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge payees: %w", err)
+	}
+	s.recordMoves(ctx, userID, moved, &target.ID)
+
+	// Keep routing descriptions of the merged payees to the target
+	for _, source := range sources {
+		if source.NormalizedName == "" || source.NormalizedName == target.NormalizedName {
+			continue
+		}
+		alias := &entity.PayeeAlias{
+			PayeeID:     target.ID,
+			Pattern:     source.NormalizedName,
+			PatternType: entity.PatternTypeContains,
+		}
+		if err := s.payeeRepo.CreateAlias(ctx, alias); err != nil {
+			return nil, fmt.Errorf("failed to add alias: %w", err)
+		}
+	}
+
+	merged, err := s.payeeRepo.FindByID(ctx, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload payee: %w", err)
+	}
+	return toResponse(merged), nil
+}
+
+func (s *service) SplitPayee(ctx context.Context, payeeID int64, userID uuid.UUID, req SplitPayeeRequest) (*SplitPayeeResponse, error) {
+	if len(req.AliasIDs) == 0 && len(req.TransactionIDs) == 0 {
+		return nil, apperror.Validation("split needs alias_ids or transaction_ids")
+	}
+
+	source, err := s.findUserPayee(ctx, payeeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var movedAliases []entity.PayeeAlias
+	for _, aliasID := range req.AliasIDs {
+		alias := findAlias(source, aliasID)
+		if alias == nil {
+			return nil, apperror.Validation("alias %d does not belong to payee", aliasID)
+		}
+		movedAliases = append(movedAliases, *alias)
+	}
+
+	target := &entity.Payee{
+		UserID:         userID,
+		Name:           req.Name,
+		NormalizedName: normalizedKey(req.Name),
+	}
+	if err := s.payeeRepo.FindOrCreate(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to create payee: %w", err)
+	}
+	if target.ID == source.ID {
+		return nil, apperror.Validation("split target must differ from the source payee")
+	}
+
+	transactionIDs, err := s.matchingTransactions(ctx, userID, source.ID, movedAliases)
+	if err != nil {
+		return nil, err
+	}
+	transactionIDs = append(transactionIDs, req.TransactionIDs...)
+
+	moved, err := s.payeeRepo.Split(ctx, userID, source.ID, target.ID, req.AliasIDs, transactionIDs)
+	if err != nil {
+		return nil, err
+	}
+	s.recordMoves(ctx, userID, moved, &target.ID)
+
+	target, err = s.payeeRepo.FindByID(ctx, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload payee: %w", err)
+	}
+
+	return &SplitPayeeResponse{
+		Payee:             *toResponse(target),
+		MovedTransactions: int64(len(moved)),
+	}, nil
+}
+
+func (s *service) GetStats(ctx context.Context, userID uuid.UUID, payeeID *int64, query PayeeStatsQuery) ([]PayeeStatResponse, error) {
+	if payeeID != nil {
+		if _, err := s.findUserPayee(ctx, *payeeID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	stats, err := s.payeeRepo.GetStats(ctx, userID, repository.PayeeStatsFilter{
+		PayeeID:   payeeID,
+		StartDate: query.StartDate,
+		EndDate:   query.EndDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee stats: %w", err)
+	}
+
+	responses := make([]PayeeStatResponse, len(stats))
+	for i, stat := range stats {
+		responses[i] = PayeeStatResponse{
+			PayeeID:          stat.PayeeID,
+			Name:             stat.Name,
+			TotalExpense:     stat.TotalExpense,
+			TotalIncome:      stat.TotalIncome,
+			TransactionCount: stat.TransactionCount,
+			FirstDate:        stat.FirstDate,
+			LastDate:         stat.LastDate,
+		}
+		if stat.TransactionCount > 0 {
+			responses[i].AverageAmount = (stat.TotalExpense + stat.TotalIncome) / stat.TransactionCount
+		}
+	}
+	return responses, nil
+}
+
+func (s *service) Resolve(ctx context.Context, tx *entity.Transaction) error {
+	if tx.PayeeID != nil || strings.TrimSpace(tx.Description) == "" {
+		return nil
+	}
+
+	payees, err := s.payeeRepo.FindByUserID(ctx, tx.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get payees: %w", err)
+	}
+
+	description := newDescription(tx.Description)
+	for i := range payees {
+		for _, alias := range payees[i].Aliases {
+			if s.aliasMatches(alias, description) {
+				tx.PayeeID = &payees[i].ID
+				return nil
+			}
+		}
+	}
+
+	key := merchant.Normalize(tx.Description)
+	if key == "" {
+		return nil
+	}
+
+	payee := &entity.Payee{
+		UserID:         tx.UserID,
+		Name:           merchant.DisplayName(key),
+		NormalizedName: key,
+	}
+	if err := s.payeeRepo.FindOrCreate(ctx, payee); err != nil {
+		return err
+	}
+
+	tx.PayeeID = &payee.ID
+	return nil
+}
+
+// recordMoves audits each transaction moved to payeeID (nil when unlinked),
+// given as it was before
+func (s *service) recordMoves(ctx context.Context, actorID uuid.UUID, moved []entity.Transaction, payeeID *int64) {
+	for i := range moved {
+		after := moved[i]
+		after.PayeeID = payeeID
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     moved[i].UserID,
+			ActorID:    actorID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityTransaction,
+			EntityID:   moved[i].ID,
+			Before:     audit.Transaction(&moved[i]),
+			After:      audit.Transaction(&after),
+		})
+	}
+}
+
+// matchingTransactions returns the payee's transactions whose description
+// matches any of the given aliases
+func (s *service) matchingTransactions(ctx context.Context, userID uuid.UUID, payeeID int64, aliases []entity.PayeeAlias) ([]int64, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	var ids []int64
+	filter := repository.TransactionFilter{PayeeID: &payeeID, Limit: splitBatchSize}
+	for {
+		transactions, err := s.txRepo.FindByUserID(ctx, userID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+
+		for _, tx := range transactions {
+			description := newDescription(tx.Description)
+			for _, alias := range aliases {
+				if s.aliasMatches(alias, description) {
+					ids = append(ids, tx.ID)
+					break
+				}
+			}
+		}
+
+		if len(transactions) < splitBatchSize {
+			return ids, nil
+		}
+		filter.Offset += splitBatchSize
+	}
+}
+
+func (s *service) findUserPayee(ctx context.Context, payeeID int64, userID uuid.UUID) (*entity.Payee, error) {
+	payee, err := s.payeeRepo.FindByID(ctx, payeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payee.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to payee")
+	}
+	return payee, nil
+}
+
+func newAlias(req AliasRequest) (*entity.PayeeAlias, error) {
+	patternType := req.PatternType
+	if patternType == "" {
+		patternType = entity.PatternTypeContains
+	}
+	if patternType == entity.PatternTypeRegex {
+		if _, err := regexp.Compile("(?i)" + req.Pattern); err != nil {
+			return nil, apperror.Validation("invalid alias pattern: %v", err).WithField("pattern", "must be a valid regular expression")
+		}
+	}
+
+	return &entity.PayeeAlias{
+		Pattern:     req.Pattern,
+		PatternType: patternType,
+	}, nil
+}
+
+// description is a transaction description prepared for alias matching
+type description struct {
+	raw   string
+	upper string
+	key   string
+}
+
+func newDescription(raw string) description {
+	return description{
+		raw:   raw,
+		upper: strings.ToUpper(raw),
+		key:   merchant.Normalize(raw),
+	}
+}
+
+// aliasMatches matches an alias against the raw description; substring
+// aliases also match the normalized description.
+func (s *service) aliasMatches(alias entity.PayeeAlias, d description) bool {
+	if alias.PatternType == entity.PatternTypeRegex {
+		pattern, err := s.compile(alias.Pattern)
+		return err == nil && pattern.MatchString(d.raw)
+	}
+
+	if strings.Contains(d.upper, strings.ToUpper(alias.Pattern)) {
+		return true
+	}
+	key := merchant.Normalize(alias.Pattern)
+	return key != "" && strings.Contains(d.key, key)
+}
+
+// compile returns the case-insensitive regex of an alias pattern. The cache
+// starts over when full, which also drops patterns of deleted aliases.
+func (s *service) compile(pattern string) (*regexp.Regexp, error) {
+	s.mu.RLock()
+	compiled, ok := s.patterns[pattern]
+	s.mu.RUnlock()
+	if ok {
+		return compiled, nil
+	}
+
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.patterns) >= maxCachedPatterns {
+		s.patterns = make(map[string]*regexp.Regexp)
+	}
+	s.patterns[pattern] = compiled
+	return compiled, nil
+}
+
+// normalizedKey returns the merchant key of a payee name, falling back to
+// the upper-cased name when normalization strips everything
+func normalizedKey(name string) string {
+	if key := merchant.Normalize(name); key != "" {
+		return key
+	}
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// hasAlias reports whether the payee already has a substring alias for pattern
+func hasAlias(payee *entity.Payee, pattern string) bool {
+	for _, alias := range payee.Aliases {
+		if alias.PatternType == entity.PatternTypeContains && strings.EqualFold(alias.Pattern, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func findAlias(payee *entity.Payee, aliasID int64) *entity.PayeeAlias {
+	for i := range payee.Aliases {
+		if payee.Aliases[i].ID == aliasID {
+			return &payee.Aliases[i]
+		}
+	}
+	return nil
+}
+
+func toResponse(payee *entity.Payee) *PayeeResponse {
+	aliases := make([]AliasResponse, len(payee.Aliases))
+	for i, alias := range payee.Aliases {
+		aliases[i] = AliasResponse{
+			ID:          alias.ID,
+			Pattern:     alias.Pattern,
+			PatternType: alias.PatternType,
+		}
+	}
+
+	return &PayeeResponse{
+		ID:             payee.ID,
+		Name:           payee.Name,
+		NormalizedName: payee.NormalizedName,
+		Aliases:        aliases,
+		CreatedAt:      payee.CreatedAt,
+	}
+}
@@ -0,0 +1,327 @@
+package payee_test
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/payee"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPayees is a PayeeRepository over a map that enforces unique
+// normalized names per user like the database does
+type memoryPayees struct {
+	repository.PayeeRepository
+	payees       map[int64]*entity.Payee
+	aliasID      int64
+	transactions []*entity.Transaction
+}
+
+func (r *memoryPayees) FindByID(ctx context.Context, id int64) (*entity.Payee, error) {
+	p, ok := r.payees[id]
+	if !ok {
+		return nil, apperror.NotFound("payee not found")
+	}
+	found := *p
+	found.Aliases = append([]entity.PayeeAlias(nil), p.Aliases...)
+	return &found, nil
+}
+
+func (r *memoryPayees) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Payee, error) {
+	var payees []entity.Payee
+	for id := int64(1); id <= int64(len(r.payees)); id++ {
+		if p, ok := r.payees[id]; ok && p.UserID == userID {
+			payees = append(payees, *p)
+		}
+	}
+	return payees, nil
+}
+
+func (r *memoryPayees) FindOrCreate(ctx context.Context, p *entity.Payee) error {
+	for _, existing := range r.payees {
+		if existing.UserID == p.UserID && existing.NormalizedName == p.NormalizedName {
+			*p = *existing
+			return nil
+		}
+	}
+	p.ID = int64(len(r.payees) + 1)
+	stored := *p
+	r.payees[p.ID] = &stored
+	return nil
+}
+
+func (r *memoryPayees) Update(ctx context.Context, p *entity.Payee) error {
+	for _, existing := range r.payees {
+		if existing.ID != p.ID && existing.UserID == p.UserID && existing.NormalizedName == p.NormalizedName {
+			return apperror.Conflict("a payee with this name already exists")
+		}
+	}
+	r.payees[p.ID].Name = p.Name
+	r.payees[p.ID].NormalizedName = p.NormalizedName
+	return nil
+}
+
+func (r *memoryPayees) CreateAlias(ctx context.Context, alias *entity.PayeeAlias) error {
+	r.aliasID++
+	alias.ID = r.aliasID
+	r.payees[alias.PayeeID].Aliases = append(r.payees[alias.PayeeID].Aliases, *alias)
+	return nil
+}
+
+func (r *memoryPayees) Split(ctx context.Context, userID uuid.UUID, sourceID, targetID int64, aliasIDs, transactionIDs []int64) ([]entity.Transaction, error) {
+	source := r.payees[sourceID]
+	var kept []entity.PayeeAlias
+	for _, alias := range source.Aliases {
+		if containsID(aliasIDs, alias.ID) {
+			alias.PayeeID = targetID
+			r.payees[targetID].Aliases = append(r.payees[targetID].Aliases, alias)
+			continue
+		}
+		kept = append(kept, alias)
+	}
+	source.Aliases = kept
+
+	var moved []entity.Transaction
+	for _, tx := range r.transactions {
+		if tx.UserID == userID && tx.PayeeID != nil && *tx.PayeeID == sourceID && containsID(transactionIDs, tx.ID) {
+			moved = append(moved, *tx)
+			tx.PayeeID = &targetID
+		}
+	}
+	return moved, nil
+}
+
+// payeeTransactions is a TransactionRepository over the transactions of a memoryPayees
+type payeeTransactions struct {
+	repository.TransactionRepository
+	payees *memoryPayees
+}
+
+func (r *payeeTransactions) FindByUserID(ctx context.Context, userID uuid.UUID, filter repository.TransactionFilter) ([]entity.Transaction, error) {
+	var transactions []entity.Transaction
+	for _, tx := range r.payees.transactions {
+		if tx.UserID == userID && tx.PayeeID != nil && *tx.PayeeID == *filter.PayeeID {
+			transactions = append(transactions, *tx)
+		}
+	}
+	if filter.Offset >= len(transactions) {
+		return nil, nil
+	}
+	return transactions[filter.Offset:], nil
+}
+
+// auditLog is an AuditLogRepository that keeps entries in memory
+type auditLog struct {
+	repository.AuditLogRepository
+	entries []entity.AuditLog
+}
+
+func (r *auditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func newService(t *testing.T, payees *memoryPayees, entries *auditLog) payee.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return payee.NewService(payees, &payeeTransactions{payees: payees}, audit.NewRecorder(entries, log))
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUpdatePayee_Rename(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name           string
+		newName        string
+		wantNormalized string
+		wantAliases    []string
+		wantErr        apperror.Code
+	}{
+		{"new key keeps the previous one as an alias", "Highlands Coffee", "HIGHLANDS COFFEE", []string{"HIGHLANDS"}, ""},
+		{"same key adds no alias", "highlands", "HIGHLANDS", nil, ""},
+		{"key of another payee", "Grab", "", nil, apperror.CodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payees := &memoryPayees{payees: map[int64]*entity.Payee{
+				1: {ID: 1, UserID: owner, Name: "Highlands", NormalizedName: "HIGHLANDS"},
+				2: {ID: 2, UserID: owner, Name: "Grab", NormalizedName: "GRAB"},
+			}}
+			service := newService(t, payees, &auditLog{})
+
+			resp, err := service.UpdatePayee(context.Background(), 1, owner, payee.UpdatePayeeRequest{Name: tt.newName})
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Equal(t, "HIGHLANDS", payees.payees[1].NormalizedName)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.newName, resp.Name)
+			assert.Equal(t, tt.wantNormalized, resp.NormalizedName)
+			assert.Equal(t, tt.wantNormalized, payees.payees[1].NormalizedName)
+			var aliases []string
+			for _, alias := range payees.payees[1].Aliases {
+				aliases = append(aliases, alias.Pattern)
+			}
+			assert.Equal(t, tt.wantAliases, aliases)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name        string
+		description string
+		wantPayeeID int64
+	}{
+		{"regex alias", "POS 4411 HIGHLANDS Q1", 1},
+		{"regex alias ignores case", "pos 12 highlands", 1},
+		{"substring alias", "GrabFood order 8812", 2},
+		{"substring alias on the normalized description", "PHUC LONG 45", 3},
+		{"normalized name of an existing payee", "Circle K 0123", 4},
+		{"no match creates a payee", "Lotteria 12", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payees := &memoryPayees{payees: map[int64]*entity.Payee{
+				1: {ID: 1, UserID: owner, Name: "Highlands", NormalizedName: "HIGHLANDS", Aliases: []entity.PayeeAlias{
+					{ID: 1, PayeeID: 1, Pattern: `^pos \d+ highlands`, PatternType: entity.PatternTypeRegex},
+				}},
+				2: {ID: 2, UserID: owner, Name: "Grab", NormalizedName: "GRAB", Aliases: []entity.PayeeAlias{
+					{ID: 2, PayeeID: 2, Pattern: "grab", PatternType: entity.PatternTypeContains},
+				}},
+				3: {ID: 3, UserID: owner, Name: "Phuc Long", NormalizedName: "PHUC LONG TEA", Aliases: []entity.PayeeAlias{
+					{ID: 3, PayeeID: 3, Pattern: "Phuc Long #99", PatternType: entity.PatternTypeContains},
+				}},
+				4: {ID: 4, UserID: owner, Name: "Circle K", NormalizedName: "CIRCLE K"},
+			}}
+			service := newService(t, payees, &auditLog{})
+
+			tx := &entity.Transaction{UserID: owner, Description: tt.description}
+			require.NoError(t, service.Resolve(context.Background(), tx))
+
+			require.NotNil(t, tx.PayeeID)
+			assert.Equal(t, tt.wantPayeeID, *tx.PayeeID)
+		})
+	}
+}
+
+func TestSplitPayee(t *testing.T) {
+	owner := uuid.New()
+	payeeID := func(id int64) *int64 { return &id }
+
+	tests := []struct {
+		name           string
+		req            payee.SplitPayeeRequest
+		wantMoved      int64
+		wantMovedIDs   []int64
+		wantAliasCount int
+	}{
+		{
+			name:           "alias moves its matching transactions",
+			req:            payee.SplitPayeeRequest{Name: "GrabFood", AliasIDs: []int64{1}},
+			wantMoved:      2,
+			wantMovedIDs:   []int64{1, 2},
+			wantAliasCount: 1,
+		},
+		{
+			name:         "listed transactions of the payee move",
+			req:          payee.SplitPayeeRequest{Name: "GrabFood", TransactionIDs: []int64{3}},
+			wantMoved:    1,
+			wantMovedIDs: []int64{3},
+		},
+		{
+			name:      "transactions of other payees and users stay",
+			req:       payee.SplitPayeeRequest{Name: "GrabFood", TransactionIDs: []int64{4, 5}},
+			wantMoved: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payees := &memoryPayees{
+				payees: map[int64]*entity.Payee{
+					1: {ID: 1, UserID: owner, Name: "Grab", NormalizedName: "GRAB", Aliases: []entity.PayeeAlias{
+						{ID: 1, PayeeID: 1, Pattern: "grabfood", PatternType: entity.PatternTypeContains},
+					}},
+					2: {ID: 2, UserID: owner, Name: "Highlands", NormalizedName: "HIGHLANDS"},
+				},
+				aliasID: 1,
+				transactions: []*entity.Transaction{
+					{ID: 1, UserID: owner, PayeeID: payeeID(1), Description: "GRABFOOD 1182"},
+					{ID: 2, UserID: owner, PayeeID: payeeID(1), Description: "grabfood order"},
+					{ID: 3, UserID: owner, PayeeID: payeeID(1), Description: "GRAB ride"},
+					{ID: 4, UserID: owner, PayeeID: payeeID(2), Description: "HIGHLANDS"},
+					{ID: 5, UserID: uuid.New(), PayeeID: payeeID(9), Description: "GRAB ride"},
+				},
+			}
+			entries := &auditLog{}
+			service := newService(t, payees, entries)
+
+			resp, err := service.SplitPayee(context.Background(), 1, owner, tt.req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantMoved, resp.MovedTransactions)
+			assert.Len(t, resp.Payee.Aliases, tt.wantAliasCount)
+			var movedIDs []int64
+			for _, tx := range payees.transactions {
+				if *tx.PayeeID == resp.Payee.ID {
+					movedIDs = append(movedIDs, tx.ID)
+				}
+			}
+			assert.Equal(t, tt.wantMovedIDs, movedIDs)
+
+			// Each move is audited as an update of the transaction
+			var auditedIDs []int64
+			for _, entry := range entries.entries {
+				assert.Equal(t, entity.AuditEntityTransaction, entry.EntityType)
+				assert.Equal(t, entity.AuditActionUpdate, entry.Action)
+				assert.Contains(t, *entry.Before, `"payee_id":1`)
+				assert.Contains(t, *entry.After, fmt.Sprintf(`"payee_id":%d`, resp.Payee.ID))
+				id, err := strconv.ParseInt(entry.EntityID, 10, 64)
+				require.NoError(t, err)
+				auditedIDs = append(auditedIDs, id)
+			}
+			assert.Equal(t, tt.wantMovedIDs, auditedIDs)
+			assert.Equal(t, payeeID(2), payees.transactions[3].PayeeID)
+		})
+	}
+}
+
+func TestSplitPayee_ForeignAlias(t *testing.T) {
+	owner := uuid.New()
+	payees := &memoryPayees{payees: map[int64]*entity.Payee{
+		1: {ID: 1, UserID: owner, Name: "Grab", NormalizedName: "GRAB"},
+		2: {ID: 2, UserID: owner, Name: "Highlands", NormalizedName: "HIGHLANDS", Aliases: []entity.PayeeAlias{
+			{ID: 1, PayeeID: 2, Pattern: "highlands", PatternType: entity.PatternTypeContains},
+		}},
+	}}
+	service := newService(t, payees, &auditLog{})
+
+	_, err := service.SplitPayee(context.Background(), 1, owner, payee.SplitPayeeRequest{Name: "GrabFood", AliasIDs: []int64{1}})
+	assert.True(t, apperror.Is(err, apperror.CodeValidation))
+	assert.Len(t, payees.payees[2].Aliases, 1)
+}
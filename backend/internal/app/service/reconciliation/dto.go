@@ -0,0 +1,37 @@
+package reconciliation
+
+import "github.com/google/uuid"
+
+// AdjustBalanceRequest sets a card balance by recording an adjustment transaction
+type AdjustBalanceRequest struct {
+	TargetBalance int64  `json:"target_balance"`
+	Note          string `json:"note" binding:"omitempty,max=255"`
+}
+
+// CardReconciliation compares a card's stored balance with its ledger
+type CardReconciliation struct {
+	CardID           int64  `json:"card_id"`
+	AccountType      string `json:"account_type"`
+	Alias            string `json:"alias,omitempty"`
+	InitialBalance   int64  `json:"initial_balance"`
+	LedgerTotal      int64  `json:"ledger_total"`
+	TransactionCount int64  `json:"transaction_count"`
+	ExpectedBalance  int64  `json:"expected_balance"`
+	ActualBalance    int64  `json:"actual_balance"`
+	Discrepancy      int64  `json:"discrepancy"` // actual - expected
+	Reconciled       bool   `json:"reconciled"`
+}
+
+// ReportResponse contains reconciliation results for all of a user's cards
+type ReportResponse struct {
+	UserID          uuid.UUID            `json:"user_id"`
+	Cards           []CardReconciliation `json:"cards"`
+	DiscrepantCards int                  `json:"discrepant_cards"`
+}
+
+// AdjustmentResponse contains the result of a balance adjustment
+type AdjustmentResponse struct {
+	Card            CardReconciliation `json:"card"`
+	TransactionID   *int64             `json:"transaction_id,omitempty"`
+	AdjustmentValue int64              `json:"adjustment_value"`
+}
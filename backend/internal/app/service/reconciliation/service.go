@@ -0,0 +1,164 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Reconcile(ctx context.Context, userID uuid.UUID) (*ReportResponse, error)
+	AdjustBalance(ctx context.Context, cardID int64, userID uuid.UUID, req AdjustBalanceRequest) (*AdjustmentResponse, error)
+}
+
+type service struct {
+	cardRepo repository.CardRepository
+	txRepo   repository.TransactionRepository
+	auditor  audit.Recorder
+}
+
+func NewService(
+	cardRepo repository.CardRepository,
+	txRepo repository.TransactionRepository,
+	auditor audit.Recorder,
+) Service {
+	return &service{
+		cardRepo: cardRepo,
+		txRepo:   txRepo,
+		auditor:  auditor,
+	}
+}
+
+// Reconcile recomputes each card's expected balance as its initial balance plus
+// the ledger and reports where the stored balance has drifted.
+func (s *service) Reconcile(ctx context.Context, userID uuid.UUID) (*ReportResponse, error) {
+	cards, err := s.cardRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	totals, err := s.ledgerTotals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReportResponse{
+		UserID: userID,
+		Cards:  make([]CardReconciliation, len(cards)),
+	}
+	for i := range cards {
+		report.Cards[i] = reconcileCard(&cards[i], totals[cards[i].ID])
+		if !report.Cards[i].Reconciled {
+			report.DiscrepantCards++
+		}
+	}
+
+	return report, nil
+}
+
+// AdjustBalance brings a card to the target balance. The adjustment transaction
+// is sized against the ledger, so the card is reconciled afterwards even if its
+// stored balance had drifted.
+func (s *service) AdjustBalance(ctx context.Context, cardID int64, userID uuid.UUID, req AdjustBalanceRequest) (*AdjustmentResponse, error) {
+	card, err := s.cardRepo.FindByID(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+
+	// Check ownership
+	if card.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to card")
+	}
+
+	before := audit.Card(card)
+
+	description := req.Note
+	if description == "" {
+		description = "Balance adjustment"
+	}
+	tx := &entity.Transaction{
+		UserID:          userID,
+		CardID:          card.ID,
+		TransactionType: entity.TransactionTypeAdjustment,
+		TransactionDate: time.Now(),
+		Description:     description,
+	}
+
+	// The card is locked while the adjustment is sized and the balance set, so
+	// a transaction posted meanwhile cannot leave it off target
+	if err := s.txRepo.SetBalance(ctx, card.ID, req.TargetBalance, tx); err != nil {
+		return nil, err
+	}
+
+	response := &AdjustmentResponse{AdjustmentValue: tx.Amount}
+	if tx.Amount != 0 {
+		response.TransactionID = &tx.ID
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     userID,
+			ActorID:    userID,
+			Action:     entity.AuditActionCreate,
+			EntityType: entity.AuditEntityTransaction,
+			EntityID:   tx.ID,
+			After:      audit.Transaction(tx),
+		})
+	}
+
+	// Reload card and ledger to report the reconciled state
+	card, err = s.cardRepo.FindByID(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload card: %w", err)
+	}
+	if card.Balance != before.Balance {
+		s.auditor.Record(ctx, audit.Change{
+			UserID:     userID,
+			ActorID:    userID,
+			Action:     entity.AuditActionUpdate,
+			EntityType: entity.AuditEntityCard,
+			EntityID:   card.ID,
+			Before:     before,
+			After:      audit.Card(card),
+		})
+	}
+	totals, err := s.ledgerTotals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	response.Card = reconcileCard(card, totals[card.ID])
+
+	return response, nil
+}
+
+func (s *service) ledgerTotals(ctx context.Context, userID uuid.UUID) (map[int64]repository.CardLedgerTotal, error) {
+	totals, err := s.txRepo.GetCardLedgerTotals(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger totals: %w", err)
+	}
+
+	byCard := make(map[int64]repository.CardLedgerTotal, len(totals))
+	for _, total := range totals {
+		byCard[total.CardID] = total
+	}
+	return byCard, nil
+}
+
+func reconcileCard(card *entity.Card, total repository.CardLedgerTotal) CardReconciliation {
+	expected := card.InitialBalance + total.Total
+	return CardReconciliation{
+		CardID:           card.ID,
+		AccountType:      card.AccountType,
+		Alias:            card.Alias,
+		InitialBalance:   card.InitialBalance,
+		LedgerTotal:      total.Total,
+		TransactionCount: total.TransactionCount,
+		ExpectedBalance:  expected,
+		ActualBalance:    card.Balance,
+		Discrepancy:      card.Balance - expected,
+		Reconciled:       card.Balance == expected,
+	}
+}
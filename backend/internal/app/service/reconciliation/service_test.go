@@ -0,0 +1,235 @@
+package reconciliation_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/reconciliation"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedCards is a CardRepository over a map
+type storedCards struct {
+	repository.CardRepository
+	cards map[int64]*entity.Card
+}
+
+func (r *storedCards) FindByID(ctx context.Context, id int64) (*entity.Card, error) {
+	card, ok := r.cards[id]
+	if !ok {
+		return nil, apperror.NotFound("card not found")
+	}
+	found := *card
+	return &found, nil
+}
+
+func (r *storedCards) FindByUserID(ctx context.Context, userID uuid.UUID) ([]entity.Card, error) {
+	var cards []entity.Card
+	for id := int64(1); id <= int64(len(r.cards)); id++ {
+		if card, ok := r.cards[id]; ok && card.UserID == userID {
+			cards = append(cards, *card)
+		}
+	}
+	return cards, nil
+}
+
+// ledger is a TransactionRepository over a slice that sets balances on cards
+// like the database does. beforeSet runs ahead of SetBalance to simulate a
+// concurrent post.
+type ledger struct {
+	repository.TransactionRepository
+	cards     *storedCards
+	txs       []entity.Transaction
+	beforeSet func()
+}
+
+func (r *ledger) post(tx entity.Transaction) {
+	tx.ID = int64(len(r.txs) + 1)
+	r.txs = append(r.txs, tx)
+	r.cards.cards[tx.CardID].Balance += tx.BalanceChange()
+}
+
+func (r *ledger) GetCardLedgerTotals(ctx context.Context, userID uuid.UUID) ([]repository.CardLedgerTotal, error) {
+	byCard := make(map[int64]*repository.CardLedgerTotal)
+	var totals []repository.CardLedgerTotal
+	for _, tx := range r.txs {
+		if tx.UserID != userID {
+			continue
+		}
+		if byCard[tx.CardID] == nil {
+			byCard[tx.CardID] = &repository.CardLedgerTotal{CardID: tx.CardID}
+		}
+		byCard[tx.CardID].Total += tx.BalanceChange()
+		byCard[tx.CardID].TransactionCount++
+	}
+	for _, total := range byCard {
+		totals = append(totals, *total)
+	}
+	return totals, nil
+}
+
+func (r *ledger) SetBalance(ctx context.Context, cardID int64, target int64, adjustment *entity.Transaction) error {
+	if r.beforeSet != nil {
+		r.beforeSet()
+	}
+	card := r.cards.cards[cardID]
+	total := card.InitialBalance
+	for _, tx := range r.txs {
+		if tx.CardID == cardID {
+			total += tx.BalanceChange()
+		}
+	}
+
+	adjustment.Amount = target - total
+	if adjustment.Amount != 0 {
+		adjustment.ID = int64(len(r.txs) + 1)
+		r.txs = append(r.txs, *adjustment)
+	}
+	card.Balance = target
+	return nil
+}
+
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (discardAuditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	return nil
+}
+
+func newService(t *testing.T, txs *ledger) reconciliation.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return reconciliation.NewService(txs.cards, txs, audit.NewRecorder(discardAuditLog{}, log))
+}
+
+// newLedger opens card 1 for owner with initial balance 10000 and posts an
+// expense of 2500 and an income of 1000 to it
+func newLedger(owner uuid.UUID, accountType string) *ledger {
+	txs := &ledger{cards: &storedCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, AccountType: accountType, InitialBalance: 10000, Balance: 10000},
+	}}}
+	txs.post(entity.Transaction{UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500})
+	txs.post(entity.Transaction{UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeIncome, Amount: 1000})
+	return txs
+}
+
+func TestReconcile(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name            string
+		accountType     string
+		drift           int64
+		wantReconciled  bool
+		wantDiscrepancy int64
+	}{
+		{"balance matches the ledger", entity.AccountTypeChecking, 0, true, 0},
+		{"balance above the ledger", entity.AccountTypeChecking, 700, false, 700},
+		{"balance below the ledger", entity.AccountTypeCash, -300, false, -300},
+		{"liability balance drifted", entity.AccountTypeCredit, -50, false, -50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := newLedger(owner, tt.accountType)
+			txs.cards.cards[1].Balance += tt.drift
+			service := newService(t, txs)
+
+			report, err := service.Reconcile(context.Background(), owner)
+			require.NoError(t, err)
+
+			require.Len(t, report.Cards, 1)
+			card := report.Cards[0]
+			assert.Equal(t, int64(-1500), card.LedgerTotal)
+			assert.Equal(t, int64(2), card.TransactionCount)
+			assert.Equal(t, int64(8500), card.ExpectedBalance)
+			assert.Equal(t, 8500+tt.drift, card.ActualBalance)
+			assert.Equal(t, tt.wantDiscrepancy, card.Discrepancy)
+			assert.Equal(t, tt.wantReconciled, card.Reconciled)
+			if tt.wantReconciled {
+				assert.Zero(t, report.DiscrepantCards)
+			} else {
+				assert.Equal(t, 1, report.DiscrepantCards)
+			}
+		})
+	}
+}
+
+func TestAdjustBalance(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name           string
+		drift          int64
+		target         int64
+		wantAdjustment int64
+	}{
+		{"records the drift and keeps the balance", 700, 9200, 700},
+		{"moves a reconciled card to a new balance", 0, 6000, -2500},
+		{"drifted card to a new balance", -300, 9000, 500},
+		{"card already on target", 0, 8500, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := newLedger(owner, entity.AccountTypeChecking)
+			txs.cards.cards[1].Balance += tt.drift
+			service := newService(t, txs)
+
+			resp, err := service.AdjustBalance(context.Background(), 1, owner, reconciliation.AdjustBalanceRequest{TargetBalance: tt.target})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantAdjustment, resp.AdjustmentValue)
+			assert.Equal(t, tt.target, txs.cards.cards[1].Balance)
+			assert.True(t, resp.Card.Reconciled)
+			assert.Equal(t, tt.target, resp.Card.ActualBalance)
+			if tt.wantAdjustment == 0 {
+				assert.Nil(t, resp.TransactionID)
+				assert.Len(t, txs.txs, 2)
+				return
+			}
+			require.NotNil(t, resp.TransactionID)
+			adjustment := txs.txs[*resp.TransactionID-1]
+			assert.Equal(t, entity.TransactionTypeAdjustment, adjustment.TransactionType)
+			assert.Equal(t, tt.wantAdjustment, adjustment.Amount)
+			assert.Equal(t, "Balance adjustment", adjustment.Description)
+		})
+	}
+}
+
+func TestAdjustBalance_PostedMeanwhile(t *testing.T) {
+	owner := uuid.New()
+	txs := newLedger(owner, entity.AccountTypeChecking)
+	// An expense lands after the card was read
+	txs.beforeSet = func() {
+		txs.beforeSet = nil
+		txs.post(entity.Transaction{UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 400})
+	}
+	service := newService(t, txs)
+
+	resp, err := service.AdjustBalance(context.Background(), 1, owner, reconciliation.AdjustBalanceRequest{TargetBalance: 9000})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(900), resp.AdjustmentValue)
+	assert.Equal(t, int64(9000), txs.cards.cards[1].Balance)
+	assert.True(t, resp.Card.Reconciled)
+}
+
+func TestAdjustBalance_OtherUsersCard(t *testing.T) {
+	owner := uuid.New()
+	txs := newLedger(owner, entity.AccountTypeChecking)
+	service := newService(t, txs)
+
+	_, err := service.AdjustBalance(context.Background(), 1, uuid.New(), reconciliation.AdjustBalanceRequest{TargetBalance: 0})
+	assert.True(t, apperror.Is(err, apperror.CodeForbidden))
+	assert.Equal(t, int64(8500), txs.cards.cards[1].Balance)
+	assert.Len(t, txs.txs, 2)
+}
@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"time"
+)
+
+// RuleRequest contains categorization rule data for create and update. At
+// least one condition and one action are required.
+type RuleRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Priority int    `json:"priority"` // lower values are evaluated first
+	IsActive *bool  `json:"is_active"`
+
+	// Conditions
+	DescriptionPattern string  `json:"description_pattern" binding:"omitempty,max=255"`
+	PatternType        string  `json:"pattern_type" binding:"omitempty,oneof=contains regex"`
+	MinAmount          *int64  `json:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount          *int64  `json:"max_amount" binding:"omitempty,min=0"`
+	CardID             *int64  `json:"card_id"`
+	TransactionType    *string `json:"transaction_type" binding:"omitempty,oneof=Income Expense Transfer"`
+
+	// Actions
+	SetCategoryID  *int64   `json:"set_category_id"`
+	AddTags        []string `json:"add_tags" binding:"omitempty,dive,min=1,max=50"`
+	SetDescription *string  `json:"set_description" binding:"omitempty,max=255"`
+}
+
+// RuleResponse contains categorization rule data
+type RuleResponse struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	Priority           int       `json:"priority"`
+	IsActive           bool      `json:"is_active"`
+	DescriptionPattern string    `json:"description_pattern,omitempty"`
+	PatternType        string    `json:"pattern_type"`
+	MinAmount          *int64    `json:"min_amount,omitempty"`
+	MaxAmount          *int64    `json:"max_amount,omitempty"`
+	CardID             *int64    `json:"card_id,omitempty"`
+	TransactionType    *string   `json:"transaction_type,omitempty"`
+	SetCategoryID      *int64    `json:"set_category_id,omitempty"`
+	AddTags            []string  `json:"add_tags,omitempty"`
+	SetDescription     *string   `json:"set_description,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ApplyRulesRequest re-applies rules to existing transactions
+type ApplyRulesRequest struct {
+	DryRun    bool       `json:"dry_run"`   // only report what would change
+	Overwrite bool       `json:"overwrite"` // replace categories that are already set
+	StartDate *time.Time `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+}
+
+// TransactionFields contains the transaction fields rules can change
+type TransactionFields struct {
+	CategoryID  *int64   `json:"category_id"`
+	Tags        []string `json:"tags"`
+	Description string   `json:"description"`
+}
+
+// TransactionChange describes how rules change a single transaction
+type TransactionChange struct {
+	TransactionID int64             `json:"transaction_id"`
+	RuleIDs       []int64           `json:"rule_ids"`
+	Before        TransactionFields `json:"before"`
+	After         TransactionFields `json:"after"`
+}
+
+// ApplyRulesResponse contains the result of re-applying rules
+type ApplyRulesResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Scanned int                 `json:"scanned"`
+	Matched int                 `json:"matched"`
+	Changed int                 `json:"changed"`
+	Changes []TransactionChange `json:"changes"`
+}
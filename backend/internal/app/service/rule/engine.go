@@ -0,0 +1,136 @@
+package rule
+
+import (
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Engine evaluates a user's categorization rules against transactions
+type Engine struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	rule    entity.CategorizationRule
+	pattern *regexp.Regexp
+}
+
+// Outcome describes the effect of applying rules to a transaction
+type Outcome struct {
+	RuleIDs []int64
+	Changed bool
+}
+
+// NewEngine compiles rules and orders them by priority. Inactive rules are ignored.
+func NewEngine(rules []entity.CategorizationRule) (*Engine, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+
+		c := compiledRule{rule: rule}
+		if rule.PatternType == entity.PatternTypeRegex && rule.DescriptionPattern != "" {
+			pattern, err := compilePattern(rule.DescriptionPattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: %w", rule.ID, err)
+			}
+			c.pattern = pattern
+		}
+		compiled = append(compiled, c)
+	}
+
+	sort.SliceStable(compiled, func(i, j int) bool {
+		if compiled[i].rule.Priority != compiled[j].rule.Priority {
+			return compiled[i].rule.Priority < compiled[j].rule.Priority
+		}
+		return compiled[i].rule.ID < compiled[j].rule.ID
+	})
+
+	return &Engine{rules: compiled}, nil
+}
+
+// compilePattern compiles a case-insensitive description pattern
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description pattern: %w", err)
+	}
+	return compiled, nil
+}
+
+// Apply runs every matching rule against tx in priority order. For the
+// category and description the first matching rule that sets them wins; tags
+// from all matching rules are merged. An existing category is only replaced
+// when overwrite is set, and split transactions keep their split categories.
+func (e *Engine) Apply(tx *entity.Transaction, overwrite bool) Outcome {
+	var outcome Outcome
+	categorySet := tx.IsSplit() || (tx.CategoryID != nil && !overwrite)
+	descriptionSet := false
+	original := tx.Description
+
+	for _, c := range e.rules {
+		if !c.matches(tx, original) {
+			continue
+		}
+		outcome.RuleIDs = append(outcome.RuleIDs, c.rule.ID)
+
+		if c.rule.SetCategoryID != nil && !categorySet {
+			if tx.CategoryID == nil || *tx.CategoryID != *c.rule.SetCategoryID {
+				categoryID := *c.rule.SetCategoryID
+				tx.CategoryID = &categoryID
+				tx.Category = nil
+				outcome.Changed = true
+			}
+			categorySet = true
+		}
+
+		if c.rule.SetDescription != nil && !descriptionSet {
+			if tx.Description != *c.rule.SetDescription {
+				tx.Description = *c.rule.SetDescription
+				outcome.Changed = true
+			}
+			descriptionSet = true
+		}
+
+		if len(c.rule.AddTags) > 0 {
+			merged := tx.Tags.Merge(c.rule.AddTags...)
+			if len(merged) != len(tx.Tags) {
+				tx.Tags = merged
+				outcome.Changed = true
+			}
+		}
+	}
+
+	return outcome
+}
+
+// matches checks the rule's conditions against the transaction. The
+// description is matched before any rule rewrote it.
+func (c *compiledRule) matches(tx *entity.Transaction, description string) bool {
+	rule := c.rule
+
+	if rule.CardID != nil && *rule.CardID != tx.CardID {
+		return false
+	}
+	if rule.TransactionType != nil && *rule.TransactionType != tx.TransactionType {
+		return false
+	}
+	if rule.MinAmount != nil && tx.Amount < *rule.MinAmount {
+		return false
+	}
+	if rule.MaxAmount != nil && tx.Amount > *rule.MaxAmount {
+		return false
+	}
+
+	if rule.DescriptionPattern == "" {
+		return true
+	}
+	if c.pattern != nil {
+		return c.pattern.MatchString(description)
+	}
+	return strings.Contains(strings.ToLower(description), strings.ToLower(rule.DescriptionPattern))
+}
@@ -0,0 +1,129 @@
+package rule_test
+
+import (
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/service/rule"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestEngine_Apply(t *testing.T) {
+	rules := []entity.CategorizationRule{
+		{
+			ID:                 1,
+			Priority:           10,
+			IsActive:           true,
+			DescriptionPattern: "amzn",
+			PatternType:        entity.PatternTypeContains,
+			SetCategoryID:      int64Ptr(5),
+			SetDescription:     stringPtr("Amazon"),
+			AddTags:            entity.Tags{"shopping"},
+		},
+		{
+			ID:                 2,
+			Priority:           1,
+			IsActive:           true,
+			DescriptionPattern: `^AMZN\s+PRIME`,
+			PatternType:        entity.PatternTypeRegex,
+			SetCategoryID:      int64Ptr(9),
+			AddTags:            entity.Tags{"subscription"},
+		},
+		{
+			ID:        3,
+			Priority:  0,
+			IsActive:  true,
+			MinAmount: int64Ptr(100000),
+			AddTags:   entity.Tags{"large"},
+		},
+		{
+			ID:                 4,
+			IsActive:           false,
+			DescriptionPattern: "amzn",
+			SetCategoryID:      int64Ptr(1),
+		},
+	}
+
+	engine, err := rule.NewEngine(rules)
+	require.NoError(t, err)
+
+	t.Run("higher priority rule wins the category", func(t *testing.T) {
+		tx := &entity.Transaction{Description: "AMZN Prime membership", Amount: 1500}
+
+		outcome := engine.Apply(tx, false)
+
+		assert.True(t, outcome.Changed)
+		assert.Equal(t, []int64{2, 1}, outcome.RuleIDs)
+		require.NotNil(t, tx.CategoryID)
+		assert.Equal(t, int64(9), *tx.CategoryID)
+		assert.Equal(t, "Amazon", tx.Description)
+		assert.Equal(t, entity.Tags{"subscription", "shopping"}, tx.Tags)
+	})
+
+	t.Run("amount range condition", func(t *testing.T) {
+		tx := &entity.Transaction{Description: "Rent", Amount: 250000}
+
+		outcome := engine.Apply(tx, false)
+
+		assert.Equal(t, []int64{3}, outcome.RuleIDs)
+		assert.Nil(t, tx.CategoryID)
+		assert.Equal(t, entity.Tags{"large"}, tx.Tags)
+	})
+
+	t.Run("existing category kept unless overwrite", func(t *testing.T) {
+		tx := &entity.Transaction{Description: "amzn mktp", CategoryID: int64Ptr(2)}
+		engine.Apply(tx, false)
+		assert.Equal(t, int64(2), *tx.CategoryID)
+
+		tx = &entity.Transaction{Description: "amzn mktp", CategoryID: int64Ptr(2)}
+		engine.Apply(tx, true)
+		assert.Equal(t, int64(5), *tx.CategoryID)
+	})
+
+	t.Run("split transactions keep their categories", func(t *testing.T) {
+		tx := &entity.Transaction{
+			Description: "amzn mktp",
+			Splits:      []entity.TransactionSplit{{CategoryID: 1}, {CategoryID: 2}},
+		}
+
+		engine.Apply(tx, true)
+
+		assert.Nil(t, tx.CategoryID)
+		assert.Equal(t, "Amazon", tx.Description)
+	})
+
+	t.Run("applying twice is a no-op", func(t *testing.T) {
+		tx := &entity.Transaction{Description: "amzn mktp"}
+		engine.Apply(tx, false)
+
+		outcome := engine.Apply(tx, false)
+
+		assert.False(t, outcome.Changed)
+	})
+
+	t.Run("no match leaves transaction untouched", func(t *testing.T) {
+		tx := &entity.Transaction{Description: "Coffee", Amount: 400}
+
+		outcome := engine.Apply(tx, false)
+
+		assert.False(t, outcome.Changed)
+		assert.Empty(t, outcome.RuleIDs)
+	})
+}
+
+func TestNewEngine_InvalidRegex(t *testing.T) {
+	_, err := rule.NewEngine([]entity.CategorizationRule{
+		{ID: 1, IsActive: true, DescriptionPattern: "(", PatternType: entity.PatternTypeRegex},
+	})
+
+	assert.Error(t, err)
+}
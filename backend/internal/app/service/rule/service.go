@@ -0,0 +1,329 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+// applyBatchSize is the number of transactions loaded per page when re-applying rules
+const applyBatchSize = 500
+
+type Service interface {
+	CreateRule(ctx context.Context, userID uuid.UUID, req RuleRequest) (*RuleResponse, error)
+	GetRules(ctx context.Context, userID uuid.UUID) ([]RuleResponse, error)
+	GetRule(ctx context.Context, ruleID int64, userID uuid.UUID) (*RuleResponse, error)
+	UpdateRule(ctx context.Context, ruleID int64, userID uuid.UUID, req RuleRequest) (*RuleResponse, error)
+	DeleteRule(ctx context.Context, ruleID int64, userID uuid.UUID) error
+	// Apply runs the user's active rules against a new transaction before it
+	// is saved. It is shared by every path that creates transactions.
+	Apply(ctx context.Context, tx *entity.Transaction) error
+	ApplyToExisting(ctx context.Context, userID uuid.UUID, req ApplyRulesRequest) (*ApplyRulesResponse, error)
+}
+
+type service struct {
+	ruleRepo     repository.RuleRepository
+	txRepo       repository.TransactionRepository
+	cardRepo     repository.CardRepository
+	categoryRepo repository.CategoryRepository
+	suggester    suggestion.Service
+	publisher    events.Publisher
+	auditor      audit.Recorder
+}
+
+func NewService(
+	ruleRepo repository.RuleRepository,
+	txRepo repository.TransactionRepository,
+	cardRepo repository.CardRepository,
+	categoryRepo repository.CategoryRepository,
+	suggester suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
+) Service {
+	return &service{
+		ruleRepo:     ruleRepo,
+		txRepo:       txRepo,
+		cardRepo:     cardRepo,
+		categoryRepo: categoryRepo,
+		suggester:    suggester,
+		publisher:    publisher,
+		auditor:      auditor,
+	}
+}
+
+func (s *service) CreateRule(ctx context.Context, userID uuid.UUID, req RuleRequest) (*RuleResponse, error) {
+	rule := &entity.CategorizationRule{UserID: userID}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	return toResponse(rule), nil
+}
+
+func (s *service) GetRules(ctx context.Context, userID uuid.UUID) ([]RuleResponse, error) {
+	rules, err := s.ruleRepo.FindByUserID(ctx, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	responses := make([]RuleResponse, len(rules))
+	for i := range rules {
+		responses[i] = *toResponse(&rules[i])
+	}
+	return responses, nil
+}
+
+func (s *service) GetRule(ctx context.Context, ruleID int64, userID uuid.UUID) (*RuleResponse, error) {
+	rule, err := s.findUserRule(ctx, ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(rule), nil
+}
+
+func (s *service) UpdateRule(ctx context.Context, ruleID int64, userID uuid.UUID, req RuleRequest) (*RuleResponse, error) {
+	rule, err := s.findUserRule(ctx, ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("failed to update rule: %w", err)
+	}
+
+	return toResponse(rule), nil
+}
+
+func (s *service) DeleteRule(ctx context.Context, ruleID int64, userID uuid.UUID) error {
+	if _, err := s.findUserRule(ctx, ruleID, userID); err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(ctx, ruleID); err != nil {
+		return fmt.Errorf("failed to delete rule: %w", err)
+	}
+	return nil
+}
+
+func (s *service) Apply(ctx context.Context, tx *entity.Transaction) error {
+	engine, err := s.loadEngine(ctx, tx.UserID)
+	if err != nil {
+		return err
+	}
+
+	engine.Apply(tx, false)
+	return nil
+}
+
+func (s *service) ApplyToExisting(ctx context.Context, userID uuid.UUID, req ApplyRulesRequest) (*ApplyRulesResponse, error) {
+	engine, err := s.loadEngine(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ApplyRulesResponse{
+		DryRun:  req.DryRun,
+		Changes: []TransactionChange{},
+	}
+
+	filter := repository.TransactionFilter{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Limit:     applyBatchSize,
+	}
+	for {
+		transactions, err := s.txRepo.FindByUserID(ctx, userID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+
+		for i := range transactions {
+			tx := &transactions[i]
+			before := toFields(tx)
+			snapshot := audit.Transaction(tx)
+
+			outcome := engine.Apply(tx, req.Overwrite)
+			response.Scanned++
+			if len(outcome.RuleIDs) > 0 {
+				response.Matched++
+			}
+			if !outcome.Changed {
+				continue
+			}
+
+			response.Changed++
+			response.Changes = append(response.Changes, TransactionChange{
+				TransactionID: tx.ID,
+				RuleIDs:       outcome.RuleIDs,
+				Before:        before,
+				After:         toFields(tx),
+			})
+
+			if req.DryRun {
+				continue
+			}
+			if err := s.txRepo.Update(ctx, tx); err != nil {
+				return nil, fmt.Errorf("failed to update transaction %d: %w", tx.ID, err)
+			}
+			s.auditor.Record(ctx, audit.Change{
+				UserID:     userID,
+				ActorID:    userID,
+				Action:     entity.AuditActionUpdate,
+				EntityType: entity.AuditEntityTransaction,
+				EntityID:   tx.ID,
+				Before:     snapshot,
+				After:      audit.Transaction(tx),
+			})
+			s.publisher.Publish(ctx, events.New(events.TransactionUpdated, userID, map[string]interface{}{
+				"transaction_id": tx.ID,
+				"reason":         "rules",
+				"rule_ids":       outcome.RuleIDs,
+				"before":         before,
+				"after":          toFields(tx),
+			}))
+		}
+
+		if len(transactions) < applyBatchSize {
+			break
+		}
+		filter.Offset += applyBatchSize
+	}
+
+	// Categories changed in bulk; retrain suggestions from scratch
+	if !req.DryRun && response.Changed > 0 {
+		s.suggester.Invalidate(userID)
+	}
+
+	return response, nil
+}
+
+func (s *service) loadEngine(ctx context.Context, userID uuid.UUID) (*Engine, error) {
+	rules, err := s.ruleRepo.FindByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	engine, err := NewEngine(rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rules: %w", err)
+	}
+	return engine, nil
+}
+
+func (s *service) findUserRule(ctx context.Context, ruleID int64, userID uuid.UUID) (*entity.CategorizationRule, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to rule")
+	}
+	return rule, nil
+}
+
+// applyRequest validates a rule request and copies it onto the rule
+func (s *service) applyRequest(ctx context.Context, rule *entity.CategorizationRule, req RuleRequest) error {
+	patternType := req.PatternType
+	if patternType == "" {
+		patternType = entity.PatternTypeContains
+	}
+	if patternType == entity.PatternTypeRegex && req.DescriptionPattern != "" {
+		if _, err := compilePattern(req.DescriptionPattern); err != nil {
+			return apperror.Validation("%s", err.Error()).WithField("description_pattern", "must be a valid regular expression")
+		}
+	}
+
+	hasCondition := req.DescriptionPattern != "" || req.MinAmount != nil || req.MaxAmount != nil ||
+		req.CardID != nil || req.TransactionType != nil
+	if !hasCondition {
+		return apperror.Validation("rule needs at least one condition")
+	}
+
+	hasAction := req.SetCategoryID != nil || len(req.AddTags) > 0 || req.SetDescription != nil
+	if !hasAction {
+		return apperror.Validation("rule needs at least one action")
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return apperror.Validation("min_amount must not exceed max_amount").WithField("min_amount", "must not exceed max_amount")
+	}
+
+	if req.CardID != nil {
+		card, err := s.cardRepo.FindByID(ctx, *req.CardID)
+		if err != nil {
+			return fmt.Errorf("card not found: %w", err)
+		}
+		if card.UserID != rule.UserID {
+			return apperror.Forbidden("unauthorized access to card")
+		}
+	}
+
+	if req.SetCategoryID != nil {
+		if _, err := s.categoryRepo.FindByID(ctx, *req.SetCategoryID); err != nil {
+			return apperror.Validation("category not found").WithField("set_category_id", "does not exist")
+		}
+	}
+
+	rule.Name = req.Name
+	rule.Priority = req.Priority
+	rule.IsActive = req.IsActive == nil || *req.IsActive
+	rule.DescriptionPattern = req.DescriptionPattern
+	rule.PatternType = patternType
+	rule.MinAmount = req.MinAmount
+	rule.MaxAmount = req.MaxAmount
+	rule.CardID = req.CardID
+	rule.TransactionType = req.TransactionType
+	rule.SetCategoryID = req.SetCategoryID
+	rule.AddTags = entity.Tags{}.Merge(req.AddTags...)
+	rule.SetDescription = req.SetDescription
+
+	return nil
+}
+
+func toFields(tx *entity.Transaction) TransactionFields {
+	fields := TransactionFields{
+		Tags:        append([]string{}, tx.Tags...),
+		Description: tx.Description,
+	}
+	if tx.CategoryID != nil {
+		categoryID := *tx.CategoryID
+		fields.CategoryID = &categoryID
+	}
+	return fields
+}
+
+func toResponse(rule *entity.CategorizationRule) *RuleResponse {
+	return &RuleResponse{
+		ID:                 rule.ID,
+		Name:               rule.Name,
+		Priority:           rule.Priority,
+		IsActive:           rule.IsActive,
+		DescriptionPattern: rule.DescriptionPattern,
+		PatternType:        rule.PatternType,
+		MinAmount:          rule.MinAmount,
+		MaxAmount:          rule.MaxAmount,
+		CardID:             rule.CardID,
+		TransactionType:    rule.TransactionType,
+		SetCategoryID:      rule.SetCategoryID,
+		AddTags:            rule.AddTags,
+		SetDescription:     rule.SetDescription,
+		CreatedAt:          rule.CreatedAt,
+		UpdatedAt:          rule.UpdatedAt,
+	}
+}
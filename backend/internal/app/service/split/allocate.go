@@ -0,0 +1,87 @@
+package split
+
+import (
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/pkg/apperror"
+)
+
+// fullBasisPoints is 100% expressed in basis points
+const fullBasisPoints = 10000
+
+// Portion is one participant's input to an allocation: an exact amount for
+// exact splits or basis points for percentage splits
+type Portion struct {
+	Amount      *int64
+	BasisPoints *int64
+}
+
+// Allocate divides total between the portions according to mode. The result
+// always sums to total; cents left over by rounding go to the first
+// participants (equal) or those with the largest remainders (percentage).
+func Allocate(total int64, mode string, portions []Portion) ([]int64, error) {
+	n := int64(len(portions))
+	if n == 0 {
+		return nil, apperror.Validation("at least one participant is required")
+	}
+	if total <= 0 {
+		return nil, apperror.Validation("amount must be positive")
+	}
+
+	amounts := make([]int64, n)
+	switch mode {
+	case entity.SplitModeEqual:
+		base, rest := total/n, total%n
+		for i := range amounts {
+			amounts[i] = base
+			if int64(i) < rest {
+				amounts[i]++
+			}
+		}
+
+	case entity.SplitModePercentage:
+		var sum, allocated int64
+		remainders := make([]int64, n)
+		for i, portion := range portions {
+			if portion.BasisPoints == nil || *portion.BasisPoints < 0 {
+				return nil, apperror.Validation("participant %d: percentage is required", i+1)
+			}
+			sum += *portion.BasisPoints
+			amounts[i] = total * *portion.BasisPoints / fullBasisPoints
+			remainders[i] = total * *portion.BasisPoints % fullBasisPoints
+			allocated += amounts[i]
+		}
+		if sum != fullBasisPoints {
+			return nil, apperror.Validation("percentages must sum to 100 (got %.2f)", float64(sum)/100)
+		}
+
+		// Hand out the rounding cents by largest remainder, earliest first
+		for left := total - allocated; left > 0; left-- {
+			best := 0
+			for i := range remainders {
+				if remainders[i] > remainders[best] {
+					best = i
+				}
+			}
+			amounts[best]++
+			remainders[best] = -1
+		}
+
+	case entity.SplitModeExact:
+		var sum int64
+		for i, portion := range portions {
+			if portion.Amount == nil || *portion.Amount < 0 {
+				return nil, apperror.Validation("participant %d: amount is required", i+1)
+			}
+			amounts[i] = *portion.Amount
+			sum += *portion.Amount
+		}
+		if sum != total {
+			return nil, apperror.Validation("share amounts must sum to the expense amount (got %d, want %d)", sum, total)
+		}
+
+	default:
+		return nil, apperror.Validation("unknown split mode %q", mode)
+	}
+
+	return amounts, nil
+}
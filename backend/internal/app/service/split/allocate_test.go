@@ -0,0 +1,61 @@
+package split_test
+
+import (
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/service/split"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func amount(v int64) *int64 { return &v }
+
+func TestAllocate(t *testing.T) {
+	t.Run("equal split hands leftover cents to the first participants", func(t *testing.T) {
+		amounts, err := split.Allocate(10000, entity.SplitModeEqual, make([]split.Portion, 3))
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3334, 3333, 3333}, amounts)
+	})
+
+	t.Run("percentage split rounds by largest remainder", func(t *testing.T) {
+		amounts, err := split.Allocate(1001, entity.SplitModePercentage, []split.Portion{
+			{BasisPoints: amount(3333)},
+			{BasisPoints: amount(3333)},
+			{BasisPoints: amount(3334)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{334, 333, 334}, amounts)
+		assert.Equal(t, int64(1001), amounts[0]+amounts[1]+amounts[2])
+	})
+
+	t.Run("percentages must sum to 100", func(t *testing.T) {
+		_, err := split.Allocate(1000, entity.SplitModePercentage, []split.Portion{
+			{BasisPoints: amount(5000)},
+			{BasisPoints: amount(4000)},
+		})
+
+		assert.EqualError(t, err, "percentages must sum to 100 (got 90.00)")
+	})
+
+	t.Run("exact split keeps the given amounts", func(t *testing.T) {
+		amounts, err := split.Allocate(5000, entity.SplitModeExact, []split.Portion{
+			{Amount: amount(1500)},
+			{Amount: amount(3500)},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1500, 3500}, amounts)
+	})
+
+	t.Run("exact amounts must sum to the total", func(t *testing.T) {
+		_, err := split.Allocate(5000, entity.SplitModeExact, []split.Portion{
+			{Amount: amount(1500)},
+			{Amount: amount(3000)},
+		})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package split
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateSplitRequest records an expense shared between participants
+type CreateSplitRequest struct {
+	Description  string               `json:"description" binding:"required,max=200"`
+	Amount       int64                `json:"amount" binding:"required,min=1"`
+	SplitMode    string               `json:"split_mode" binding:"required,oneof=equal percentage exact"`
+	ExpenseDate  *time.Time           `json:"expense_date"` // defaults to today
+	Participants []ParticipantRequest `json:"participants" binding:"required,min=2,dive"`
+}
+
+// ParticipantRequest identifies a participant as yourself, a registered user
+// by email or a named contact, with their share for percentage and exact
+// splits. The participant marked paid covered the expense; by default you did.
+type ParticipantRequest struct {
+	Self       bool     `json:"self"`
+	Email      string   `json:"email" binding:"omitempty,email"`
+	Contact    string   `json:"contact" binding:"omitempty,max=100"`
+	Amount     *int64   `json:"amount" binding:"omitempty,min=0"`
+	Percentage *float64 `json:"percentage" binding:"omitempty,min=0,max=100"`
+	Paid       bool     `json:"paid"`
+}
+
+// SplitQuery contains shared expense listing parameters
+type SplitQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SettleRequest pays off part or all of the balance with one counterparty.
+// The payment is posted to the card as an expense when you owe them and as
+// income when they owe you.
+type SettleRequest struct {
+	UserID    *uuid.UUID `json:"user_id"`
+	ContactID *int64     `json:"contact_id"`
+	Amount    int64      `json:"amount" binding:"required,min=1"`
+	CardID    int64      `json:"card_id" binding:"required"`
+	Date      *time.Time `json:"date"` // defaults to today
+	Note      string     `json:"note" binding:"omitempty,max=200"`
+}
+
+// PartyResponse identifies a participant or counterparty
+type PartyResponse struct {
+	Type      string     `json:"type"` // user or contact
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	ContactID *int64     `json:"contact_id,omitempty"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email,omitempty"`
+}
+
+// ShareResponse contains one participant's share
+type ShareResponse struct {
+	Party      PartyResponse `json:"party"`
+	Amount     int64         `json:"amount"`
+	Percentage *float64      `json:"percentage,omitempty"`
+	IsPayer    bool          `json:"is_payer"`
+}
+
+// SplitResponse contains a shared expense
+type SplitResponse struct {
+	ID          int64           `json:"id"`
+	CreatedBy   uuid.UUID       `json:"created_by"`
+	Description string          `json:"description"`
+	Amount      int64           `json:"amount"`
+	SplitMode   string          `json:"split_mode"`
+	ExpenseDate time.Time       `json:"expense_date"`
+	PaidBy      PartyResponse   `json:"paid_by"`
+	Shares      []ShareResponse `json:"shares"`
+	YourBalance int64           `json:"your_balance"` // positive: owed to you, negative: you owe
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// SplitListResponse contains paginated shared expenses
+type SplitListResponse struct {
+	Splits []SplitResponse `json:"splits"`
+	Total  int64           `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// BalanceResponse is the running balance with one counterparty
+type BalanceResponse struct {
+	Counterparty PartyResponse `json:"counterparty"`
+	Balance      int64         `json:"balance"` // positive: they owe you, negative: you owe them
+}
+
+// SettlementResponse contains a recorded settle-up
+type SettlementResponse struct {
+	ID            int64         `json:"id"`
+	From          PartyResponse `json:"from"`
+	To            PartyResponse `json:"to"`
+	Amount        int64         `json:"amount"`
+	TransactionID *int64        `json:"transaction_id,omitempty"`
+	Note          string        `json:"note,omitempty"`
+	Balance       int64         `json:"balance"` // remaining balance with the counterparty
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// ContactResponse contains a split contact
+type ContactResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
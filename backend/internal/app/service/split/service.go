@@ -0,0 +1,604 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// settleUpTag marks transactions posted by a settle-up
+const settleUpTag = "settle-up"
+
+type Service interface {
+	CreateSplit(ctx context.Context, userID uuid.UUID, req CreateSplitRequest) (*SplitResponse, error)
+	GetSplits(ctx context.Context, userID uuid.UUID, query SplitQuery) (*SplitListResponse, error)
+	GetSplit(ctx context.Context, splitID int64, userID uuid.UUID) (*SplitResponse, error)
+	DeleteSplit(ctx context.Context, splitID int64, userID uuid.UUID) error
+	GetBalances(ctx context.Context, userID uuid.UUID) ([]BalanceResponse, error)
+	Settle(ctx context.Context, userID uuid.UUID, req SettleRequest) (*SettlementResponse, error)
+	GetContacts(ctx context.Context, userID uuid.UUID) ([]ContactResponse, error)
+}
+
+type service struct {
+	sharedExpenseRepo repository.SharedExpenseRepository
+	userRepo          repository.UserRepository
+	txService         transaction.Service
+	logger            *logger.Logger
+}
+
+func NewService(
+	sharedExpenseRepo repository.SharedExpenseRepository,
+	userRepo repository.UserRepository,
+	txService transaction.Service,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		sharedExpenseRepo: sharedExpenseRepo,
+		userRepo:          userRepo,
+		txService:         txService,
+		logger:            logger,
+	}
+}
+
+// party identifies a user or a contact. Exactly one field is set.
+type party struct {
+	userID    *uuid.UUID
+	contactID *int64
+}
+
+func userParty(id uuid.UUID) party {
+	return party{userID: &id}
+}
+
+func contactParty(id int64) party {
+	return party{contactID: &id}
+}
+
+func (p party) key() string {
+	if p.userID != nil {
+		return "user:" + p.userID.String()
+	}
+	return fmt.Sprintf("contact:%d", *p.contactID)
+}
+
+func (p party) isUser(id uuid.UUID) bool {
+	return p.userID != nil && *p.userID == id
+}
+
+func shareParty(share *entity.SharedExpenseShare) party {
+	if share.UserID != nil {
+		return userParty(*share.UserID)
+	}
+	return contactParty(*share.ContactID)
+}
+
+func (s *service) CreateSplit(ctx context.Context, userID uuid.UUID, req CreateSplitRequest) (*SplitResponse, error) {
+	creator, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	expense := &entity.SharedExpense{
+		CreatedBy:   userID,
+		Description: req.Description,
+		Amount:      req.Amount,
+		SplitMode:   req.SplitMode,
+		ExpenseDate: today(),
+	}
+	if req.ExpenseDate != nil {
+		expense.ExpenseDate = *req.ExpenseDate
+	}
+
+	seen := make(map[string]bool, len(req.Participants))
+	portions := make([]Portion, 0, len(req.Participants))
+	payerIndex := -1
+	includesSelf := false
+	registered := 0
+
+	for i, p := range req.Participants {
+		share, err := s.resolveParticipant(ctx, creator, p)
+		if err != nil {
+			return nil, err
+		}
+
+		key := shareParty(share).key()
+		if seen[key] {
+			return nil, apperror.Validation("participant %d is listed more than once", i+1)
+		}
+		seen[key] = true
+
+		if share.UserID != nil {
+			registered++
+			if *share.UserID == userID {
+				includesSelf = true
+			}
+		}
+
+		if p.Paid {
+			if payerIndex >= 0 {
+				return nil, apperror.Validation("only one participant can be marked as paid")
+			}
+			payerIndex = i
+		}
+
+		portion, err := portionFor(req.SplitMode, p)
+		if err != nil {
+			return nil, fmt.Errorf("participant %d: %w", i+1, err)
+		}
+		if portion.BasisPoints != nil {
+			share.BasisPoints = portion.BasisPoints
+		}
+		portions = append(portions, portion)
+		expense.Shares = append(expense.Shares, *share)
+	}
+
+	if !includesSelf {
+		return nil, apperror.Forbidden("you must be one of the participants")
+	}
+
+	if payerIndex < 0 {
+		for i := range expense.Shares {
+			if expense.Shares[i].UserID != nil && *expense.Shares[i].UserID == userID {
+				payerIndex = i
+				break
+			}
+		}
+	}
+
+	// A contact has no ledger of their own, so what they paid is only
+	// meaningful between them and the user who recorded it
+	if expense.Shares[payerIndex].ContactID != nil && registered > 1 {
+		return nil, apperror.Validation("a contact can only pay when you are the only registered participant")
+	}
+	expense.Shares[payerIndex].IsPayer = true
+
+	amounts, err := Allocate(req.Amount, req.SplitMode, portions)
+	if err != nil {
+		return nil, err
+	}
+	for i := range expense.Shares {
+		expense.Shares[i].Amount = amounts[i]
+	}
+
+	if err := s.sharedExpenseRepo.Create(ctx, expense); err != nil {
+		return nil, fmt.Errorf("failed to create shared expense: %w", err)
+	}
+
+	// Reload to pick up participant names
+	created, err := s.sharedExpenseRepo.FindByID(ctx, expense.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload shared expense: %w", err)
+	}
+
+	return s.toResponse(created, userID), nil
+}
+
+// resolveParticipant turns a participant into a share, looking up users by
+// email and finding or creating contacts by name
+func (s *service) resolveParticipant(ctx context.Context, creator *entity.User, p ParticipantRequest) (*entity.SharedExpenseShare, error) {
+	email := strings.ToLower(strings.TrimSpace(p.Email))
+	name := strings.TrimSpace(p.Contact)
+
+	given := 0
+	for _, set := range []bool{p.Self, email != "", name != ""} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		return nil, apperror.Validation("each participant needs exactly one of self, email or contact")
+	}
+
+	switch {
+	case p.Self || email == strings.ToLower(creator.Email):
+		id := creator.ID
+		return &entity.SharedExpenseShare{UserID: &id}, nil
+
+	case email != "":
+		user, err := s.userRepo.FindByEmail(ctx, email)
+		if err != nil || !user.IsActive {
+			return nil, apperror.Validation("no user found with email %s", email)
+		}
+		id := user.ID
+		return &entity.SharedExpenseShare{UserID: &id}, nil
+
+	default:
+		contact, err := s.sharedExpenseRepo.FindContactByName(ctx, creator.ID, name)
+		if err != nil {
+			contact = &entity.SplitContact{UserID: creator.ID, Name: name}
+			if err := s.sharedExpenseRepo.CreateContact(ctx, contact); err != nil {
+				return nil, fmt.Errorf("failed to create contact: %w", err)
+			}
+		}
+		id := contact.ID
+		return &entity.SharedExpenseShare{ContactID: &id}, nil
+	}
+}
+
+// portionFor extracts the participant's input for the split mode
+func portionFor(mode string, p ParticipantRequest) (Portion, error) {
+	switch mode {
+	case entity.SplitModePercentage:
+		if p.Percentage == nil {
+			return Portion{}, apperror.Validation("percentage is required for percentage splits")
+		}
+		bp := int64(math.Round(*p.Percentage * 100))
+		return Portion{BasisPoints: &bp}, nil
+	case entity.SplitModeExact:
+		if p.Amount == nil {
+			return Portion{}, apperror.Validation("amount is required for exact splits")
+		}
+		return Portion{Amount: p.Amount}, nil
+	default:
+		return Portion{}, nil
+	}
+}
+
+func (s *service) GetSplits(ctx context.Context, userID uuid.UUID, query SplitQuery) (*SplitListResponse, error) {
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+
+	expenses, err := s.sharedExpenseRepo.FindInvolving(ctx, userID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared expenses: %w", err)
+	}
+
+	total, err := s.sharedExpenseRepo.CountInvolving(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count shared expenses: %w", err)
+	}
+
+	responses := make([]SplitResponse, 0, len(expenses))
+	for i := range expenses {
+		responses = append(responses, *s.toResponse(&expenses[i], userID))
+	}
+
+	return &SplitListResponse{
+		Splits: responses,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}, nil
+}
+
+func (s *service) GetSplit(ctx context.Context, splitID int64, userID uuid.UUID) (*SplitResponse, error) {
+	expense, err := s.sharedExpenseRepo.FindByID(ctx, splitID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared expense: %w", err)
+	}
+
+	if !involves(expense, userID) {
+		return nil, apperror.Forbidden("unauthorized access to shared expense")
+	}
+
+	return s.toResponse(expense, userID), nil
+}
+
+func (s *service) DeleteSplit(ctx context.Context, splitID int64, userID uuid.UUID) error {
+	expense, err := s.sharedExpenseRepo.FindByID(ctx, splitID)
+	if err != nil {
+		return fmt.Errorf("failed to get shared expense: %w", err)
+	}
+
+	if expense.CreatedBy != userID {
+		return apperror.Forbidden("unauthorized access to shared expense")
+	}
+
+	if err := s.sharedExpenseRepo.Delete(ctx, splitID); err != nil {
+		return fmt.Errorf("failed to delete shared expense: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) GetBalances(ctx context.Context, userID uuid.UUID) ([]BalanceResponse, error) {
+	balances, parties, err := s.balances(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]BalanceResponse, 0, len(balances))
+	for key, balance := range balances {
+		if balance == 0 {
+			continue
+		}
+		responses = append(responses, BalanceResponse{
+			Counterparty: s.describe(ctx, parties[key]),
+			Balance:      balance,
+		})
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Counterparty.Name < responses[j].Counterparty.Name
+	})
+
+	return responses, nil
+}
+
+// balances nets every expense and settlement involving the user per
+// counterparty. A positive balance means the counterparty owes the user.
+func (s *service) balances(ctx context.Context, userID uuid.UUID) (map[string]int64, map[string]party, error) {
+	expenses, err := s.sharedExpenseRepo.FindInvolving(ctx, userID, 0, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get shared expenses: %w", err)
+	}
+
+	settlements, err := s.sharedExpenseRepo.FindSettlementsInvolving(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	balances := make(map[string]int64)
+	parties := make(map[string]party)
+	add := func(p party, amount int64) {
+		balances[p.key()] += amount
+		parties[p.key()] = p
+	}
+
+	for i := range expenses {
+		payer := expenses[i].Payer()
+		if payer == nil {
+			continue
+		}
+		payerParty := shareParty(payer)
+
+		for j := range expenses[i].Shares {
+			share := &expenses[i].Shares[j]
+			if share.IsPayer {
+				continue
+			}
+			sharer := shareParty(share)
+			switch {
+			case payerParty.isUser(userID):
+				add(sharer, share.Amount)
+			case sharer.isUser(userID):
+				add(payerParty, -share.Amount)
+			}
+		}
+	}
+
+	for _, settlement := range settlements {
+		from := party{userID: settlement.FromUserID, contactID: settlement.FromContactID}
+		to := party{userID: settlement.ToUserID, contactID: settlement.ToContactID}
+		switch {
+		case from.isUser(userID):
+			add(to, settlement.Amount)
+		case to.isUser(userID):
+			add(from, -settlement.Amount)
+		}
+	}
+
+	return balances, parties, nil
+}
+
+func (s *service) Settle(ctx context.Context, userID uuid.UUID, req SettleRequest) (*SettlementResponse, error) {
+	if (req.UserID == nil) == (req.ContactID == nil) {
+		return nil, apperror.Validation("exactly one of user_id and contact_id is required")
+	}
+
+	counterparty := party{userID: req.UserID, contactID: req.ContactID}
+	if counterparty.isUser(userID) {
+		return nil, apperror.Validation("cannot settle up with yourself")
+	}
+	if req.ContactID != nil {
+		contact, err := s.sharedExpenseRepo.FindContactByID(ctx, *req.ContactID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contact: %w", err)
+		}
+		if contact.UserID != userID {
+			return nil, apperror.Forbidden("unauthorized access to contact")
+		}
+	}
+
+	balances, _, err := s.balances(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	balance := balances[counterparty.key()]
+	if balance == 0 {
+		return nil, apperror.Conflict("nothing to settle with this counterparty")
+	}
+
+	outstanding := balance
+	if outstanding < 0 {
+		outstanding = -outstanding
+	}
+	if req.Amount > outstanding {
+		return nil, apperror.Validation("amount exceeds the outstanding balance of %d", outstanding)
+	}
+
+	described := s.describe(ctx, counterparty)
+	me := userParty(userID)
+
+	// Paying what you owe is an expense; being paid back is income
+	from, to := me, counterparty
+	txType := entity.TransactionTypeExpense
+	if balance > 0 {
+		from, to = counterparty, me
+		txType = entity.TransactionTypeIncome
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+	description := "Settle up with " + described.Name
+	if req.Note != "" {
+		description += ": " + req.Note
+	}
+
+	tx, err := s.txService.CreateTransaction(ctx, userID, transaction.CreateTransactionRequest{
+		CardID:          req.CardID,
+		TransactionType: txType,
+		Amount:          req.Amount,
+		TransactionDate: date,
+		Description:     description,
+		Tags:            []string{settleUpTag},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record settle-up transaction: %w", err)
+	}
+
+	settlement := &entity.SplitSettlement{
+		CreatedBy:     userID,
+		FromUserID:    from.userID,
+		FromContactID: from.contactID,
+		ToUserID:      to.userID,
+		ToContactID:   to.contactID,
+		Amount:        req.Amount,
+		TransactionID: &tx.ID,
+		Note:          req.Note,
+	}
+	if err := s.sharedExpenseRepo.CreateSettlement(ctx, settlement); err != nil {
+		// Take back the transaction so the balance and the ledger still agree
+		if deleteErr := s.txService.DeleteTransaction(ctx, tx.ID, userID, &tx.Version); deleteErr != nil {
+			s.logger.Error("Failed to delete settle-up transaction of an unrecorded settlement",
+				logger.Any("transaction_id", tx.ID),
+				logger.Error(deleteErr),
+			)
+		}
+		return nil, fmt.Errorf("failed to record settlement: %w", err)
+	}
+
+	remaining := balance - req.Amount
+	if balance < 0 {
+		remaining = balance + req.Amount
+	}
+
+	return &SettlementResponse{
+		ID:            settlement.ID,
+		From:          s.describe(ctx, from),
+		To:            s.describe(ctx, to),
+		Amount:        settlement.Amount,
+		TransactionID: settlement.TransactionID,
+		Note:          settlement.Note,
+		Balance:       remaining,
+		CreatedAt:     settlement.CreatedAt,
+	}, nil
+}
+
+func (s *service) GetContacts(ctx context.Context, userID uuid.UUID) ([]ContactResponse, error) {
+	contacts, err := s.sharedExpenseRepo.FindContacts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+
+	responses := make([]ContactResponse, 0, len(contacts))
+	for _, contact := range contacts {
+		responses = append(responses, ContactResponse{
+			ID:        contact.ID,
+			Name:      contact.Name,
+			CreatedAt: contact.CreatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// describe looks up the display details of a party
+func (s *service) describe(ctx context.Context, p party) PartyResponse {
+	if p.userID != nil {
+		response := PartyResponse{Type: "user", UserID: p.userID}
+		if user, err := s.userRepo.FindByID(ctx, *p.userID); err == nil {
+			response.Name = user.FullName()
+			response.Email = user.Email
+		}
+		return response
+	}
+
+	response := PartyResponse{Type: "contact", ContactID: p.contactID}
+	if contact, err := s.sharedExpenseRepo.FindContactByID(ctx, *p.contactID); err == nil {
+		response.Name = contact.Name
+	}
+	return response
+}
+
+// involves reports whether the user created or shares in the expense
+func involves(expense *entity.SharedExpense, userID uuid.UUID) bool {
+	if expense.CreatedBy == userID {
+		return true
+	}
+	for i := range expense.Shares {
+		if shareParty(&expense.Shares[i]).isUser(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+func shareResponseParty(share *entity.SharedExpenseShare) PartyResponse {
+	if share.UserID != nil {
+		response := PartyResponse{Type: "user", UserID: share.UserID}
+		if share.User != nil {
+			response.Name = share.User.FullName()
+			response.Email = share.User.Email
+		}
+		return response
+	}
+
+	response := PartyResponse{Type: "contact", ContactID: share.ContactID}
+	if share.Contact != nil {
+		response.Name = share.Contact.Name
+	}
+	return response
+}
+
+func (s *service) toResponse(expense *entity.SharedExpense, userID uuid.UUID) *SplitResponse {
+	response := &SplitResponse{
+		ID:          expense.ID,
+		CreatedBy:   expense.CreatedBy,
+		Description: expense.Description,
+		Amount:      expense.Amount,
+		SplitMode:   expense.SplitMode,
+		ExpenseDate: expense.ExpenseDate,
+		Shares:      make([]ShareResponse, 0, len(expense.Shares)),
+		CreatedAt:   expense.CreatedAt,
+	}
+
+	payer := expense.Payer()
+	for i := range expense.Shares {
+		share := &expense.Shares[i]
+		shareResp := ShareResponse{
+			Party:   shareResponseParty(share),
+			Amount:  share.Amount,
+			IsPayer: share.IsPayer,
+		}
+		if share.BasisPoints != nil {
+			pct := float64(*share.BasisPoints) / 100
+			shareResp.Percentage = &pct
+		}
+		if share.IsPayer {
+			response.PaidBy = shareResp.Party
+		}
+		response.Shares = append(response.Shares, shareResp)
+
+		// Your balance on this expense alone
+		if payer == nil || share.IsPayer {
+			continue
+		}
+		switch {
+		case shareParty(payer).isUser(userID):
+			response.YourBalance += share.Amount
+		case shareParty(share).isUser(userID):
+			response.YourBalance -= share.Amount
+		}
+	}
+
+	return response
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,279 @@
+package split_test
+
+import (
+	"context"
+	"errors"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/split"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ledger is a SharedExpenseRepository over slices. settleErr fails every
+// CreateSettlement.
+type ledger struct {
+	repository.SharedExpenseRepository
+	contacts    map[int64]*entity.SplitContact
+	expenses    []entity.SharedExpense
+	settlements []entity.SplitSettlement
+	settleErr   error
+}
+
+func (r *ledger) FindContactByID(ctx context.Context, id int64) (*entity.SplitContact, error) {
+	contact, ok := r.contacts[id]
+	if !ok {
+		return nil, apperror.NotFound("contact not found")
+	}
+	return contact, nil
+}
+
+func (r *ledger) FindInvolving(ctx context.Context, userID uuid.UUID, limit, offset int) ([]entity.SharedExpense, error) {
+	var expenses []entity.SharedExpense
+	for _, expense := range r.expenses {
+		for _, share := range expense.Shares {
+			if share.UserID != nil && *share.UserID == userID {
+				expenses = append(expenses, expense)
+				break
+			}
+		}
+	}
+	return expenses, nil
+}
+
+func (r *ledger) FindSettlementsInvolving(ctx context.Context, userID uuid.UUID) ([]entity.SplitSettlement, error) {
+	var settlements []entity.SplitSettlement
+	for _, settlement := range r.settlements {
+		if settlement.CreatedBy == userID ||
+			(settlement.FromUserID != nil && *settlement.FromUserID == userID) ||
+			(settlement.ToUserID != nil && *settlement.ToUserID == userID) {
+			settlements = append(settlements, settlement)
+		}
+	}
+	return settlements, nil
+}
+
+func (r *ledger) CreateSettlement(ctx context.Context, settlement *entity.SplitSettlement) error {
+	if r.settleErr != nil {
+		return r.settleErr
+	}
+	settlement.ID = int64(len(r.settlements) + 1)
+	r.settlements = append(r.settlements, *settlement)
+	return nil
+}
+
+// people is a UserRepository over a map
+type people struct {
+	repository.UserRepository
+	users map[uuid.UUID]*entity.User
+}
+
+func (r *people) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperror.NotFound("user not found")
+	}
+	return user, nil
+}
+
+// postings is a transaction.Service that keeps the transactions it posts
+type postings struct {
+	transaction.Service
+	posted map[int64]transaction.CreateTransactionRequest
+	nextID int64
+}
+
+func (s *postings) CreateTransaction(ctx context.Context, userID uuid.UUID, req transaction.CreateTransactionRequest) (*transaction.TransactionResponse, error) {
+	s.nextID++
+	s.posted[s.nextID] = req
+	return &transaction.TransactionResponse{ID: s.nextID, Version: 1}, nil
+}
+
+func (s *postings) DeleteTransaction(ctx context.Context, id int64, userID uuid.UUID, expectedVersion *int64) error {
+	delete(s.posted, id)
+	return nil
+}
+
+type fixture struct {
+	ann, bob, dan uuid.UUID
+	ledger        *ledger
+	txService     *postings
+	service       split.Service
+}
+
+func share(userID *uuid.UUID, contactID *int64, amount int64, payer bool) entity.SharedExpenseShare {
+	return entity.SharedExpenseShare{UserID: userID, ContactID: contactID, Amount: amount, IsPayer: payer}
+}
+
+// newFixture leaves Ann owed 500 by Bob and 2000 by her contact Chi, and
+// owing Dan 2000
+func newFixture(t *testing.T) *fixture {
+	f := &fixture{ann: uuid.New(), bob: uuid.New(), dan: uuid.New()}
+	chi, stranger := int64(1), int64(2)
+	f.ledger = &ledger{
+		contacts: map[int64]*entity.SplitContact{
+			chi:      {ID: chi, UserID: f.ann, Name: "Chi"},
+			stranger: {ID: stranger, UserID: uuid.New(), Name: "Chi"},
+		},
+		expenses: []entity.SharedExpense{
+			{ID: 1, CreatedBy: f.ann, Amount: 9000, Shares: []entity.SharedExpenseShare{
+				share(&f.ann, nil, 3000, true), share(&f.bob, nil, 3000, false), share(nil, &chi, 3000, false),
+			}},
+			{ID: 2, CreatedBy: f.bob, Amount: 5000, Shares: []entity.SharedExpenseShare{
+				share(&f.bob, nil, 2500, true), share(&f.ann, nil, 2500, false),
+			}},
+			{ID: 3, CreatedBy: f.dan, Amount: 4000, Shares: []entity.SharedExpenseShare{
+				share(&f.dan, nil, 2000, true), share(&f.ann, nil, 2000, false),
+			}},
+		},
+		settlements: []entity.SplitSettlement{
+			{ID: 1, CreatedBy: f.ann, FromContactID: &chi, ToUserID: &f.ann, Amount: 1000},
+		},
+	}
+	users := &people{users: map[uuid.UUID]*entity.User{
+		f.ann: {ID: f.ann, FirstName: "Ann", LastName: "Le"},
+		f.bob: {ID: f.bob, FirstName: "Bob", LastName: "Tran"},
+		f.dan: {ID: f.dan, FirstName: "Dan", LastName: "Vo"},
+	}}
+	f.txService = &postings{posted: make(map[int64]transaction.CreateTransactionRequest)}
+
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	f.service = split.NewService(f.ledger, users, f.txService, log)
+	return f
+}
+
+func TestGetBalances(t *testing.T) {
+	f := newFixture(t)
+
+	balances, err := f.service.GetBalances(context.Background(), f.ann)
+	require.NoError(t, err)
+
+	got := make(map[string]int64)
+	for _, balance := range balances {
+		got[balance.Counterparty.Name] = balance.Balance
+	}
+	assert.Equal(t, map[string]int64{"Bob Tran": 500, "Chi": 2000, "Dan Vo": -2000}, got)
+
+	// Bob sees the other side of the expenses shared with Ann
+	balances, err = f.service.GetBalances(context.Background(), f.bob)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.Equal(t, "Ann Le", balances[0].Counterparty.Name)
+	assert.Equal(t, int64(-500), balances[0].Balance)
+}
+
+func TestSettle(t *testing.T) {
+	chi := int64(1)
+
+	tests := []struct {
+		name        string
+		req         func(f *fixture) split.SettleRequest
+		amount      int64
+		wantType    string
+		wantFrom    string
+		wantBalance int64
+	}{
+		{
+			name:        "paying what you owe is an expense",
+			req:         func(f *fixture) split.SettleRequest { return split.SettleRequest{UserID: &f.dan} },
+			amount:      1500,
+			wantType:    entity.TransactionTypeExpense,
+			wantFrom:    "Ann Le",
+			wantBalance: -500,
+		},
+		{
+			name:        "being paid back is income",
+			req:         func(f *fixture) split.SettleRequest { return split.SettleRequest{ContactID: &chi} },
+			amount:      2000,
+			wantType:    entity.TransactionTypeIncome,
+			wantFrom:    "Chi",
+			wantBalance: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			req := tt.req(f)
+			req.Amount, req.CardID = tt.amount, 7
+
+			resp, err := f.service.Settle(context.Background(), f.ann, req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantFrom, resp.From.Name)
+			assert.Equal(t, tt.wantBalance, resp.Balance)
+			require.NotNil(t, resp.TransactionID)
+			posted := f.txService.posted[*resp.TransactionID]
+			assert.Equal(t, tt.wantType, posted.TransactionType)
+			assert.Equal(t, tt.amount, posted.Amount)
+			assert.Equal(t, int64(7), posted.CardID)
+			assert.Len(t, f.ledger.settlements, 2)
+		})
+	}
+}
+
+func TestSettle_Refused(t *testing.T) {
+	chi, stranger := int64(1), int64(2)
+
+	tests := []struct {
+		name    string
+		req     func(f *fixture) split.SettleRequest
+		wantErr apperror.Code
+	}{
+		{
+			name:    "more than is outstanding",
+			req:     func(f *fixture) split.SettleRequest { return split.SettleRequest{ContactID: &chi, Amount: 2001} },
+			wantErr: apperror.CodeValidation,
+		},
+		{
+			name:    "contact of another user",
+			req:     func(f *fixture) split.SettleRequest { return split.SettleRequest{ContactID: &stranger, Amount: 100} },
+			wantErr: apperror.CodeForbidden,
+		},
+		{
+			name: "nothing outstanding",
+			req: func(f *fixture) split.SettleRequest {
+				nobody := uuid.New()
+				return split.SettleRequest{UserID: &nobody, Amount: 100}
+			},
+			wantErr: apperror.CodeConflict,
+		},
+		{
+			name:    "yourself",
+			req:     func(f *fixture) split.SettleRequest { return split.SettleRequest{UserID: &f.ann, Amount: 100} },
+			wantErr: apperror.CodeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			req := tt.req(f)
+			req.CardID = 7
+
+			_, err := f.service.Settle(context.Background(), f.ann, req)
+			assert.True(t, apperror.Is(err, tt.wantErr))
+			assert.Empty(t, f.txService.posted)
+			assert.Len(t, f.ledger.settlements, 1)
+		})
+	}
+}
+
+func TestSettle_TakesBackTheTransactionWhenNotRecorded(t *testing.T) {
+	f := newFixture(t)
+	f.ledger.settleErr = errors.New("connection reset")
+
+	_, err := f.service.Settle(context.Background(), f.ann, split.SettleRequest{UserID: &f.dan, Amount: 500, CardID: 7})
+	require.Error(t, err)
+
+	assert.Equal(t, int64(1), f.txService.nextID)
+	assert.Empty(t, f.txService.posted)
+	assert.Len(t, f.ledger.settlements, 1)
+}
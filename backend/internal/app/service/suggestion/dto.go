@@ -0,0 +1,23 @@
+package suggestion
+
+// SuggestCategoryRequest contains the transaction data to classify
+type SuggestCategoryRequest struct {
+	Description     string  `json:"description" binding:"required,max=500"`
+	Amount          int64   `json:"amount" binding:"omitempty,min=0"`
+	TransactionType *string `json:"transaction_type" binding:"omitempty,oneof=Income Expense Transfer"`
+	Limit           int     `json:"limit" binding:"omitempty,min=1,max=10"`
+}
+
+// CategorySuggestion is a ranked category with the classifier's confidence
+type CategorySuggestion struct {
+	CategoryID int64   `json:"category_id"`
+	Name       string  `json:"name"`
+	Icon       string  `json:"icon,omitempty"`
+	Confidence float64 `json:"confidence"`
+}
+
+// SuggestCategoryResponse contains ranked category suggestions
+type SuggestCategoryResponse struct {
+	Suggestions []CategorySuggestion `json:"suggestions"`
+	TrainedOn   int                  `json:"trained_on"` // number of categorized samples
+}
@@ -0,0 +1,198 @@
+package suggestion
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/pkg/classifier"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// defaultLimit is the number of suggestions returned when none is requested
+const defaultLimit = 3
+
+type Service interface {
+	SuggestCategory(ctx context.Context, userID uuid.UUID, req SuggestCategoryRequest) (*SuggestCategoryResponse, error)
+	// Learn adds a newly categorized transaction to the user's model
+	Learn(userID uuid.UUID, tx *entity.Transaction)
+	// Forget removes a deleted transaction from the user's model
+	Forget(userID uuid.UUID, tx *entity.Transaction)
+	// Invalidate drops the user's model so it is retrained on next use
+	Invalidate(userID uuid.UUID)
+}
+
+// service keeps one classifier per user in memory. A model is trained from
+// the user's history on first use and updated incrementally afterwards. At
+// most maxModels are kept; the least recently used is dropped beyond that.
+type service struct {
+	txRepo       repository.TransactionRepository
+	categoryRepo repository.CategoryRepository
+	maxModels    int
+
+	mu     sync.Mutex
+	models map[uuid.UUID]*list.Element
+	recent *list.List // of *userModel, most recently used first
+}
+
+type userModel struct {
+	userID uuid.UUID
+	model  *classifier.NaiveBayes[int64]
+}
+
+func NewService(
+	txRepo repository.TransactionRepository,
+	categoryRepo repository.CategoryRepository,
+	maxModels int,
+) Service {
+	return &service{
+		txRepo:       txRepo,
+		categoryRepo: categoryRepo,
+		maxModels:    maxModels,
+		models:       make(map[uuid.UUID]*list.Element),
+		recent:       list.New(),
+	}
+}
+
+func (s *service) SuggestCategory(ctx context.Context, userID uuid.UUID, req SuggestCategoryRequest) (*SuggestCategoryResponse, error) {
+	model, err := s.model(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	byID := make(map[int64]entity.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	allow := func(categoryID int64) bool {
+		category, ok := byID[categoryID]
+		if !ok {
+			return false
+		}
+		return req.TransactionType == nil || category.CategoryType == *req.TransactionType
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	predictions := model.Predict(features(req.Description, req.Amount), allow)
+	if len(predictions) > limit {
+		predictions = predictions[:limit]
+	}
+
+	suggestions := make([]CategorySuggestion, len(predictions))
+	for i, prediction := range predictions {
+		category := byID[prediction.Label]
+		suggestions[i] = CategorySuggestion{
+			CategoryID: category.ID,
+			Name:       category.Name,
+			Icon:       category.Icon,
+			Confidence: prediction.Probability,
+		}
+	}
+
+	return &SuggestCategoryResponse{
+		Suggestions: suggestions,
+		TrainedOn:   model.Documents(),
+	}, nil
+}
+
+func (s *service) Learn(userID uuid.UUID, tx *entity.Transaction) {
+	s.update(userID, tx, (*classifier.NaiveBayes[int64]).Learn)
+}
+
+func (s *service) Forget(userID uuid.UUID, tx *entity.Transaction) {
+	s.update(userID, tx, (*classifier.NaiveBayes[int64]).Unlearn)
+}
+
+// update applies tx to the user's model with apply, once per split line
+func (s *service) update(userID uuid.UUID, tx *entity.Transaction, apply func(*classifier.NaiveBayes[int64], []string, int64)) {
+	// Models that are not loaded yet see the ledger as it is when trained
+	model, ok := s.cached(userID)
+	if !ok || tx.Description == "" {
+		return
+	}
+
+	if tx.IsSplit() {
+		for _, split := range tx.Splits {
+			apply(model, features(tx.Description, split.Amount), split.CategoryID)
+		}
+		return
+	}
+	if tx.CategoryID != nil {
+		apply(model, features(tx.Description, tx.Amount), *tx.CategoryID)
+	}
+}
+
+func (s *service) Invalidate(userID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.models[userID]; ok {
+		s.recent.Remove(elem)
+		delete(s.models, userID)
+	}
+}
+
+// model returns the user's classifier, training it from history if needed
+func (s *service) model(ctx context.Context, userID uuid.UUID) (*classifier.NaiveBayes[int64], error) {
+	if model, ok := s.cached(userID); ok {
+		return model, nil
+	}
+
+	samples, err := s.txRepo.GetTrainingSamples(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load training data: %w", err)
+	}
+
+	model := classifier.New[int64]()
+	for _, sample := range samples {
+		model.Learn(features(sample.Description, sample.Amount), sample.CategoryID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.models[userID]; ok {
+		s.recent.MoveToFront(elem)
+		return elem.Value.(*userModel).model, nil
+	}
+	s.models[userID] = s.recent.PushFront(&userModel{userID: userID, model: model})
+	for s.recent.Len() > s.maxModels {
+		oldest := s.recent.Back()
+		s.recent.Remove(oldest)
+		delete(s.models, oldest.Value.(*userModel).userID)
+	}
+	return model, nil
+}
+
+// cached returns the user's loaded model and marks it recently used
+func (s *service) cached(userID uuid.UUID) (*classifier.NaiveBayes[int64], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.models[userID]
+	if !ok {
+		return nil, false
+	}
+	s.recent.MoveToFront(elem)
+	return elem.Value.(*userModel).model, true
+}
+
+// features tokenizes a description and adds the amount's order of magnitude
+// so that e.g. rent and coffee at the same merchant can be told apart.
+func features(description string, amount int64) []string {
+	tokens := classifier.Tokenize(description)
+	if amount > 0 {
+		tokens = append(tokens, "__amount_"+strconv.Itoa(len(strconv.FormatInt(amount, 10))))
+	}
+	return tokens
+}
@@ -0,0 +1,104 @@
+package suggestion_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/suggestion"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// history is a TransactionRepository that serves the same training samples to
+// every user and counts how often models are trained
+type history struct {
+	repository.TransactionRepository
+	samples []repository.TrainingSample
+	loads   map[uuid.UUID]int
+}
+
+func (r *history) GetTrainingSamples(ctx context.Context, userID uuid.UUID) ([]repository.TrainingSample, error) {
+	r.loads[userID]++
+	return r.samples, nil
+}
+
+type categories struct {
+	repository.CategoryRepository
+}
+
+func (categories) FindAll(ctx context.Context) ([]entity.Category, error) {
+	return []entity.Category{
+		{ID: 1, Name: "Coffee", CategoryType: entity.CategoryTypeExpense},
+		{ID: 2, Name: "Fuel", CategoryType: entity.CategoryTypeExpense},
+	}, nil
+}
+
+func newHistory() *history {
+	return &history{
+		samples: []repository.TrainingSample{
+			{Description: "Highlands coffee", Amount: 45000, CategoryID: 1},
+			{Description: "Petrolimex fuel", Amount: 500000, CategoryID: 2},
+		},
+		loads: make(map[uuid.UUID]int),
+	}
+}
+
+func trainedOn(t *testing.T, service suggestion.Service, userID uuid.UUID) int {
+	resp, err := service.SuggestCategory(context.Background(), userID, suggestion.SuggestCategoryRequest{Description: "coffee"})
+	require.NoError(t, err)
+	return resp.TrainedOn
+}
+
+func TestLearnAndForget(t *testing.T) {
+	owner := uuid.New()
+	service := suggestion.NewService(newHistory(), categories{}, 10)
+	coffee := int64(1)
+
+	tests := []struct {
+		name string
+		tx   *entity.Transaction
+	}{
+		{"categorized", &entity.Transaction{Description: "Phuc Long coffee", Amount: 55000, CategoryID: &coffee}},
+		{"split", &entity.Transaction{Description: "Circle K", Amount: 80000, Splits: []entity.TransactionSplit{
+			{CategoryID: 1, Amount: 30000},
+			{CategoryID: 2, Amount: 50000},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := trainedOn(t, service, owner)
+
+			service.Learn(owner, tt.tx)
+			assert.Greater(t, trainedOn(t, service, owner), before)
+
+			service.Forget(owner, tt.tx)
+			assert.Equal(t, before, trainedOn(t, service, owner))
+		})
+	}
+}
+
+func TestModelsAreBounded(t *testing.T) {
+	repo := newHistory()
+	service := suggestion.NewService(repo, categories{}, 2)
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	trainedOn(t, service, first)
+	trainedOn(t, service, second)
+	trainedOn(t, service, first)
+	// Loading a third model drops the least recently used one
+	trainedOn(t, service, third)
+	trainedOn(t, service, first)
+	trainedOn(t, service, second)
+
+	assert.Equal(t, 1, repo.loads[first])
+	assert.Equal(t, 2, repo.loads[second])
+	assert.Equal(t, 1, repo.loads[third])
+
+	service.Invalidate(first)
+	trainedOn(t, service, first)
+	assert.Equal(t, 2, repo.loads[first])
+}
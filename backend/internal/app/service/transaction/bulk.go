@@ -0,0 +1,329 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkItems caps the transactions one bulk request may create or target
+const MaxBulkItems = 500
+
+// bulkItem is one item of a bulk request on its way to the database
+type bulkItem struct {
+	id     int64
+	tx     *entity.Transaction
+	before *audit.TransactionSnapshot
+	change *repository.BulkChange // nil when the item needs no write
+	err    error
+}
+
+// Bulk validates every item first, then writes the valid ones and their
+// balance effects in one database transaction. In atomic mode a single
+// invalid or failed item leaves everything unchanged.
+func (s *service) Bulk(ctx context.Context, userID uuid.UUID, req BulkRequest) (*BulkResponse, error) {
+	if req.Mode == "" {
+		req.Mode = BulkModeAtomic
+	}
+
+	var items []bulkItem
+	var err error
+	if req.Operation == BulkCreate {
+		items, err = s.prepareBulkCreate(ctx, userID, req)
+	} else {
+		items, err = s.prepareBulkTargets(ctx, userID, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	atomic := req.Mode == BulkModeAtomic
+	failed := false
+	for _, item := range items {
+		failed = failed || item.err != nil
+	}
+
+	// An atomic batch with an invalid item never reaches the database
+	if !atomic || !failed {
+		var changes []repository.BulkChange
+		var indexes []int
+		for i, item := range items {
+			if item.err == nil && item.change != nil {
+				changes = append(changes, *item.change)
+				indexes = append(indexes, i)
+			}
+		}
+		if len(changes) > 0 {
+			failures, err := s.txRepo.ApplyBulk(ctx, changes, atomic)
+			if err != nil {
+				return nil, err
+			}
+			for j, failure := range failures {
+				if failure != nil {
+					items[indexes[j]].err = failure
+					failed = true
+				}
+			}
+		}
+	}
+	applied := !atomic || !failed
+
+	resp := &BulkResponse{
+		Operation: req.Operation,
+		Mode:      req.Mode,
+		Results:   make([]BulkItemResult, len(items)),
+	}
+	for i, item := range items {
+		result := BulkItemResult{Index: i, ID: item.id}
+		switch {
+		case item.err != nil:
+			_, body := apperror.NewResponse(item.err, "")
+			result.Status = BulkStatusFailed
+			result.Code = string(body.Code)
+			result.Error = body.Message
+			resp.Failed++
+		case !applied:
+			result.Status = BulkStatusNotApplied
+		default:
+			result.Status = BulkStatusOK
+			if item.change != nil && item.change.Create != nil {
+				result.ID = item.tx.ID
+			}
+			resp.Succeeded++
+		}
+		resp.Results[i] = result
+	}
+
+	if applied {
+		s.recordBulk(ctx, userID, req.Operation, items)
+	}
+	return resp, nil
+}
+
+// prepareBulkCreate builds each transaction as CreateTransaction would, except
+// that possible duplicates are not reported
+func (s *service) prepareBulkCreate(ctx context.Context, userID uuid.UUID, req BulkRequest) ([]bulkItem, error) {
+	if len(req.IDs) > 0 || req.Filter != nil {
+		return nil, apperror.Validation("create takes transactions, not ids or a filter")
+	}
+	if len(req.Transactions) == 0 {
+		return nil, apperror.Validation("transactions are required").WithField("transactions", "is required")
+	}
+	if len(req.Transactions) > MaxBulkItems {
+		return nil, apperror.Validation("at most %d transactions per request", MaxBulkItems)
+	}
+
+	cards := make(map[int64]*entity.Card)
+	items := make([]bulkItem, len(req.Transactions))
+	for i, txReq := range req.Transactions {
+		card, ok := cards[txReq.CardID]
+		if !ok {
+			found, err := s.cardRepo.FindByID(ctx, txReq.CardID)
+			if err != nil {
+				items[i].err = fmt.Errorf("card not found: %w", err)
+				continue
+			}
+			card = found
+			cards[card.ID] = card
+		}
+
+		tx, err := s.prepareTransaction(ctx, userID, card, txReq)
+		if err != nil {
+			items[i].err = err
+			continue
+		}
+		// Later items are checked against the balance this one leaves
+		card.Balance += tx.BalanceChange()
+		items[i] = bulkItem{tx: tx, change: &repository.BulkChange{Create: tx}}
+	}
+	return items, nil
+}
+
+// prepareBulkTargets loads the targeted transactions and applies the
+// operation to each in memory
+func (s *service) prepareBulkTargets(ctx context.Context, userID uuid.UUID, req BulkRequest) ([]bulkItem, error) {
+	if len(req.Transactions) > 0 {
+		return nil, apperror.Validation("only create takes transactions")
+	}
+
+	var tags entity.Tags
+	switch req.Operation {
+	case BulkUpdateCategory:
+		if req.CategoryID == nil {
+			return nil, apperror.Validation("category is required").WithField("category_id", "is required")
+		}
+		if _, err := s.categoryRepo.FindByID(ctx, *req.CategoryID); err != nil {
+			if apperror.Is(err, apperror.CodeNotFound) {
+				return nil, apperror.Validation("category not found").WithField("category_id", "does not exist")
+			}
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+	case BulkAddTag:
+		if tags = tags.Merge(req.Tags...); len(tags) == 0 {
+			return nil, apperror.Validation("tags are required").WithField("tags", "is required")
+		}
+	}
+
+	ids, found, err := s.bulkTargets(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make(map[int64]*entity.Card)
+	items := make([]bulkItem, len(ids))
+	for i, id := range ids {
+		items[i].id = id
+		tx, ok := found[id]
+		if !ok {
+			items[i].err = apperror.NotFound("transaction not found")
+			continue
+		}
+
+		card, ok := cards[tx.CardID]
+		if !ok {
+			card, err = s.cardRepo.FindByID(ctx, tx.CardID)
+			if err != nil {
+				items[i].err = fmt.Errorf("card not found: %w", err)
+				continue
+			}
+			cards[card.ID] = card
+		}
+		if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionPost); err != nil {
+			items[i].err = err
+			continue
+		}
+
+		items[i].tx = tx
+		items[i].before = audit.Transaction(tx)
+		switch req.Operation {
+		case BulkUpdateCategory:
+			if tx.IsSplit() {
+				items[i].err = apperror.Validation("split transactions are categorized per split")
+				continue
+			}
+			if tx.CategoryID == nil || *tx.CategoryID != *req.CategoryID {
+				categoryID := *req.CategoryID
+				tx.CategoryID = &categoryID
+				tx.Category = nil
+				items[i].change = &repository.BulkChange{Update: tx}
+			}
+		case BulkAddTag:
+			if merged := tx.Tags.Merge(tags...); len(merged) != len(tx.Tags) {
+				tx.Tags = merged
+				items[i].change = &repository.BulkChange{Update: tx}
+			}
+		case BulkDelete:
+			items[i].change = &repository.BulkChange{Delete: tx}
+		}
+	}
+	return items, nil
+}
+
+// bulkTargets resolves the IDs or filter of a request to transactions, keyed
+// by ID. IDs that do not exist are returned without a transaction.
+func (s *service) bulkTargets(ctx context.Context, userID uuid.UUID, req BulkRequest) ([]int64, map[int64]*entity.Transaction, error) {
+	var ids []int64
+	var txs []entity.Transaction
+	switch {
+	case len(req.IDs) > 0 && req.Filter != nil:
+		return nil, nil, apperror.Validation("use either ids or a filter, not both")
+
+	case len(req.IDs) > 0:
+		if len(req.IDs) > MaxBulkItems {
+			return nil, nil, apperror.Validation("at most %d ids per request", MaxBulkItems)
+		}
+		seen := make(map[int64]bool, len(req.IDs))
+		for _, id := range req.IDs {
+			if seen[id] {
+				return nil, nil, apperror.Validation("transaction %d is listed more than once", id).WithField("ids", "must be unique")
+			}
+			seen[id] = true
+		}
+		ids = req.IDs
+
+		var err error
+		if txs, err = s.txRepo.FindByIDs(ctx, ids); err != nil {
+			return nil, nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+
+	case req.Filter != nil:
+		f := req.Filter
+		if f.CardID == nil && f.TransactionType == nil && f.CategoryID == nil &&
+			f.PayeeID == nil && f.StartDate == nil && f.EndDate == nil {
+			return nil, nil, apperror.Validation("filter needs at least one criterion").WithField("filter", "is empty")
+		}
+
+		var err error
+		txs, err = s.txRepo.FindByUserID(ctx, userID, repository.TransactionFilter{
+			CardID:          f.CardID,
+			TransactionType: f.TransactionType,
+			CategoryID:      f.CategoryID,
+			PayeeID:         f.PayeeID,
+			StartDate:       f.StartDate,
+			EndDate:         f.EndDate,
+			Limit:           MaxBulkItems + 1,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		if len(txs) > MaxBulkItems {
+			return nil, nil, apperror.Validation("filter matches more than %d transactions", MaxBulkItems)
+		}
+		for _, tx := range txs {
+			ids = append(ids, tx.ID)
+		}
+
+	default:
+		return nil, nil, apperror.Validation("ids or a filter is required")
+	}
+
+	found := make(map[int64]*entity.Transaction, len(txs))
+	for i := range txs {
+		found[txs[i].ID] = &txs[i]
+	}
+	return ids, found, nil
+}
+
+// recordBulk audits and publishes every change that was written
+func (s *service) recordBulk(ctx context.Context, userID uuid.UUID, operation string, items []bulkItem) {
+	changed := false
+	for _, item := range items {
+		if item.err != nil || item.change == nil {
+			continue
+		}
+		changed = true
+		switch {
+		case item.change.Create != nil:
+			s.recordCreate(ctx, userID, item.tx)
+		case item.change.Delete != nil:
+			s.recordDelete(ctx, userID, item.tx)
+		default:
+			s.auditor.Record(ctx, audit.Change{
+				UserID:     item.tx.UserID,
+				ActorID:    userID,
+				Action:     entity.AuditActionUpdate,
+				EntityType: entity.AuditEntityTransaction,
+				EntityID:   item.tx.ID,
+				Before:     item.before,
+				After:      audit.Transaction(item.tx),
+			})
+			s.publisher.Publish(ctx, events.New(events.TransactionUpdated, item.tx.UserID, map[string]interface{}{
+				"transaction_id": item.tx.ID,
+				"reason":         "bulk",
+				"operation":      operation,
+			}))
+		}
+	}
+
+	// Categories changed in bulk; retrain suggestions from scratch
+	if operation == BulkUpdateCategory && changed {
+		s.suggester.Invalidate(userID)
+	}
+}
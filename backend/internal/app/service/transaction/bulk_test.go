@@ -0,0 +1,247 @@
+package transaction_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/payee"
+	"pfn-backend/internal/app/service/rule"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkTransactions is a TransactionRepository over a map. ApplyBulk records
+// the changes it receives and fails those listed in failAt.
+type bulkTransactions struct {
+	repository.TransactionRepository
+	txs     map[int64]*entity.Transaction
+	applied [][]repository.BulkChange
+	failAt  map[int]error
+}
+
+func (r *bulkTransactions) FindByIDs(ctx context.Context, ids []int64) ([]entity.Transaction, error) {
+	var found []entity.Transaction
+	for _, id := range ids {
+		if tx, ok := r.txs[id]; ok {
+			found = append(found, *tx)
+		}
+	}
+	return found, nil
+}
+
+func (r *bulkTransactions) ApplyBulk(ctx context.Context, changes []repository.BulkChange, atomic bool) ([]error, error) {
+	r.applied = append(r.applied, changes)
+	failures := make([]error, len(changes))
+	for i := range changes {
+		if err, ok := r.failAt[i]; ok {
+			failures[i] = err
+			if atomic {
+				break
+			}
+		}
+	}
+	return failures, nil
+}
+
+type bulkCards struct {
+	repository.CardRepository
+	cards map[int64]*entity.Card
+}
+
+func (r *bulkCards) FindByID(ctx context.Context, id int64) (*entity.Card, error) {
+	c, ok := r.cards[id]
+	if !ok {
+		return nil, apperror.NotFound("card not found")
+	}
+	found := *c
+	return &found, nil
+}
+
+// The creation hooks leave transactions as they are
+type noPayees struct{ payee.Service }
+
+func (noPayees) Resolve(ctx context.Context, tx *entity.Transaction) error { return nil }
+
+type noRules struct{ rule.Service }
+
+func (noRules) Apply(ctx context.Context, tx *entity.Transaction) error { return nil }
+
+type noSuggestions struct{ suggestion.Service }
+
+func (noSuggestions) Learn(userID uuid.UUID, tx *entity.Transaction)  {}
+func (noSuggestions) Forget(userID uuid.UUID, tx *entity.Transaction) {}
+func (noSuggestions) Invalidate(userID uuid.UUID)                     {}
+
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (discardAuditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	return nil
+}
+
+func newService(t *testing.T, txs *bulkTransactions, cards *bulkCards) transaction.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return transaction.NewService(txs, cards, nil, access.NewService(nil),
+		noRules{}, noPayees{}, noSuggestions{}, nil,
+		events.NewBus(log), audit.NewRecorder(discardAuditLog{}, log))
+}
+
+func statuses(resp *transaction.BulkResponse) []string {
+	var out []string
+	for _, result := range resp.Results {
+		out = append(out, result.Status)
+	}
+	return out
+}
+
+func TestBulk_AtomicWritesNothingWhenAnItemIsInvalid(t *testing.T) {
+	owner := uuid.New()
+	txs := &bulkTransactions{txs: map[int64]*entity.Transaction{
+		1: {ID: 1, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 500},
+	}}
+	cards := &bulkCards{cards: map[int64]*entity.Card{1: {ID: 1, UserID: owner}}}
+
+	resp, err := newService(t, txs, cards).Bulk(context.Background(), owner, transaction.BulkRequest{
+		Operation: transaction.BulkDelete,
+		IDs:       []int64{1, 99},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, transaction.BulkModeAtomic, resp.Mode)
+	assert.Equal(t, []string{transaction.BulkStatusNotApplied, transaction.BulkStatusFailed}, statuses(resp))
+	assert.Equal(t, string(apperror.CodeNotFound), resp.Results[1].Code)
+	assert.Equal(t, 0, resp.Succeeded)
+	assert.Equal(t, 1, resp.Failed)
+	assert.Empty(t, txs.applied)
+}
+
+func TestBulk_BestEffortKeepsTheItemsThatSucceed(t *testing.T) {
+	owner := uuid.New()
+	stranger := uuid.New()
+	txs := &bulkTransactions{
+		txs: map[int64]*entity.Transaction{
+			1: {ID: 1, UserID: owner, CardID: 1, Tags: entity.Tags{"trip"}},
+			2: {ID: 2, UserID: owner, CardID: 1},
+			3: {ID: 3, UserID: owner, CardID: 1},
+			4: {ID: 4, UserID: stranger, CardID: 2},
+		},
+		failAt: map[int]error{1: apperror.Stale("transaction was changed by another request")},
+	}
+	cards := &bulkCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner},
+		2: {ID: 2, UserID: stranger},
+	}}
+
+	resp, err := newService(t, txs, cards).Bulk(context.Background(), owner, transaction.BulkRequest{
+		Operation: transaction.BulkAddTag,
+		Mode:      transaction.BulkModeBestEffort,
+		IDs:       []int64{1, 2, 3, 4},
+		Tags:      []string{"trip"},
+	})
+	require.NoError(t, err)
+
+	// Transaction 1 is already tagged and 4 belongs to someone else, so only
+	// 2 and 3 are written; the database rejects 3
+	require.Len(t, txs.applied, 1)
+	require.Len(t, txs.applied[0], 2)
+	assert.Equal(t, int64(2), txs.applied[0][0].Update.ID)
+	assert.Equal(t, entity.Tags{"trip"}, txs.applied[0][0].Update.Tags)
+
+	assert.Equal(t, []string{
+		transaction.BulkStatusOK,
+		transaction.BulkStatusOK,
+		transaction.BulkStatusFailed,
+		transaction.BulkStatusFailed,
+	}, statuses(resp))
+	assert.Equal(t, string(apperror.CodeStale), resp.Results[2].Code)
+	assert.Equal(t, string(apperror.CodeForbidden), resp.Results[3].Code)
+	assert.Equal(t, 2, resp.Succeeded)
+	assert.Equal(t, 2, resp.Failed)
+}
+
+func TestBulk_AtomicReportsDatabaseFailure(t *testing.T) {
+	owner := uuid.New()
+	txs := &bulkTransactions{
+		txs: map[int64]*entity.Transaction{
+			1: {ID: 1, UserID: owner, CardID: 1},
+			2: {ID: 2, UserID: owner, CardID: 1},
+		},
+		failAt: map[int]error{0: apperror.NotFound("transaction not found")},
+	}
+	cards := &bulkCards{cards: map[int64]*entity.Card{1: {ID: 1, UserID: owner}}}
+
+	resp, err := newService(t, txs, cards).Bulk(context.Background(), owner, transaction.BulkRequest{
+		Operation: transaction.BulkDelete,
+		IDs:       []int64{1, 2},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{transaction.BulkStatusFailed, transaction.BulkStatusNotApplied}, statuses(resp))
+	assert.Equal(t, 0, resp.Succeeded)
+}
+
+func TestBulk_CreateChecksCreditAcrossItems(t *testing.T) {
+	owner := uuid.New()
+	limit := int64(10000)
+	txs := &bulkTransactions{}
+	cards := &bulkCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, CreditLimit: &limit, Balance: -2000},
+	}}
+	expense := func(amount int64) transaction.CreateTransactionRequest {
+		return transaction.CreateTransactionRequest{
+			CardID:          1,
+			TransactionType: entity.TransactionTypeExpense,
+			Amount:          amount,
+			TransactionDate: time.Now(),
+		}
+	}
+
+	resp, err := newService(t, txs, cards).Bulk(context.Background(), owner, transaction.BulkRequest{
+		Operation:    transaction.BulkCreate,
+		Mode:         transaction.BulkModeBestEffort,
+		Transactions: []transaction.CreateTransactionRequest{expense(5000), expense(5000), expense(3000)},
+	})
+	require.NoError(t, err)
+
+	// 8000 of credit is left: the first and third fit, the second does not
+	assert.Equal(t, []string{
+		transaction.BulkStatusOK,
+		transaction.BulkStatusFailed,
+		transaction.BulkStatusOK,
+	}, statuses(resp))
+	assert.Equal(t, string(apperror.CodeConflict), resp.Results[1].Code)
+	require.Len(t, txs.applied, 1)
+	assert.Len(t, txs.applied[0], 2)
+}
+
+func TestBulk_RejectsMalformedRequests(t *testing.T) {
+	owner := uuid.New()
+	svc := newService(t, &bulkTransactions{}, &bulkCards{})
+
+	for name, req := range map[string]transaction.BulkRequest{
+		"no targets":     {Operation: transaction.BulkDelete},
+		"ids and filter": {Operation: transaction.BulkDelete, IDs: []int64{1}, Filter: &transaction.BulkFilter{}},
+		"empty filter":   {Operation: transaction.BulkDelete, Filter: &transaction.BulkFilter{}},
+		"duplicate ids":  {Operation: transaction.BulkDelete, IDs: []int64{1, 1}},
+		"no tags":        {Operation: transaction.BulkAddTag, IDs: []int64{1}, Tags: []string{" "}},
+		"create by ids":  {Operation: transaction.BulkCreate, IDs: []int64{1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.Bulk(context.Background(), owner, req)
+			assert.True(t, apperror.Is(err, apperror.CodeValidation), "got %v", err)
+		})
+	}
+}
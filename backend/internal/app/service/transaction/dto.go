@@ -8,18 +8,29 @@ import (
 
 // CreateTransactionRequest contains transaction creation data
 type CreateTransactionRequest struct {
-	CardID          int64     `json:"card_id" binding:"required"`
-	CategoryID      *int64    `json:"category_id" binding:"omitempty"`
-	TransactionType string    `json:"transaction_type" binding:"required,oneof=Income Expense Transfer"`
-	Amount          int64     `json:"amount" binding:"required,min=1"`
-	TransactionDate time.Time `json:"transaction_date" binding:"required"`
-	Description     string    `json:"description" binding:"omitempty"`
+	CardID          int64          `json:"card_id" binding:"required"`
+	CategoryID      *int64         `json:"category_id" binding:"omitempty"`
+	TransactionType string         `json:"transaction_type" binding:"required,oneof=Income Expense Transfer"`
+	Amount          int64          `json:"amount" binding:"required,min=1"`
+	TransactionDate time.Time      `json:"transaction_date" binding:"required"`
+	Description     string         `json:"description" binding:"omitempty"`
+	Tags            []string       `json:"tags" binding:"omitempty,dive,min=1,max=50"`
+	Splits          []SplitRequest `json:"splits" binding:"omitempty,dive"`
+}
+
+// SplitRequest assigns part of a transaction amount to a category
+type SplitRequest struct {
+	CategoryID int64  `json:"category_id" binding:"required"`
+	Amount     int64  `json:"amount" binding:"required,min=1"`
+	Note       string `json:"note" binding:"omitempty,max=255"`
 }
 
 // TransactionFilter contains filtering parameters
 type TransactionFilter struct {
-	TransactionType *string    `form:"transaction_type" binding:"omitempty,oneof=Income Expense Transfer"`
+	CardID          *int64     `form:"card_id" binding:"omitempty"`
+	TransactionType *string    `form:"transaction_type" binding:"omitempty,oneof=Income Expense Transfer Adjustment"`
 	CategoryID      *int64     `form:"category_id" binding:"omitempty"`
+	PayeeID         *int64     `form:"payee_id" binding:"omitempty"`
 	StartDate       *time.Time `form:"start_date" binding:"omitempty"`
 	EndDate         *time.Time `form:"end_date" binding:"omitempty"`
 	Limit           int        `form:"limit" binding:"omitempty,min=1,max=100"`
@@ -28,16 +39,43 @@ type TransactionFilter struct {
 
 // TransactionResponse contains transaction data with category
 type TransactionResponse struct {
-	ID              int64         `json:"id"`
-	UserID          uuid.UUID     `json:"user_id"`
-	CardID          int64         `json:"card_id"`
-	CategoryID      *int64        `json:"category_id,omitempty"`
-	Category        *CategoryInfo `json:"category,omitempty"`
-	TransactionType string        `json:"transaction_type"`
-	Amount          int64         `json:"amount"`
-	TransactionDate time.Time     `json:"transaction_date"`
-	Description     string        `json:"description,omitempty"`
-	CreatedAt       time.Time     `json:"created_at"`
+	ID              int64           `json:"id"`
+	UserID          uuid.UUID       `json:"user_id"`
+	CardID          int64           `json:"card_id"`
+	CategoryID      *int64          `json:"category_id,omitempty"`
+	Category        *CategoryInfo   `json:"category,omitempty"`
+	PayeeID         *int64          `json:"payee_id,omitempty"`
+	Payee           *PayeeInfo      `json:"payee,omitempty"`
+	TransactionType string          `json:"transaction_type"`
+	Amount          int64           `json:"amount"`
+	TransactionDate time.Time       `json:"transaction_date"`
+	Description     string          `json:"description,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+	Splits          []SplitResponse `json:"splits,omitempty"`
+	CreatedBy       *uuid.UUID      `json:"created_by,omitempty"` // household member who posted it
+	CreatedAt       time.Time       `json:"created_at"`
+	Version         int64           `json:"version"` // sent back as the ETag
+
+	// Set on creation when the transaction looks like a double-post
+	Warnings           []string        `json:"warnings,omitempty"`
+	PossibleDuplicates []DuplicateInfo `json:"possible_duplicates,omitempty"`
+}
+
+// DuplicateInfo describes an existing transaction that a new one may duplicate
+type DuplicateInfo struct {
+	ID              int64     `json:"id"`
+	TransactionDate time.Time `json:"transaction_date"`
+	Description     string    `json:"description,omitempty"`
+	Similarity      float64   `json:"similarity"`
+}
+
+// SplitResponse contains a split line of a transaction
+type SplitResponse struct {
+	ID         int64         `json:"id"`
+	CategoryID int64         `json:"category_id"`
+	Category   *CategoryInfo `json:"category,omitempty"`
+	Amount     int64         `json:"amount"`
+	Note       string        `json:"note,omitempty"`
 }
 
 // CategoryInfo contains basic category information
@@ -47,6 +85,12 @@ type CategoryInfo struct {
 	Icon string `json:"icon,omitempty"`
 }
 
+// PayeeInfo contains basic payee information
+type PayeeInfo struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
 // StatsResponse contains transaction statistics
 type StatsResponse struct {
 	TotalIncome   int64 `json:"total_income"`
@@ -56,6 +100,21 @@ type StatsResponse struct {
 	Count         int64 `json:"count"`
 }
 
+// CategoryStatsFilter contains category analytics parameters
+type CategoryStatsFilter struct {
+	TransactionType *string    `form:"transaction_type" binding:"omitempty,oneof=Income Expense Transfer"`
+	StartDate       *time.Time `form:"start_date" binding:"omitempty"`
+	EndDate         *time.Time `form:"end_date" binding:"omitempty"`
+}
+
+// CategoryStatResponse contains totals for a single category
+type CategoryStatResponse struct {
+	CategoryID *int64        `json:"category_id"`
+	Category   *CategoryInfo `json:"category,omitempty"`
+	Total      int64         `json:"total"`
+	Count      int64         `json:"count"`
+}
+
 // TransactionListResponse contains paginated transactions
 type TransactionListResponse struct {
 	Transactions []TransactionResponse `json:"transactions"`
@@ -63,3 +122,66 @@ type TransactionListResponse struct {
 	Limit        int                   `json:"limit"`
 	Offset       int                   `json:"offset"`
 }
+
+// Bulk operations
+const (
+	BulkCreate         = "create"
+	BulkUpdateCategory = "update_category"
+	BulkAddTag         = "add_tag"
+	BulkDelete         = "delete"
+)
+
+// Bulk modes
+const (
+	BulkModeAtomic     = "atomic"      // any failure leaves everything unchanged
+	BulkModeBestEffort = "best_effort" // items that succeed are kept
+)
+
+// Bulk item statuses
+const (
+	BulkStatusOK         = "ok"
+	BulkStatusFailed     = "failed"
+	BulkStatusNotApplied = "not_applied" // valid, but rolled back with an atomic batch
+)
+
+// BulkRequest applies one operation to many transactions. Create takes
+// Transactions; the other operations target IDs or a Filter, not both.
+type BulkRequest struct {
+	Operation    string                     `json:"operation" binding:"required,oneof=create update_category add_tag delete"`
+	Mode         string                     `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Transactions []CreateTransactionRequest `json:"transactions" binding:"omitempty,dive"`
+	IDs          []int64                    `json:"ids" binding:"omitempty"`
+	Filter       *BulkFilter                `json:"filter"`
+	CategoryID   *int64                     `json:"category_id"`
+	Tags         []string                   `json:"tags" binding:"omitempty,dive,min=1,max=50"`
+}
+
+// BulkFilter selects the caller's transactions; at least one field is required
+type BulkFilter struct {
+	CardID          *int64     `json:"card_id"`
+	TransactionType *string    `json:"transaction_type" binding:"omitempty,oneof=Income Expense Transfer Adjustment"`
+	CategoryID      *int64     `json:"category_id"`
+	PayeeID         *int64     `json:"payee_id"`
+	StartDate       *time.Time `json:"start_date"`
+	EndDate         *time.Time `json:"end_date"`
+}
+
+// BulkResponse reports the outcome of every item, in request order
+type BulkResponse struct {
+	Operation string           `json:"operation"`
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// BulkItemResult is the outcome of one item. Index points into Transactions
+// for creates and into the targets otherwise; ID is the created or targeted
+// transaction.
+type BulkItemResult struct {
+	Index  int    `json:"index"`
+	ID     int64  `json:"id,omitempty"`
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
@@ -5,6 +5,14 @@ import (
 	"fmt"
 	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/duplicate"
+	"pfn-backend/internal/app/service/payee"
+	"pfn-backend/internal/app/service/rule"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,77 +21,184 @@ import (
 type Service interface {
 	CreateTransaction(ctx context.Context, userID uuid.UUID, req CreateTransactionRequest) (*TransactionResponse, error)
 	GetUserTransactions(ctx context.Context, userID uuid.UUID, filter TransactionFilter) (*TransactionListResponse, error)
+	GetTransaction(ctx context.Context, id int64, userID uuid.UUID) (*TransactionResponse, error)
+	// DeleteTransaction deletes only if the transaction is still at
+	// expectedVersion, when one is given
+	DeleteTransaction(ctx context.Context, id int64, userID uuid.UUID, expectedVersion *int64) error
+	// Bulk applies one operation to many transactions and reports each item
+	Bulk(ctx context.Context, userID uuid.UUID, req BulkRequest) (*BulkResponse, error)
 	GetStats(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) (*StatsResponse, error)
+	GetCategoryStats(ctx context.Context, userID uuid.UUID, filter CategoryStatsFilter) ([]CategoryStatResponse, error)
 }
 
 type service struct {
-	txRepo   repository.TransactionRepository
-	cardRepo repository.CardRepository
+	txRepo       repository.TransactionRepository
+	cardRepo     repository.CardRepository
+	categoryRepo repository.CategoryRepository
+	access       access.Service
+	ruleService  rule.Service
+	payeeService payee.Service
+	suggester    suggestion.Service
+	duplicates   duplicate.Service
+	publisher    events.Publisher
+	auditor      audit.Recorder
 }
 
 func NewService(
 	txRepo repository.TransactionRepository,
 	cardRepo repository.CardRepository,
+	categoryRepo repository.CategoryRepository,
+	access access.Service,
+	ruleService rule.Service,
+	payeeService payee.Service,
+	suggester suggestion.Service,
+	duplicates duplicate.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
 ) Service {
 	return &service{
-		txRepo:   txRepo,
-		cardRepo: cardRepo,
+		txRepo:       txRepo,
+		cardRepo:     cardRepo,
+		categoryRepo: categoryRepo,
+		access:       access,
+		ruleService:  ruleService,
+		payeeService: payeeService,
+		suggester:    suggester,
+		duplicates:   duplicates,
+		publisher:    publisher,
+		auditor:      auditor,
 	}
 }
 
 func (s *service) CreateTransaction(ctx context.Context, userID uuid.UUID, req CreateTransactionRequest) (*TransactionResponse, error) {
-	// Verify card ownership
 	card, err := s.cardRepo.FindByID(ctx, req.CardID)
 	if err != nil {
 		return nil, fmt.Errorf("card not found: %w", err)
 	}
 
-	if card.UserID != userID {
-		return nil, fmt.Errorf("unauthorized access to card")
+	tx, err := s.prepareTransaction(ctx, userID, card, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Probable double-posts are still created but flagged in the response
+	matches, err := s.duplicates.Detect(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicates: %w", err)
+	}
+
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// Update card balance based on transaction type
+	if err := s.cardRepo.UpdateBalance(ctx, req.CardID, tx.BalanceChange()); err != nil {
+		return nil, fmt.Errorf("failed to update card balance: %w", err)
+	}
+
+	// Reload transaction with category
+	tx, err = s.txRepo.FindByID(ctx, tx.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload transaction: %w", err)
+	}
+
+	resp := s.recordCreate(ctx, userID, tx)
+	if len(matches) > 0 {
+		resp.Warnings = []string{fmt.Sprintf("possible duplicate of %d existing transaction(s)", len(matches))}
+		resp.PossibleDuplicates = make([]DuplicateInfo, len(matches))
+		for i, match := range matches {
+			resp.PossibleDuplicates[i] = DuplicateInfo{
+				ID:              match.ID,
+				TransactionDate: match.TransactionDate,
+				Description:     match.Description,
+				Similarity:      match.Similarity,
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// prepareTransaction checks that the user may post req to card and builds the
+// transaction with its payee and rule-based categorization, without saving it
+func (s *service) prepareTransaction(ctx context.Context, userID uuid.UUID, card *entity.Card, req CreateTransactionRequest) (*entity.Transaction, error) {
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionPost); err != nil {
+		return nil, err
 	}
 
 	// Check if card is frozen
 	if card.IsFrozen {
-		return nil, fmt.Errorf("card is frozen")
+		s.publisher.Publish(ctx, events.New(events.FrozenCardAttempt, card.UserID, map[string]interface{}{
+			"card_id":     card.ID,
+			"last4":       card.CardNumberLast4,
+			"alias":       card.Alias,
+			"amount":      req.Amount,
+			"description": req.Description,
+		}))
+		return nil, apperror.Conflict("card is frozen")
+	}
+
+	// Spending on a credit account cannot exceed its available credit
+	if available := card.AvailableCredit(); available != nil && req.TransactionType != entity.TransactionTypeIncome {
+		if req.Amount > *available {
+			return nil, apperror.Conflict("insufficient available credit")
+		}
 	}
 
-	// Create transaction
+	// Validate split lines
+	splits, err := s.buildSplits(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The transaction belongs to the card owner's ledger; household members
+	// posting to a shared card are recorded as its creator
 	tx := &entity.Transaction{
-		UserID:          userID,
+		UserID:          card.UserID,
 		CardID:          req.CardID,
 		CategoryID:      req.CategoryID,
 		TransactionType: req.TransactionType,
 		Amount:          req.Amount,
 		TransactionDate: req.TransactionDate,
 		Description:     req.Description,
+		Tags:            entity.Tags{}.Merge(req.Tags...),
+		Splits:          splits,
 	}
-
-	if err := s.txRepo.Create(ctx, tx); err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	if userID != card.UserID {
+		tx.CreatedBy = &userID
 	}
 
-	// Update card balance based on transaction type
-	var balanceChange int64
-	switch req.TransactionType {
-	case entity.TransactionTypeIncome:
-		balanceChange = req.Amount
-	case entity.TransactionTypeExpense:
-		balanceChange = -req.Amount
-	case entity.TransactionTypeTransfer:
-		balanceChange = -req.Amount
+	// Link the payee from the raw description before rules rewrite it
+	if err := s.payeeService.Resolve(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to resolve payee: %w", err)
 	}
 
-	if err := s.cardRepo.UpdateBalance(ctx, req.CardID, balanceChange); err != nil {
-		return nil, fmt.Errorf("failed to update card balance: %w", err)
+	// Categorize with the user's rules; an explicit category is kept
+	if err := s.ruleService.Apply(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to apply rules: %w", err)
 	}
 
-	// Reload transaction with category
-	tx, err = s.txRepo.FindByID(ctx, tx.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to reload transaction: %w", err)
-	}
+	return tx, nil
+}
 
-	return s.toResponse(tx), nil
+// recordCreate runs the follow-ups of a saved transaction: suggestion
+// learning, the audit entry and the created event
+func (s *service) recordCreate(ctx context.Context, userID uuid.UUID, tx *entity.Transaction) *TransactionResponse {
+	s.suggester.Learn(tx.UserID, tx)
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     tx.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionCreate,
+		EntityType: entity.AuditEntityTransaction,
+		EntityID:   tx.ID,
+		After:      audit.Transaction(tx),
+	})
+
+	resp := s.toResponse(tx)
+	s.publisher.Publish(ctx, events.New(events.TransactionCreated, tx.UserID, map[string]interface{}{
+		"transaction": resp,
+	}))
+	return resp
 }
 
 func (s *service) GetUserTransactions(ctx context.Context, userID uuid.UUID, filter TransactionFilter) (*TransactionListResponse, error) {
@@ -92,21 +207,37 @@ func (s *service) GetUserTransactions(ctx context.Context, userID uuid.UUID, fil
 		filter.Limit = 20
 	}
 
+	// Filtering by a card shared through a household lists the owner's
+	// transactions on that card
+	ownerID := userID
+	if filter.CardID != nil {
+		card, err := s.cardRepo.FindByID(ctx, *filter.CardID)
+		if err != nil {
+			return nil, fmt.Errorf("card not found: %w", err)
+		}
+		if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionView); err != nil {
+			return nil, err
+		}
+		ownerID = card.UserID
+	}
+
 	repoFilter := repository.TransactionFilter{
+		CardID:          filter.CardID,
 		TransactionType: filter.TransactionType,
 		CategoryID:      filter.CategoryID,
+		PayeeID:         filter.PayeeID,
 		StartDate:       filter.StartDate,
 		EndDate:         filter.EndDate,
 		Limit:           filter.Limit,
 		Offset:          filter.Offset,
 	}
 
-	transactions, err := s.txRepo.FindByUserID(ctx, userID, repoFilter)
+	transactions, err := s.txRepo.FindByUserID(ctx, ownerID, repoFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 
-	total, err := s.txRepo.Count(ctx, userID, repoFilter)
+	total, err := s.txRepo.Count(ctx, ownerID, repoFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count transactions: %w", err)
 	}
@@ -124,6 +255,69 @@ func (s *service) GetUserTransactions(ctx context.Context, userID uuid.UUID, fil
 	}, nil
 }
 
+func (s *service) GetTransaction(ctx context.Context, id int64, userID uuid.UUID) (*TransactionResponse, error) {
+	tx, err := s.txRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	card, err := s.cardRepo.FindByID(ctx, tx.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("card not found: %w", err)
+	}
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionView); err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(tx), nil
+}
+
+// DeleteTransaction moves the transaction to the trash and reverses its effect
+// on the card balance. Only the version read is deleted, so an edit landing in
+// between fails the delete as stale instead of reversing an outdated amount.
+func (s *service) DeleteTransaction(ctx context.Context, id int64, userID uuid.UUID, expectedVersion *int64) error {
+	tx, err := s.txRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	card, err := s.cardRepo.FindByID(ctx, tx.CardID)
+	if err != nil {
+		return fmt.Errorf("card not found: %w", err)
+	}
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionPost); err != nil {
+		return err
+	}
+	if expectedVersion != nil && *expectedVersion != tx.Version {
+		return apperror.Stale("transaction has changed since version %d", *expectedVersion)
+	}
+
+	if err := s.txRepo.Delete(ctx, tx); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	s.recordDelete(ctx, userID, tx)
+	return nil
+}
+
+// recordDelete runs the follow-ups of a deleted transaction: suggestion
+// unlearning, the audit entry and the deleted event
+func (s *service) recordDelete(ctx context.Context, userID uuid.UUID, tx *entity.Transaction) {
+	s.suggester.Forget(tx.UserID, tx)
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     tx.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionDelete,
+		EntityType: entity.AuditEntityTransaction,
+		EntityID:   tx.ID,
+		Before:     audit.Transaction(tx),
+	})
+	s.publisher.Publish(ctx, events.New(events.TransactionDeleted, tx.UserID, map[string]interface{}{
+		"transaction_id": tx.ID,
+		"card_id":        tx.CardID,
+	}))
+}
+
 func (s *service) GetStats(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) (*StatsResponse, error) {
 	stats, err := s.txRepo.GetStats(ctx, userID, startDate, endDate)
 	if err != nil {
@@ -139,6 +333,86 @@ func (s *service) GetStats(ctx context.Context, userID uuid.UUID, startDate, end
 	}, nil
 }
 
+func (s *service) GetCategoryStats(ctx context.Context, userID uuid.UUID, filter CategoryStatsFilter) ([]CategoryStatResponse, error) {
+	stats, err := s.txRepo.GetCategoryStats(ctx, userID, repository.CategoryStatsFilter{
+		TransactionType: filter.TransactionType,
+		StartDate:       filter.StartDate,
+		EndDate:         filter.EndDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category stats: %w", err)
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	byID := make(map[int64]*entity.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+
+	responses := make([]CategoryStatResponse, len(stats))
+	for i, stat := range stats {
+		responses[i] = CategoryStatResponse{
+			CategoryID: stat.CategoryID,
+			Total:      stat.Total,
+			Count:      stat.Count,
+		}
+		if stat.CategoryID != nil {
+			if category, ok := byID[*stat.CategoryID]; ok {
+				responses[i].Category = toCategoryInfo(category)
+			}
+		}
+	}
+
+	return responses, nil
+}
+
+// buildSplits validates split lines against the parent transaction. Split
+// amounts must sum to the parent amount and the parent carries no category.
+func (s *service) buildSplits(ctx context.Context, req CreateTransactionRequest) ([]entity.TransactionSplit, error) {
+	if len(req.Splits) == 0 {
+		return nil, nil
+	}
+
+	if len(req.Splits) < 2 {
+		return nil, apperror.Validation("a split transaction needs at least two splits")
+	}
+	if req.CategoryID != nil {
+		return nil, apperror.Validation("split transactions must not set a category on the parent")
+	}
+
+	var total int64
+	splits := make([]entity.TransactionSplit, len(req.Splits))
+	for i, split := range req.Splits {
+		if _, err := s.categoryRepo.FindByID(ctx, split.CategoryID); err != nil {
+			return nil, apperror.Validation("split %d: category not found", i+1)
+		}
+
+		total += split.Amount
+		splits[i] = entity.TransactionSplit{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Note:       split.Note,
+		}
+	}
+
+	if total != req.Amount {
+		return nil, apperror.Validation("split amounts must sum to the transaction amount (got %d, want %d)", total, req.Amount)
+	}
+
+	return splits, nil
+}
+
+func toCategoryInfo(category *entity.Category) *CategoryInfo {
+	return &CategoryInfo{
+		ID:   category.ID,
+		Name: category.Name,
+		Icon: category.Icon,
+	}
+}
+
 func (s *service) toResponse(tx *entity.Transaction) *TransactionResponse {
 	resp := &TransactionResponse{
 		ID:              tx.ID,
@@ -149,14 +423,36 @@ func (s *service) toResponse(tx *entity.Transaction) *TransactionResponse {
 		Amount:          tx.Amount,
 		TransactionDate: tx.TransactionDate,
 		Description:     tx.Description,
+		Tags:            tx.Tags,
+		CreatedBy:       tx.CreatedBy,
 		CreatedAt:       tx.CreatedAt,
+		Version:         tx.Version,
 	}
 
 	if tx.Category != nil {
-		resp.Category = &CategoryInfo{
-			ID:   tx.Category.ID,
-			Name: tx.Category.Name,
-			Icon: tx.Category.Icon,
+		resp.Category = toCategoryInfo(tx.Category)
+	}
+
+	if tx.Payee != nil {
+		resp.PayeeID = tx.PayeeID
+		resp.Payee = &PayeeInfo{
+			ID:   tx.Payee.ID,
+			Name: tx.Payee.Name,
+		}
+	}
+
+	if tx.IsSplit() {
+		resp.Splits = make([]SplitResponse, len(tx.Splits))
+		for i, split := range tx.Splits {
+			resp.Splits[i] = SplitResponse{
+				ID:         split.ID,
+				CategoryID: split.CategoryID,
+				Amount:     split.Amount,
+				Note:       split.Note,
+			}
+			if split.Category != nil {
+				resp.Splits[i].Category = toCategoryInfo(split.Category)
+			}
 		}
 	}
 
@@ -0,0 +1,306 @@
+package transaction_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/duplicate"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postedTransactions is a TransactionRepository that keeps created
+// transactions in a map. Deletes move the balances of cards like the
+// database does.
+type postedTransactions struct {
+	repository.TransactionRepository
+	txs   map[int64]*entity.Transaction
+	cards *postingCards
+}
+
+func (r *postedTransactions) Create(ctx context.Context, tx *entity.Transaction) error {
+	tx.ID = int64(len(r.txs) + 1)
+	stored := *tx
+	r.txs[tx.ID] = &stored
+	return nil
+}
+
+func (r *postedTransactions) FindByID(ctx context.Context, id int64) (*entity.Transaction, error) {
+	tx, ok := r.txs[id]
+	if !ok {
+		return nil, apperror.NotFound("transaction not found")
+	}
+	found := *tx
+	return &found, nil
+}
+
+func (r *postedTransactions) Delete(ctx context.Context, tx *entity.Transaction) error {
+	stored, ok := r.txs[tx.ID]
+	if !ok {
+		return apperror.NotFound("transaction not found")
+	}
+	if stored.Version != tx.Version {
+		return apperror.Stale("transaction was changed by another request")
+	}
+	delete(r.txs, tx.ID)
+	r.cards.cards[tx.CardID].Balance -= tx.BalanceChange()
+	return nil
+}
+
+// racingTransactions runs race before deleting, as a concurrent request would
+// between the read and the write
+type racingTransactions struct {
+	*postedTransactions
+	race func()
+}
+
+func (r *racingTransactions) Delete(ctx context.Context, tx *entity.Transaction) error {
+	r.race()
+	return r.postedTransactions.Delete(ctx, tx)
+}
+
+// postingCards is a CardRepository over a map that applies balance changes
+type postingCards struct {
+	repository.CardRepository
+	cards map[int64]*entity.Card
+}
+
+func (r *postingCards) FindByID(ctx context.Context, id int64) (*entity.Card, error) {
+	c, ok := r.cards[id]
+	if !ok {
+		return nil, apperror.NotFound("card not found")
+	}
+	found := *c
+	return &found, nil
+}
+
+func (r *postingCards) UpdateBalance(ctx context.Context, id int64, amount int64) error {
+	r.cards[id].Balance += amount
+	return nil
+}
+
+// knownCategories is a CategoryRepository that only finds the listed IDs
+type knownCategories struct {
+	repository.CategoryRepository
+	ids map[int64]bool
+}
+
+func (r *knownCategories) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	if !r.ids[id] {
+		return nil, apperror.NotFound("category not found")
+	}
+	return &entity.Category{ID: id}, nil
+}
+
+type noDuplicates struct{ duplicate.Service }
+
+func (noDuplicates) Detect(ctx context.Context, tx *entity.Transaction) ([]duplicate.Match, error) {
+	return nil, nil
+}
+
+func categoryID(id int64) *int64 {
+	return &id
+}
+
+func TestCreateTransaction_Splits(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name       string
+		categoryID *int64
+		splits     []transaction.SplitRequest
+		wantErr    string
+	}{
+		{
+			name: "splits summing to the amount",
+			splits: []transaction.SplitRequest{
+				{CategoryID: 1, Amount: 6000, Note: "groceries"},
+				{CategoryID: 2, Amount: 4000},
+			},
+		},
+		{
+			name:    "single split",
+			splits:  []transaction.SplitRequest{{CategoryID: 1, Amount: 10000}},
+			wantErr: "a split transaction needs at least two splits",
+		},
+		{
+			name:       "category on the parent",
+			categoryID: categoryID(1),
+			splits: []transaction.SplitRequest{
+				{CategoryID: 1, Amount: 6000},
+				{CategoryID: 2, Amount: 4000},
+			},
+			wantErr: "split transactions must not set a category on the parent",
+		},
+		{
+			name: "unknown category",
+			splits: []transaction.SplitRequest{
+				{CategoryID: 1, Amount: 6000},
+				{CategoryID: 9, Amount: 4000},
+			},
+			wantErr: "split 2: category not found",
+		},
+		{
+			name: "amounts short of the total",
+			splits: []transaction.SplitRequest{
+				{CategoryID: 1, Amount: 6000},
+				{CategoryID: 2, Amount: 3000},
+			},
+			wantErr: "split amounts must sum to the transaction amount (got 9000, want 10000)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := &postedTransactions{txs: map[int64]*entity.Transaction{}}
+			cards := &postingCards{cards: map[int64]*entity.Card{1: {ID: 1, UserID: owner, Balance: 50000}}}
+			log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+			require.NoError(t, err)
+			service := transaction.NewService(txs, cards, &knownCategories{ids: map[int64]bool{1: true, 2: true}},
+				access.NewService(nil), noRules{}, noPayees{}, noSuggestions{}, noDuplicates{},
+				events.NewBus(log), audit.NewRecorder(discardAuditLog{}, log))
+
+			resp, err := service.CreateTransaction(context.Background(), owner, transaction.CreateTransactionRequest{
+				CardID:          1,
+				CategoryID:      tt.categoryID,
+				TransactionType: entity.TransactionTypeExpense,
+				Amount:          10000,
+				TransactionDate: time.Now(),
+				Splits:          tt.splits,
+			})
+
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, apperror.CodeValidation))
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, txs.txs)
+				assert.Equal(t, int64(50000), cards.cards[1].Balance)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Splits, len(tt.splits))
+			for i, split := range tt.splits {
+				assert.Equal(t, split.CategoryID, resp.Splits[i].CategoryID)
+				assert.Equal(t, split.Amount, resp.Splits[i].Amount)
+			}
+			assert.Equal(t, int64(40000), cards.cards[1].Balance)
+		})
+	}
+}
+
+func TestCreateTransaction_CreditLimit(t *testing.T) {
+	owner := uuid.New()
+	creditLimit := int64(50000)
+
+	tests := []struct {
+		name            string
+		transactionType string
+		amount          int64
+		wantErr         bool
+		wantBalance     int64
+	}{
+		{"spending within the available credit", entity.TransactionTypeExpense, 20000, false, -50000},
+		{"spending above the available credit", entity.TransactionTypeExpense, 20001, true, -30000},
+		{"payments are not limited", entity.TransactionTypeIncome, 100000, false, 70000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := &postedTransactions{txs: map[int64]*entity.Transaction{}}
+			cards := &postingCards{cards: map[int64]*entity.Card{
+				1: {ID: 1, UserID: owner, AccountType: entity.AccountTypeCredit, Balance: -30000, CreditLimit: &creditLimit},
+			}}
+			log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+			require.NoError(t, err)
+			service := transaction.NewService(txs, cards, &knownCategories{},
+				access.NewService(nil), noRules{}, noPayees{}, noSuggestions{}, noDuplicates{},
+				events.NewBus(log), audit.NewRecorder(discardAuditLog{}, log))
+
+			_, err = service.CreateTransaction(context.Background(), owner, transaction.CreateTransactionRequest{
+				CardID:          1,
+				TransactionType: tt.transactionType,
+				Amount:          tt.amount,
+				TransactionDate: time.Now(),
+			})
+
+			if tt.wantErr {
+				assert.True(t, apperror.Is(err, apperror.CodeConflict))
+				assert.Empty(t, txs.txs)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantBalance, cards.cards[1].Balance)
+		})
+	}
+}
+
+func TestDeleteTransaction(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name    string
+		race    func(txs *postedTransactions)
+		wantErr apperror.Code
+	}{
+		{name: "reverses the balance"},
+		{
+			name: "deleted meanwhile",
+			race: func(txs *postedTransactions) {
+				require.NoError(t, txs.Delete(context.Background(), &entity.Transaction{
+					ID: 1, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500,
+				}))
+			},
+			wantErr: apperror.CodeNotFound,
+		},
+		{
+			name: "edited meanwhile",
+			race: func(txs *postedTransactions) {
+				edited := *txs.txs[1]
+				edited.Amount, edited.Version = 4000, edited.Version+1
+				txs.txs[1] = &edited
+			},
+			wantErr: apperror.CodeStale,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := &postingCards{cards: map[int64]*entity.Card{1: {ID: 1, UserID: owner, Balance: 7500}}}
+			txs := &postedTransactions{cards: cards, txs: map[int64]*entity.Transaction{
+				1: {ID: 1, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500},
+			}}
+			var repo repository.TransactionRepository = txs
+			if tt.race != nil {
+				repo = &racingTransactions{postedTransactions: txs, race: func() { tt.race(txs) }}
+			}
+			log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+			require.NoError(t, err)
+			service := transaction.NewService(repo, cards, &knownCategories{},
+				access.NewService(nil), noRules{}, noPayees{}, noSuggestions{}, noDuplicates{},
+				events.NewBus(log), audit.NewRecorder(discardAuditLog{}, log))
+
+			err = service.DeleteTransaction(context.Background(), 1, owner, nil)
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantErr == apperror.CodeStale {
+				assert.Contains(t, txs.txs, int64(1))
+				assert.Equal(t, int64(7500), cards.cards[1].Balance)
+				return
+			}
+			assert.Empty(t, txs.txs)
+			assert.Equal(t, int64(10000), cards.cards[1].Balance, "the balance is reversed once")
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package trash
+
+import "time"
+
+// TrashResponse lists everything the user can still restore
+type TrashResponse struct {
+	Cards        []TrashedCardResponse        `json:"cards"`
+	Transactions []TrashedTransactionResponse `json:"transactions"`
+}
+
+// TrashedCardResponse is a deleted card. Its transactions were deleted with
+// it and come back when the card is restored.
+type TrashedCardResponse struct {
+	ID              int64     `json:"id"`
+	AccountType     string    `json:"account_type"`
+	Alias           string    `json:"alias"`
+	CardNumberLast4 string    `json:"card_number_last4,omitempty"`
+	Balance         int64     `json:"balance"`
+	DeletedAt       time.Time `json:"deleted_at"`
+	PurgeAt         time.Time `json:"purge_at"` // when the card is removed for good
+}
+
+// TrashedTransactionResponse is a transaction deleted on its own
+type TrashedTransactionResponse struct {
+	ID              int64     `json:"id"`
+	CardID          int64     `json:"card_id"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          int64     `json:"amount"`
+	TransactionDate time.Time `json:"transaction_date"`
+	Description     string    `json:"description"`
+	CategoryName    string    `json:"category_name,omitempty"`
+	PayeeName       string    `json:"payee_name,omitempty"`
+	DeletedAt       time.Time `json:"deleted_at"`
+	PurgeAt         time.Time `json:"purge_at"` // when the transaction is removed for good
+}
+
+// RestoreResponse describes the restored item and its card's balance
+type RestoreResponse struct {
+	EntityType    string `json:"entity_type"`
+	ID            int64  `json:"id"`
+	CardID        int64  `json:"card_id"`
+	BalanceChange int64  `json:"balance_change"` // applied to the card by the restore
+	CardBalance   int64  `json:"card_balance"`
+}
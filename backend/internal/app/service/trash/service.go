@@ -0,0 +1,211 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetTrash(ctx context.Context, userID uuid.UUID) (*TrashResponse, error)
+	// RestoreCard brings back a card with the transactions deleted along with
+	// it. Deleting a card never touched its balance, so nothing is re-applied.
+	RestoreCard(ctx context.Context, id int64, userID uuid.UUID) (*RestoreResponse, error)
+	// RestoreTransaction brings back a transaction and re-applies it to the
+	// card balance. Anyone who may post to the card may restore it.
+	RestoreTransaction(ctx context.Context, id int64, userID uuid.UUID) (*RestoreResponse, error)
+	// PurgeExpired permanently removes everything deleted longer ago than the
+	// retention period
+	PurgeExpired(ctx context.Context) error
+}
+
+type service struct {
+	cardRepo  repository.CardRepository
+	txRepo    repository.TransactionRepository
+	access    access.Service
+	suggester suggestion.Service
+	publisher events.Publisher
+	auditor   audit.Recorder
+	retention time.Duration
+	logger    *logger.Logger
+}
+
+func NewService(
+	cardRepo repository.CardRepository,
+	txRepo repository.TransactionRepository,
+	access access.Service,
+	suggester suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
+	retention time.Duration,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		cardRepo:  cardRepo,
+		txRepo:    txRepo,
+		access:    access,
+		suggester: suggester,
+		publisher: publisher,
+		auditor:   auditor,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+func (s *service) GetTrash(ctx context.Context, userID uuid.UUID) (*TrashResponse, error) {
+	cards, err := s.cardRepo.FindDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted cards: %w", err)
+	}
+	transactions, err := s.txRepo.FindDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted transactions: %w", err)
+	}
+
+	resp := &TrashResponse{
+		Cards:        make([]TrashedCardResponse, len(cards)),
+		Transactions: make([]TrashedTransactionResponse, len(transactions)),
+	}
+	for i, card := range cards {
+		resp.Cards[i] = TrashedCardResponse{
+			ID:              card.ID,
+			AccountType:     card.AccountType,
+			Alias:           card.Alias,
+			CardNumberLast4: card.CardNumberLast4,
+			Balance:         card.Balance,
+			DeletedAt:       card.DeletedAt.Time,
+			PurgeAt:         card.DeletedAt.Time.Add(s.retention),
+		}
+	}
+	for i, tx := range transactions {
+		item := TrashedTransactionResponse{
+			ID:              tx.ID,
+			CardID:          tx.CardID,
+			TransactionType: tx.TransactionType,
+			Amount:          tx.Amount,
+			TransactionDate: tx.TransactionDate,
+			Description:     tx.Description,
+			DeletedAt:       tx.DeletedAt.Time,
+			PurgeAt:         tx.DeletedAt.Time.Add(s.retention),
+		}
+		if tx.Category != nil {
+			item.CategoryName = tx.Category.Name
+		}
+		if tx.Payee != nil {
+			item.PayeeName = tx.Payee.Name
+		}
+		resp.Transactions[i] = item
+	}
+
+	return resp, nil
+}
+
+func (s *service) RestoreCard(ctx context.Context, id int64, userID uuid.UUID) (*RestoreResponse, error) {
+	card, err := s.cardRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if card.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to card")
+	}
+
+	if err := s.cardRepo.Restore(ctx, card.ID); err != nil {
+		return nil, fmt.Errorf("failed to restore card: %w", err)
+	}
+	card.DeletedAt.Valid = false
+	// Its transactions are back; retrain suggestions with them
+	s.suggester.Invalidate(card.UserID)
+
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     card.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionRestore,
+		EntityType: entity.AuditEntityCard,
+		EntityID:   card.ID,
+		After:      audit.Card(card),
+	})
+
+	return &RestoreResponse{
+		EntityType:  entity.AuditEntityCard,
+		ID:          card.ID,
+		CardID:      card.ID,
+		CardBalance: card.Balance,
+	}, nil
+}
+
+func (s *service) RestoreTransaction(ctx context.Context, id int64, userID uuid.UUID) (*RestoreResponse, error) {
+	tx, err := s.txRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.cardRepo.FindByID(ctx, tx.CardID)
+	if err != nil {
+		if apperror.Is(err, apperror.CodeNotFound) {
+			return nil, apperror.Conflict("the transaction's card is deleted; restore the card first")
+		}
+		return nil, err
+	}
+	if err := s.access.AuthorizeCard(ctx, userID, card, access.ActionPost); err != nil {
+		return nil, err
+	}
+
+	if err := s.txRepo.Restore(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to restore transaction: %w", err)
+	}
+	change := tx.BalanceChange()
+	tx.DeletedAt.Valid = false
+	s.suggester.Learn(tx.UserID, tx)
+
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     tx.UserID,
+		ActorID:    userID,
+		Action:     entity.AuditActionRestore,
+		EntityType: entity.AuditEntityTransaction,
+		EntityID:   tx.ID,
+		After:      audit.Transaction(tx),
+	})
+	s.publisher.Publish(ctx, events.New(events.TransactionRestored, tx.UserID, map[string]interface{}{
+		"transaction_id": tx.ID,
+		"card_id":        tx.CardID,
+	}))
+
+	return &RestoreResponse{
+		EntityType:    entity.AuditEntityTransaction,
+		ID:            tx.ID,
+		CardID:        card.ID,
+		BalanceChange: change,
+		CardBalance:   card.Balance + change,
+	}, nil
+}
+
+func (s *service) PurgeExpired(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.retention)
+
+	transactions, err := s.txRepo.PurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	cards, err := s.cardRepo.PurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+
+	if transactions > 0 || cards > 0 {
+		s.logger.Info("Purged expired trash",
+			logger.Int("cards", int(cards)),
+			logger.Int("transactions", int(transactions)),
+		)
+	}
+	return nil
+}
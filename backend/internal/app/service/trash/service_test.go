@@ -0,0 +1,280 @@
+package trash_test
+
+import (
+	"context"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/app/service/trash"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// memoryCards is a CardRepository over a map; deleted cards keep their row.
+// When findErr is set, FindByID fails with it.
+type memoryCards struct {
+	repository.CardRepository
+	cards   map[int64]*entity.Card
+	findErr error
+}
+
+func (r *memoryCards) FindByID(ctx context.Context, id int64) (*entity.Card, error) {
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	card, ok := r.cards[id]
+	if !ok || card.DeletedAt.Valid {
+		return nil, apperror.NotFound("card not found")
+	}
+	found := *card
+	return &found, nil
+}
+
+func (r *memoryCards) FindDeletedByID(ctx context.Context, id int64) (*entity.Card, error) {
+	card, ok := r.cards[id]
+	if !ok || !card.DeletedAt.Valid {
+		return nil, assert.AnError
+	}
+	found := *card
+	return &found, nil
+}
+
+func (r *memoryCards) Restore(ctx context.Context, id int64) error {
+	r.cards[id].DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+func (r *memoryCards) UpdateBalance(ctx context.Context, id int64, amount int64) error {
+	r.cards[id].Balance += amount
+	return nil
+}
+
+// memoryTransactions is a TransactionRepository over a map that moves the
+// balances of cards like the database does
+type memoryTransactions struct {
+	repository.TransactionRepository
+	cards        *memoryCards
+	transactions map[int64]*entity.Transaction
+}
+
+func (r *memoryTransactions) FindDeletedByID(ctx context.Context, id int64) (*entity.Transaction, error) {
+	tx, ok := r.transactions[id]
+	if !ok || !tx.DeletedAt.Valid {
+		return nil, assert.AnError
+	}
+	found := *tx
+	return &found, nil
+}
+
+func (r *memoryTransactions) Restore(ctx context.Context, tx *entity.Transaction) error {
+	stored := r.transactions[tx.ID]
+	if !stored.DeletedAt.Valid {
+		return apperror.Conflict("transaction is no longer in the trash")
+	}
+	stored.DeletedAt = gorm.DeletedAt{}
+	r.cards.cards[tx.CardID].Balance += tx.BalanceChange()
+	return nil
+}
+
+// racingTransactions runs race before restoring, as a concurrent request
+// would between the read and the write
+type racingTransactions struct {
+	*memoryTransactions
+	race func()
+}
+
+func (r *racingTransactions) Restore(ctx context.Context, tx *entity.Transaction) error {
+	r.race()
+	return r.memoryTransactions.Restore(ctx, tx)
+}
+
+// householdMembers is a HouseholdRepository that only knows memberships
+type householdMembers struct {
+	repository.HouseholdRepository
+	members []entity.HouseholdMember
+}
+
+func (r *householdMembers) FindMember(ctx context.Context, householdID int64, userID uuid.UUID) (*entity.HouseholdMember, error) {
+	for i := range r.members {
+		if r.members[i].HouseholdID == householdID && r.members[i].UserID == userID {
+			return &r.members[i], nil
+		}
+	}
+	return nil, apperror.NotFound("member not found")
+}
+
+// discardAuditLog drops every entry
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (discardAuditLog) Create(ctx context.Context, entry *entity.AuditLog) error {
+	return nil
+}
+
+type noSuggestions struct{ suggestion.Service }
+
+func (noSuggestions) Learn(userID uuid.UUID, tx *entity.Transaction) {}
+func (noSuggestions) Invalidate(userID uuid.UUID)                    {}
+
+func newService(t *testing.T, cards *memoryCards, transactions repository.TransactionRepository, members ...entity.HouseholdMember) trash.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return trash.NewService(cards, transactions, access.NewService(&householdMembers{members: members}), noSuggestions{},
+		events.NewBus(log), audit.NewRecorder(discardAuditLog{}, log), 30*24*time.Hour, log)
+}
+
+func deletedAt(t time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: t, Valid: true}
+}
+
+func TestRestoreTransaction_ReappliesBalance(t *testing.T) {
+	owner := uuid.New()
+	cards := &memoryCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Balance: 10000},
+	}}
+	transactions := &memoryTransactions{cards: cards, transactions: map[int64]*entity.Transaction{
+		7: {ID: 7, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500, DeletedAt: deletedAt(time.Now())},
+	}}
+	service := newService(t, cards, transactions)
+
+	resp, err := service.RestoreTransaction(context.Background(), 7, owner)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(-2500), resp.BalanceChange)
+	assert.Equal(t, int64(7500), resp.CardBalance)
+	assert.Equal(t, int64(7500), cards.cards[1].Balance)
+	assert.False(t, transactions.transactions[7].DeletedAt.Valid)
+}
+
+func TestRestoreTransaction_RequiresLiveCard(t *testing.T) {
+	owner := uuid.New()
+	cards := &memoryCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Balance: 10000, DeletedAt: deletedAt(time.Now())},
+	}}
+	transactions := &memoryTransactions{cards: cards, transactions: map[int64]*entity.Transaction{
+		7: {ID: 7, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeIncome, Amount: 2500, DeletedAt: deletedAt(time.Now())},
+	}}
+	service := newService(t, cards, transactions)
+
+	_, err := service.RestoreTransaction(context.Background(), 7, owner)
+	assert.True(t, apperror.Is(err, apperror.CodeConflict))
+
+	assert.Equal(t, int64(10000), cards.cards[1].Balance)
+	assert.True(t, transactions.transactions[7].DeletedAt.Valid)
+}
+
+func TestRestoreTransaction_CardLookupFails(t *testing.T) {
+	owner := uuid.New()
+	cards := &memoryCards{
+		cards:   map[int64]*entity.Card{1: {ID: 1, UserID: owner, Balance: 10000}},
+		findErr: assert.AnError,
+	}
+	transactions := &memoryTransactions{cards: cards, transactions: map[int64]*entity.Transaction{
+		7: {ID: 7, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeIncome, Amount: 2500, DeletedAt: deletedAt(time.Now())},
+	}}
+	service := newService(t, cards, transactions)
+
+	_, err := service.RestoreTransaction(context.Background(), 7, owner)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, apperror.Is(err, apperror.CodeConflict))
+
+	assert.Equal(t, int64(10000), cards.cards[1].Balance)
+	assert.True(t, transactions.transactions[7].DeletedAt.Valid)
+}
+
+func TestRestore_OtherUsersTrash(t *testing.T) {
+	owner := uuid.New()
+	cards := &memoryCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Balance: 10000},
+		2: {ID: 2, UserID: owner, DeletedAt: deletedAt(time.Now())},
+	}}
+	transactions := &memoryTransactions{cards: cards, transactions: map[int64]*entity.Transaction{
+		7: {ID: 7, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500, DeletedAt: deletedAt(time.Now())},
+	}}
+	service := newService(t, cards, transactions)
+
+	_, err := service.RestoreCard(context.Background(), 2, uuid.New())
+	assert.Error(t, err)
+	_, err = service.RestoreTransaction(context.Background(), 7, uuid.New())
+	assert.Error(t, err)
+
+	assert.True(t, cards.cards[2].DeletedAt.Valid)
+	assert.True(t, transactions.transactions[7].DeletedAt.Valid)
+	assert.Equal(t, int64(10000), cards.cards[1].Balance)
+}
+
+func TestRestoreTransaction_HouseholdCard(t *testing.T) {
+	owner, editor, viewer := uuid.New(), uuid.New(), uuid.New()
+	household := int64(3)
+	members := []entity.HouseholdMember{
+		{HouseholdID: household, UserID: owner, Role: entity.HouseholdRoleOwner},
+		{HouseholdID: household, UserID: editor, Role: entity.HouseholdRoleEditor},
+		{HouseholdID: household, UserID: viewer, Role: entity.HouseholdRoleViewer},
+	}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		wantErr apperror.Code
+	}{
+		{name: "owner restores", userID: owner},
+		{name: "editor restores", userID: editor},
+		{name: "viewer cannot restore", userID: viewer, wantErr: apperror.CodeForbidden},
+		{name: "non-member cannot restore", userID: uuid.New(), wantErr: apperror.CodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards := &memoryCards{cards: map[int64]*entity.Card{
+				1: {ID: 1, UserID: owner, HouseholdID: &household, Balance: 10000},
+			}}
+			transactions := &memoryTransactions{cards: cards, transactions: map[int64]*entity.Transaction{
+				7: {ID: 7, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500, DeletedAt: deletedAt(time.Now())},
+			}}
+			service := newService(t, cards, transactions, members...)
+
+			_, err := service.RestoreTransaction(context.Background(), 7, tt.userID)
+			if tt.wantErr != "" {
+				assert.True(t, apperror.Is(err, tt.wantErr))
+				assert.Equal(t, int64(10000), cards.cards[1].Balance)
+				assert.True(t, transactions.transactions[7].DeletedAt.Valid)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, int64(7500), cards.cards[1].Balance)
+			assert.False(t, transactions.transactions[7].DeletedAt.Valid)
+		})
+	}
+}
+
+func TestRestoreTransaction_RestoredMeanwhile(t *testing.T) {
+	owner := uuid.New()
+	cards := &memoryCards{cards: map[int64]*entity.Card{
+		1: {ID: 1, UserID: owner, Balance: 10000},
+	}}
+	transactions := &memoryTransactions{cards: cards, transactions: map[int64]*entity.Transaction{
+		7: {ID: 7, UserID: owner, CardID: 1, TransactionType: entity.TransactionTypeExpense, Amount: 2500, DeletedAt: deletedAt(time.Now())},
+	}}
+	// Another request restores the transaction after this one read it
+	racing := &racingTransactions{memoryTransactions: transactions, race: func() {
+		_, err := newService(t, cards, transactions).RestoreTransaction(context.Background(), 7, owner)
+		require.NoError(t, err)
+	}}
+	service := newService(t, cards, racing)
+
+	_, err := service.RestoreTransaction(context.Background(), 7, owner)
+	assert.True(t, apperror.Is(err, apperror.CodeConflict))
+	assert.Equal(t, int64(7500), cards.cards[1].Balance, "the balance is re-applied once")
+}
@@ -15,5 +15,6 @@ type ProfileResponse struct {
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
 	FullName  string    `json:"full_name"`
+	Role      string    `json:"role"`
 	IsActive  bool      `json:"is_active"`
 }
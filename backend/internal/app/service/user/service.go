@@ -3,7 +3,9 @@ package user
 import (
 	"context"
 	"fmt"
+	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/audit"
 
 	"github.com/google/uuid"
 )
@@ -15,11 +17,13 @@ type Service interface {
 
 type service struct {
 	userRepo repository.UserRepository
+	auditor  audit.Recorder
 }
 
-func NewService(userRepo repository.UserRepository) Service {
+func NewService(userRepo repository.UserRepository, auditor audit.Recorder) Service {
 	return &service{
 		userRepo: userRepo,
+		auditor:  auditor,
 	}
 }
 
@@ -35,6 +39,7 @@ func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*ProfileRes
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		FullName:  user.FullName(),
+		Role:      user.Role,
 		IsActive:  user.IsActive,
 	}, nil
 }
@@ -45,6 +50,8 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, req Updat
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	before := audit.User(user)
+
 	// Update fields if provided
 	if req.FirstName != "" {
 		user.FirstName = req.FirstName
@@ -56,6 +63,15 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, req Updat
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
+	s.auditor.Record(ctx, audit.Change{
+		UserID:     user.ID,
+		ActorID:    userID,
+		Action:     entity.AuditActionUpdate,
+		EntityType: entity.AuditEntityUser,
+		EntityID:   user.ID,
+		Before:     before,
+		After:      audit.User(user),
+	})
 
 	return &ProfileResponse{
 		ID:        user.ID,
@@ -63,6 +79,7 @@ func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, req Updat
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		FullName:  user.FullName(),
+		Role:      user.Role,
 		IsActive:  user.IsActive,
 	}, nil
 }
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookRequest contains webhook data for create and update
+type WebhookRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=500"`
+	Description string   `json:"description" binding:"max=200"`
+	Events      []string `json:"events" binding:"required,min=1,dive,oneof=transaction.created transaction.updated transaction.deleted transaction.restored card.frozen card.unfrozen budget.exceeded"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// WebhookResponse contains webhook data. Secret is only returned when it is
+// created or rotated.
+type WebhookResponse struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events"`
+	IsActive    bool      `json:"is_active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DeliveryFilter contains delivery log parameters
+type DeliveryFilter struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// DeliveryResponse is one entry of a webhook's delivery log
+type DeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	DurationMs     int64           `json:"duration_ms"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+}
+
+// DeliveryListResponse contains a page of the delivery log
+type DeliveryListResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+	Total      int64              `json:"total"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+}
+
+// Payload is the JSON body POSTed to receivers. ID is the same for every
+// attempt and every webhook receiving the event, so receivers can deduplicate.
+type Payload struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
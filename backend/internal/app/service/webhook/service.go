@@ -0,0 +1,400 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/pkg/webhook"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventPing is sent by the test endpoint; it is not subscribable
+const EventPing = "ping"
+
+type Service interface {
+	CreateWebhook(ctx context.Context, userID uuid.UUID, req WebhookRequest) (*WebhookResponse, error)
+	GetWebhooks(ctx context.Context, userID uuid.UUID) ([]WebhookResponse, error)
+	GetWebhook(ctx context.Context, webhookID int64, userID uuid.UUID) (*WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, webhookID int64, userID uuid.UUID, req WebhookRequest) (*WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, webhookID int64, userID uuid.UUID) error
+	RotateSecret(ctx context.Context, webhookID int64, userID uuid.UUID) (*WebhookResponse, error)
+	// Ping sends a test event right away and returns its delivery log entry
+	Ping(ctx context.Context, webhookID int64, userID uuid.UUID) (*DeliveryResponse, error)
+	GetDeliveries(ctx context.Context, webhookID int64, userID uuid.UUID, filter DeliveryFilter) (*DeliveryListResponse, error)
+	// HandleEvent queues an event for every subscribed webhook; subscribe it to the event bus
+	HandleEvent(ctx context.Context, event events.Event) error
+	// DispatchPending sends queued deliveries that are due
+	DispatchPending(ctx context.Context) error
+}
+
+type service struct {
+	webhookRepo repository.WebhookRepository
+	sender      *webhook.Sender
+	cfg         config.WebhooksConfig
+	logger      *logger.Logger
+}
+
+func NewService(
+	webhookRepo repository.WebhookRepository,
+	sender *webhook.Sender,
+	cfg config.WebhooksConfig,
+	logger *logger.Logger,
+) Service {
+	return &service{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (s *service) CreateWebhook(ctx context.Context, userID uuid.UUID, req WebhookRequest) (*WebhookResponse, error) {
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &entity.Webhook{
+		UserID: userID,
+		Secret: secret,
+	}
+	if err := s.applyRequest(ctx, hook, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Create(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	resp := toResponse(hook)
+	resp.Secret = hook.Secret
+	return resp, nil
+}
+
+func (s *service) GetWebhooks(ctx context.Context, userID uuid.UUID) ([]WebhookResponse, error) {
+	hooks, err := s.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	responses := make([]WebhookResponse, len(hooks))
+	for i := range hooks {
+		responses[i] = *toResponse(&hooks[i])
+	}
+	return responses, nil
+}
+
+func (s *service) GetWebhook(ctx context.Context, webhookID int64, userID uuid.UUID) (*WebhookResponse, error) {
+	hook, err := s.findUserWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(hook), nil
+}
+
+func (s *service) UpdateWebhook(ctx context.Context, webhookID int64, userID uuid.UUID, req WebhookRequest) (*WebhookResponse, error) {
+	hook, err := s.findUserWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(ctx, hook, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return toResponse(hook), nil
+}
+
+func (s *service) DeleteWebhook(ctx context.Context, webhookID int64, userID uuid.UUID) error {
+	if _, err := s.findUserWebhook(ctx, webhookID, userID); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(ctx, webhookID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (s *service) RotateSecret(ctx context.Context, webhookID int64, userID uuid.UUID) (*WebhookResponse, error) {
+	hook, err := s.findUserWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hook.Secret, err = webhook.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	resp := toResponse(hook)
+	resp.Secret = hook.Secret
+	return resp, nil
+}
+
+func (s *service) Ping(ctx context.Context, webhookID int64, userID uuid.UUID) (*DeliveryResponse, error) {
+	hook, err := s.findUserWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := events.New(EventPing, userID, map[string]interface{}{
+		"webhook_id": hook.ID,
+	})
+	deliveries, err := s.enqueue(ctx, []entity.Webhook{*hook}, event)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pings report the receiver's answer directly instead of being retried
+	delivery := &deliveries[0]
+	delivery.Webhook = hook
+	s.attempt(ctx, delivery, false)
+
+	resp := toDeliveryResponse(delivery)
+	return &resp, nil
+}
+
+func (s *service) GetDeliveries(ctx context.Context, webhookID int64, userID uuid.UUID, filter DeliveryFilter) (*DeliveryListResponse, error) {
+	if _, err := s.findUserWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = 20
+	}
+
+	deliveries, total, err := s.webhookRepo.FindDeliveries(ctx, webhookID, repository.DeliveryFilter{
+		Status: filter.Status,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
+	}
+
+	responses := make([]DeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = toDeliveryResponse(&deliveries[i])
+	}
+
+	return &DeliveryListResponse{
+		Deliveries: responses,
+		Total:      total,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}, nil
+}
+
+func (s *service) HandleEvent(ctx context.Context, event events.Event) error {
+	hooks, err := s.webhookRepo.FindSubscribed(ctx, event.UserID, event.Type)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.enqueue(ctx, hooks, event)
+	return err
+}
+
+func (s *service) DispatchPending(ctx context.Context) error {
+	now := time.Now()
+	// Hold claimed rows long enough for every request in the batch to time out
+	lease := now.Add(time.Duration(s.cfg.BatchSize+1) * s.cfg.Timeout)
+
+	deliveries, err := s.webhookRepo.ClaimDue(ctx, now, lease, s.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.attempt(ctx, &deliveries[i], true)
+	}
+	return nil
+}
+
+// enqueue writes an outbox row per webhook. The payload is rendered once so
+// every attempt sends identical bytes.
+func (s *service) enqueue(ctx context.Context, hooks []entity.Webhook, event events.Event) ([]entity.WebhookDelivery, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	data := event.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	body, err := json.Marshal(Payload{
+		ID:        "evt_" + uuid.NewString(),
+		Type:      event.Type,
+		CreatedAt: event.OccurredAt,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]entity.WebhookDelivery, len(hooks))
+	for i, hook := range hooks {
+		deliveries[i] = entity.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventType:     event.Type,
+			Payload:       string(body),
+			Status:        entity.DeliveryStatusPending,
+			NextAttemptAt: &now,
+		}
+	}
+
+	if err := s.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// attempt sends a delivery once and records the outcome. Failed deliveries
+// are rescheduled with exponential backoff until they run out of attempts.
+func (s *service) attempt(ctx context.Context, delivery *entity.WebhookDelivery, retry bool) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.NextAttemptAt = nil
+	delivery.ResponseStatus = nil
+	delivery.Error = ""
+	delivery.DurationMs = 0
+
+	hook := delivery.Webhook
+	if hook == nil || !hook.IsActive {
+		delivery.Status = entity.DeliveryStatusFailed
+		delivery.Error = "webhook is disabled"
+		s.saveDelivery(ctx, delivery)
+		return
+	}
+
+	result, err := s.sender.Send(ctx, webhook.Request{
+		URL:        hook.URL,
+		Secret:     hook.Secret,
+		Event:      delivery.EventType,
+		DeliveryID: strconv.FormatInt(delivery.ID, 10),
+		Body:       []byte(delivery.Payload),
+	})
+
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case result.Success():
+		delivery.ResponseStatus = &result.StatusCode
+		delivery.DurationMs = result.Duration.Milliseconds()
+	default:
+		delivery.ResponseStatus = &result.StatusCode
+		delivery.DurationMs = result.Duration.Milliseconds()
+		delivery.Error = fmt.Sprintf("receiver responded with status %d", result.StatusCode)
+	}
+
+	switch {
+	case delivery.Error == "":
+		delivery.Status = entity.DeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+	case retry && delivery.Attempts < s.cfg.MaxAttempts:
+		next := now.Add(webhook.Backoff(delivery.Attempts, s.cfg.InitialBackoff, s.cfg.MaxBackoff))
+		delivery.Status = entity.DeliveryStatusPending
+		delivery.NextAttemptAt = &next
+	default:
+		delivery.Status = entity.DeliveryStatusFailed
+	}
+
+	s.saveDelivery(ctx, delivery)
+}
+
+func (s *service) saveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) {
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		s.logger.Error("Failed to record webhook delivery", logger.Int("delivery_id", int(delivery.ID)), logger.Error(err))
+	}
+}
+
+func (s *service) findUserWebhook(ctx context.Context, webhookID int64, userID uuid.UUID) (*entity.Webhook, error) {
+	hook, err := s.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hook.UserID != userID {
+		return nil, apperror.Forbidden("unauthorized access to webhook")
+	}
+	return hook, nil
+}
+
+// applyRequest validates a webhook request and copies it onto the webhook.
+// The URL must resolve to public addresses only.
+func (s *service) applyRequest(ctx context.Context, hook *entity.Webhook, req WebhookRequest) error {
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return apperror.Validation("url must be an absolute http or https URL")
+	}
+	if err := s.sender.CheckHost(ctx, parsed.Hostname()); err != nil {
+		if errors.Is(err, webhook.ErrBlockedDestination) {
+			return apperror.Validation("url must not point to a private, loopback or link-local address").WithField("url", "must be a public address")
+		}
+		return apperror.Validation("url host could not be resolved").WithField("url", "must resolve")
+	}
+
+	hook.URL = req.URL
+	hook.Description = req.Description
+	hook.IsActive = req.IsActive == nil || *req.IsActive
+
+	hook.Events = entity.EventTypes{}
+	for _, eventType := range req.Events {
+		if !hook.Events.Has(eventType) {
+			hook.Events = append(hook.Events, eventType)
+		}
+	}
+	return nil
+}
+
+func toResponse(hook *entity.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:          hook.ID,
+		URL:         hook.URL,
+		Description: hook.Description,
+		Events:      hook.Events,
+		IsActive:    hook.IsActive,
+		CreatedAt:   hook.CreatedAt,
+	}
+}
+
+func toDeliveryResponse(delivery *entity.WebhookDelivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             delivery.ID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		DurationMs:     delivery.DurationMs,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+}
@@ -0,0 +1,320 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/webhook"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	pkgwebhook "pfn-backend/internal/pkg/webhook"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository is an in-memory WebhookRepository
+type memoryRepository struct {
+	repository.WebhookRepository
+	webhooks   map[int64]*entity.Webhook
+	deliveries map[int64]*entity.WebhookDelivery
+	nextID     int64
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		webhooks:   map[int64]*entity.Webhook{},
+		deliveries: map[int64]*entity.WebhookDelivery{},
+	}
+}
+
+func (r *memoryRepository) Create(ctx context.Context, hook *entity.Webhook) error {
+	r.nextID++
+	hook.ID = r.nextID
+	stored := *hook
+	r.webhooks[hook.ID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	hook, ok := r.webhooks[id]
+	if !ok {
+		return nil, assert.AnError
+	}
+	found := *hook
+	return &found, nil
+}
+
+func (r *memoryRepository) FindSubscribed(ctx context.Context, userID uuid.UUID, eventType string) ([]entity.Webhook, error) {
+	var hooks []entity.Webhook
+	for _, hook := range r.webhooks {
+		if hook.UserID == userID && hook.IsActive && hook.Events.Has(eventType) {
+			hooks = append(hooks, *hook)
+		}
+	}
+	return hooks, nil
+}
+
+func (r *memoryRepository) CreateDeliveries(ctx context.Context, deliveries []entity.WebhookDelivery) error {
+	for i := range deliveries {
+		r.nextID++
+		deliveries[i].ID = r.nextID
+		stored := deliveries[i]
+		r.deliveries[stored.ID] = &stored
+	}
+	return nil
+}
+
+func (r *memoryRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	var due []entity.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status != entity.DeliveryStatusPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.NextAttemptAt = &leaseUntil
+		claimed := *delivery
+		claimed.Webhook = r.webhooks[delivery.WebhookID]
+		due = append(due, claimed)
+	}
+	return due, nil
+}
+
+func (r *memoryRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	stored := *delivery
+	stored.Webhook = nil
+	r.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+// makeDue moves every pending delivery's next attempt into the past
+func (r *memoryRepository) makeDue() {
+	past := time.Now().Add(-time.Second)
+	for _, delivery := range r.deliveries {
+		if delivery.Status == entity.DeliveryStatusPending {
+			delivery.NextAttemptAt = &past
+		}
+	}
+}
+
+// receiver is an httptest server that records deliveries and answers with
+// the queued status codes, then 200
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	rec := &receiver{statuses: statuses}
+	rec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		rec.requests = append(rec.requests, r)
+		rec.bodies = append(rec.bodies, body)
+		status := http.StatusOK
+		if len(rec.statuses) > 0 {
+			status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(rec.Close)
+	return rec
+}
+
+// newService delivers to the loopback receivers of these tests
+func newService(t *testing.T, repo *memoryRepository) webhook.Service {
+	return newServiceWithConfig(t, repo, config.WebhooksConfig{
+		Timeout:              time.Second,
+		MaxAttempts:          3,
+		InitialBackoff:       time.Minute,
+		MaxBackoff:           time.Hour,
+		BatchSize:            10,
+		AllowPrivateNetworks: true,
+	})
+}
+
+func newServiceWithConfig(t *testing.T, repo *memoryRepository, cfg config.WebhooksConfig) webhook.Service {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return webhook.NewService(repo, pkgwebhook.NewSender(cfg.Timeout, cfg.AllowPrivateNetworks), cfg, log)
+}
+
+func TestService_DeliversSignedEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	svc := newService(t, repo)
+	rec := newReceiver(t)
+	userID := uuid.New()
+
+	created, err := svc.CreateWebhook(ctx, userID, webhook.WebhookRequest{
+		URL:    rec.URL,
+		Events: []string{events.TransactionCreated},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Secret)
+
+	// Unsubscribed types and other users' events are not queued
+	require.NoError(t, svc.HandleEvent(ctx, events.New(events.CardFrozen, userID, nil)))
+	require.NoError(t, svc.HandleEvent(ctx, events.New(events.TransactionCreated, uuid.New(), nil)))
+	require.NoError(t, svc.HandleEvent(ctx, events.New(events.TransactionCreated, userID, map[string]interface{}{"amount": 1200})))
+	require.Len(t, repo.deliveries, 1)
+
+	require.NoError(t, svc.DispatchPending(ctx))
+
+	require.Len(t, rec.requests, 1)
+	req, body := rec.requests[0], rec.bodies[0]
+	assert.Equal(t, events.TransactionCreated, req.Header.Get(pkgwebhook.EventHeader))
+	assert.NoError(t, pkgwebhook.Verify(created.Secret, req.Header.Get(pkgwebhook.SignatureHeader), body, time.Minute, time.Now()))
+
+	var payload webhook.Payload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, events.TransactionCreated, payload.Type)
+	assert.EqualValues(t, 1200, payload.Data["amount"])
+
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, entity.DeliveryStatusSucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.NotNil(t, delivery.DeliveredAt)
+	}
+}
+
+func TestService_RetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	svc := newService(t, repo)
+	userID := uuid.New()
+
+	t.Run("succeeds after a failure", func(t *testing.T) {
+		rec := newReceiver(t, http.StatusInternalServerError)
+		_, err := svc.CreateWebhook(ctx, userID, webhook.WebhookRequest{URL: rec.URL, Events: []string{events.CardFrozen}})
+		require.NoError(t, err)
+		require.NoError(t, svc.HandleEvent(ctx, events.New(events.CardFrozen, userID, nil)))
+
+		before := time.Now()
+		require.NoError(t, svc.DispatchPending(ctx))
+
+		var delivery *entity.WebhookDelivery
+		for _, d := range repo.deliveries {
+			delivery = d
+		}
+		assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, http.StatusInternalServerError, *delivery.ResponseStatus)
+		require.NotNil(t, delivery.NextAttemptAt)
+		assert.WithinDuration(t, before.Add(time.Minute), *delivery.NextAttemptAt, 5*time.Second)
+
+		// Not due yet: nothing is sent
+		require.NoError(t, svc.DispatchPending(ctx))
+		assert.Len(t, rec.requests, 1)
+
+		repo.makeDue()
+		require.NoError(t, svc.DispatchPending(ctx))
+
+		delivery = repo.deliveries[delivery.ID]
+		assert.Equal(t, entity.DeliveryStatusSucceeded, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		// Retries resend the same event
+		require.Len(t, rec.bodies, 2)
+		assert.Equal(t, rec.bodies[0], rec.bodies[1])
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		repo.deliveries = map[int64]*entity.WebhookDelivery{}
+		rec := newReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+		hook, err := svc.CreateWebhook(ctx, userID, webhook.WebhookRequest{URL: rec.URL, Events: []string{events.CardUnfrozen}})
+		require.NoError(t, err)
+		require.NoError(t, svc.HandleEvent(ctx, events.New(events.CardUnfrozen, userID, nil)))
+
+		for i := 0; i < 3; i++ {
+			repo.makeDue()
+			require.NoError(t, svc.DispatchPending(ctx))
+		}
+
+		for _, delivery := range repo.deliveries {
+			assert.Equal(t, hook.ID, delivery.WebhookID)
+			assert.Equal(t, entity.DeliveryStatusFailed, delivery.Status)
+			assert.Equal(t, 3, delivery.Attempts)
+			assert.Nil(t, delivery.NextAttemptAt)
+		}
+		assert.Len(t, rec.requests, 3)
+	})
+}
+
+func TestService_Ping(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	svc := newService(t, repo)
+	userID := uuid.New()
+
+	t.Run("reports the receiver's answer", func(t *testing.T) {
+		rec := newReceiver(t, http.StatusGone)
+		hook, err := svc.CreateWebhook(ctx, userID, webhook.WebhookRequest{URL: rec.URL, Events: []string{events.CardFrozen}})
+		require.NoError(t, err)
+
+		result, err := svc.Ping(ctx, hook.ID, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, webhook.EventPing, result.EventType)
+		assert.Equal(t, entity.DeliveryStatusFailed, result.Status)
+		assert.Equal(t, http.StatusGone, *result.ResponseStatus)
+		assert.Len(t, rec.requests, 1)
+	})
+
+	t.Run("rejects other users", func(t *testing.T) {
+		rec := newReceiver(t)
+		hook, err := svc.CreateWebhook(ctx, userID, webhook.WebhookRequest{URL: rec.URL, Events: []string{events.CardFrozen}})
+		require.NoError(t, err)
+
+		_, err = svc.Ping(ctx, hook.ID, uuid.New())
+
+		assert.Error(t, err)
+		assert.Empty(t, rec.requests)
+	})
+}
+
+func TestService_RejectsPrivateURLs(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	svc := newServiceWithConfig(t, repo, config.WebhooksConfig{Timeout: time.Second, MaxAttempts: 3})
+	userID := uuid.New()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"loopback", "http://127.0.0.1:8080/hook"},
+		{"localhost", "http://localhost/hook"},
+		{"private", "https://10.0.0.5/hook"},
+		{"cloud metadata", "http://169.254.169.254/latest/meta-data"},
+		{"ipv6 loopback", "http://[::1]:9000/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateWebhook(ctx, userID, webhook.WebhookRequest{URL: tt.url, Events: []string{events.CardFrozen}})
+			assert.True(t, apperror.Is(err, apperror.CodeValidation))
+		})
+	}
+	assert.Empty(t, repo.webhooks)
+
+	t.Run("update", func(t *testing.T) {
+		hook, err := newService(t, repo).CreateWebhook(ctx, userID, webhook.WebhookRequest{URL: "http://127.0.0.1/hook", Events: []string{events.CardFrozen}})
+		require.NoError(t, err)
+
+		_, err = svc.UpdateWebhook(ctx, hook.ID, userID, webhook.WebhookRequest{URL: "http://192.168.0.1/hook", Events: []string{events.CardFrozen}})
+		assert.True(t, apperror.Is(err, apperror.CodeValidation))
+		assert.Equal(t, "http://127.0.0.1/hook", repo.webhooks[hook.ID].URL)
+	})
+}
@@ -9,12 +9,20 @@ import (
 )
 
 type Config struct {
-	App      AppConfig      `mapstructure:"app"`
-	Database DatabaseConfig `mapstructure:"database"`
-	JWT      JwtConfig      `mapstructure:"jwt"`
-	CORS     CORSConfig     `mapstructure:"cors"`
-	Logger   LoggerConfig   `mapstructure:"logger"`
-	Tracer   TracerConfig   `mapstructure:"tracer"`
+	App         AppConfig         `mapstructure:"app"`
+	Database    DatabaseConfig    `mapstructure:"database"`
+	JWT         JwtConfig         `mapstructure:"jwt"`
+	CORS        CORSConfig        `mapstructure:"cors"`
+	Logger      LoggerConfig      `mapstructure:"logger"`
+	Tracer      TracerConfig      `mapstructure:"tracer"`
+	Jobs        JobsConfig        `mapstructure:"jobs"`
+	Duplicates  DuplicatesConfig  `mapstructure:"duplicates"`
+	Suggestions SuggestionsConfig `mapstructure:"suggestions"`
+	Webhooks    WebhooksConfig    `mapstructure:"webhooks"`
+	Trash       TrashConfig       `mapstructure:"trash"`
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	GraphQL     GraphQLConfig     `mapstructure:"graphql"`
+	GRPC        GRPCConfig        `mapstructure:"grpc"`
 }
 
 type AppConfig struct {
@@ -77,6 +85,59 @@ type TracerConfig struct {
 	SampleRate  float64 `mapstructure:"sample_rate"`
 }
 
+// JobsConfig contains background job intervals; a zero interval disables the job
+type JobsConfig struct {
+	NetWorthSnapshotInterval time.Duration `mapstructure:"net_worth_snapshot_interval"`
+	BillReminderInterval     time.Duration `mapstructure:"bill_reminder_interval"`
+	WebhookDispatchInterval  time.Duration `mapstructure:"webhook_dispatch_interval"`
+	TrashPurgeInterval       time.Duration `mapstructure:"trash_purge_interval"`
+	IdempotencyPurgeInterval time.Duration `mapstructure:"idempotency_purge_interval"`
+}
+
+// DuplicatesConfig controls duplicate transaction detection
+type DuplicatesConfig struct {
+	WindowDays    int     `mapstructure:"window_days"`    // max days between duplicate postings
+	MinSimilarity float64 `mapstructure:"min_similarity"` // 0..1 description word overlap
+}
+
+// SuggestionsConfig controls the in-memory category suggestion models
+type SuggestionsConfig struct {
+	MaxModels int `mapstructure:"max_models"` // per-user models kept, least recently used dropped first
+}
+
+// WebhooksConfig controls outbound webhook delivery
+type WebhooksConfig struct {
+	Timeout        time.Duration `mapstructure:"timeout"`         // per-request timeout
+	MaxAttempts    int           `mapstructure:"max_attempts"`    // attempts before a delivery is marked failed
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"` // delay before the first retry, doubled per retry
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	BatchSize      int           `mapstructure:"batch_size"` // deliveries sent per dispatch run
+	// Receivers on loopback, private or link-local addresses; local development only
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+// TrashConfig controls how long soft-deleted cards and transactions are kept
+type TrashConfig struct {
+	Retention time.Duration `mapstructure:"retention"` // age after which deleted rows are purged
+}
+
+// IdempotencyConfig controls how long Idempotency-Key responses are replayed
+type IdempotencyConfig struct {
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// GraphQLConfig bounds the queries /graphql accepts
+type GraphQLConfig struct {
+	MaxDepth      int `mapstructure:"max_depth"`      // nested field levels
+	MaxComplexity int `mapstructure:"max_complexity"` // fields, multiplied through lists
+}
+
+// GRPCConfig controls the gRPC API served next to HTTP on app.host
+type GRPCConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Port    string `mapstructure:"port"`
+}
+
 func Load(configPath string) (*Config, error) {
 	v := viper.New()
 
@@ -140,8 +201,8 @@ func setDefaults(v *viper.Viper) {
 	// CORS defaults
 	v.SetDefault("cors.allow_origins", []string{"*"})
 	v.SetDefault("cors.allow_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
-	v.SetDefault("cors.allow_headers", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"})
-	v.SetDefault("cors.expose_headers", []string{"X-Request-ID"})
+	v.SetDefault("cors.allow_headers", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Request-ID", "Idempotency-Key", "If-Match"})
+	v.SetDefault("cors.expose_headers", []string{"X-Request-ID", "Idempotent-Replayed", "ETag"})
 	v.SetDefault("cors.max_age", 86400)
 
 	// Logger defaults
@@ -157,4 +218,40 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("tracer.insecure", true)
 	v.SetDefault("tracer.sample_rate", 1.0)
 
+	// Background job defaults
+	v.SetDefault("jobs.net_worth_snapshot_interval", "1h")
+	v.SetDefault("jobs.bill_reminder_interval", "1h")
+	v.SetDefault("jobs.webhook_dispatch_interval", "10s")
+	v.SetDefault("jobs.trash_purge_interval", "24h")
+	v.SetDefault("jobs.idempotency_purge_interval", "1h")
+
+	// Duplicate detection defaults
+	v.SetDefault("duplicates.window_days", 3)
+	v.SetDefault("duplicates.min_similarity", 0.5)
+
+	// Category suggestion defaults
+	v.SetDefault("suggestions.max_models", 1000)
+
+	// Webhook delivery defaults
+	v.SetDefault("webhooks.timeout", "10s")
+	v.SetDefault("webhooks.max_attempts", 8)
+	v.SetDefault("webhooks.initial_backoff", "30s")
+	v.SetDefault("webhooks.max_backoff", "6h")
+	v.SetDefault("webhooks.batch_size", 50)
+	v.SetDefault("webhooks.allow_private_networks", false)
+
+	// Trash defaults
+	v.SetDefault("trash.retention", "720h")
+
+	// Idempotency defaults
+	v.SetDefault("idempotency.ttl", "24h")
+
+	// GraphQL defaults
+	v.SetDefault("graphql.max_depth", 8)
+	v.SetDefault("graphql.max_complexity", 1000)
+
+	// gRPC defaults
+	v.SetDefault("grpc.enabled", true)
+	v.SetDefault("grpc.port", "9090")
+
 }
@@ -0,0 +1,13 @@
+// Package docs holds the OpenAPI document generated from the handler
+// annotations. Regenerate it with go generate after changing routes or
+// annotations; the router tests fail while it is out of date.
+package docs
+
+import _ "embed"
+
+//go:generate go run ../../cmd/openapi -root ../..
+
+// OpenAPI is the OpenAPI 3 document served at /api/docs/openapi.json
+//
+//go:embed openapi.json
+var OpenAPI []byte
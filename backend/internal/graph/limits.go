@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// defaultListSize is the assumed length of lists that take no limit argument
+const defaultListSize = 10
+
+// Limits bound the cost of a single query. Zero disables a limit.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// measure walks an operation and returns its depth and complexity. Every
+// field costs one; a list multiplies the cost of its selection by the limit
+// argument of the nearest field above it that takes one, or by
+// defaultListSize. Introspection fields are free.
+type measure struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+func (l Limits) check(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	m := &measure{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		// Execution reports the missing operation
+		return nil
+	}
+
+	depth, complexity := m.selections(schema.QueryType(), operation.SelectionSet, 0)
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity)
+	}
+	return nil
+}
+
+// selections returns the depth and complexity of a selection set on parent.
+// pending is a limit argument not yet consumed by a list.
+func (m *measure) selections(parent *graphql.Object, set *ast.SelectionSet, pending int) (int, int) {
+	if set == nil || parent == nil {
+		return 0, 0
+	}
+
+	depth, complexity := 0, 0
+	add := func(d, c int) {
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			add(m.field(parent, selection, pending))
+		case *ast.InlineFragment:
+			add(m.selections(m.object(parent, selection.TypeCondition), selection.SelectionSet, pending))
+		case *ast.FragmentSpread:
+			if fragment, ok := m.fragments[selection.Name.Value]; ok {
+				add(m.selections(m.object(parent, fragment.TypeCondition), fragment.SelectionSet, pending))
+			}
+		}
+	}
+	return depth, complexity
+}
+
+func (m *measure) field(parent *graphql.Object, field *ast.Field, pending int) (int, int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+	def, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	if limit, ok := m.limit(def, field); ok {
+		pending = limit
+	}
+
+	// Unwrap to the named type, noting whether a list sits in between
+	multiplier := 1
+	typ := def.Type
+	for {
+		switch t := typ.(type) {
+		case *graphql.NonNull:
+			typ = t.OfType
+			continue
+		case *graphql.List:
+			multiplier = defaultListSize
+			if pending > 0 {
+				multiplier, pending = pending, 0
+			}
+			typ = t.OfType
+			continue
+		}
+		break
+	}
+
+	object, _ := typ.(*graphql.Object)
+	depth, complexity := m.selections(object, field.SelectionSet, pending)
+	return depth + 1, 1 + multiplier*complexity
+}
+
+// limit reads the field's limit argument, falling back to its default
+func (m *measure) limit(def *graphql.FieldDefinition, field *ast.Field) (int, bool) {
+	var arg *graphql.Argument
+	for _, a := range def.Args {
+		if a.Name() == "limit" {
+			arg = a
+		}
+	}
+	if arg == nil {
+		return 0, false
+	}
+
+	for _, a := range field.Arguments {
+		if a.Name.Value != "limit" {
+			continue
+		}
+		switch value := a.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil {
+				return n, true
+			}
+		case *ast.Variable:
+			switch n := m.variables[value.Name.Value].(type) {
+			case int:
+				return n, true
+			case float64:
+				return int(n), true
+			}
+		}
+	}
+	if n, ok := arg.DefaultValue.(int); ok {
+		return n, true
+	}
+	return 0, false
+}
+
+// object resolves a fragment's type condition, defaulting to the parent
+func (m *measure) object(parent *graphql.Object, condition *ast.Named) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	object, _ := m.schema.Type(condition.Name.Value).(*graphql.Object)
+	return object
+}
@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"context"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/category"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders batch the lookups that would otherwise run once per list item.
+// They are created per request, so their caches never outlive it.
+type loaders struct {
+	cards      *dataloader.Loader[int64, *card.CardResponse]
+	categories *dataloader.Loader[int64, *category.CategoryResponse]
+}
+
+func (s *Schema) newLoaders(userID uuid.UUID) *loaders {
+	return &loaders{
+		cards:      dataloader.NewBatchedLoader(s.batchCards(userID)),
+		categories: dataloader.NewBatchedLoader(s.batchCategories),
+	}
+}
+
+// batchCards loads the user's cards, including those shared with them, in one
+// call; a card missing from that list is fetched on its own, which also
+// applies the usual access check
+func (s *Schema) batchCards(userID uuid.UUID) dataloader.BatchFunc[int64, *card.CardResponse] {
+	return func(ctx context.Context, ids []int64) []*dataloader.Result[*card.CardResponse] {
+		results := make([]*dataloader.Result[*card.CardResponse], len(ids))
+
+		cards, err := s.cardService.GetUserCards(ctx, userID, card.CardListFilter{})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*card.CardResponse]{Error: err}
+			}
+			return results
+		}
+		byID := make(map[int64]*card.CardResponse, len(cards))
+		for i := range cards {
+			byID[cards[i].ID] = &cards[i]
+		}
+
+		for i, id := range ids {
+			if c, ok := byID[id]; ok {
+				results[i] = &dataloader.Result[*card.CardResponse]{Data: c}
+				continue
+			}
+			c, err := s.cardService.GetCard(ctx, id, userID)
+			results[i] = &dataloader.Result[*card.CardResponse]{Data: c, Error: err}
+		}
+		return results
+	}
+}
+
+// batchCategories loads every category in one call
+func (s *Schema) batchCategories(ctx context.Context, ids []int64) []*dataloader.Result[*category.CategoryResponse] {
+	results := make([]*dataloader.Result[*category.CategoryResponse], len(ids))
+
+	categories, err := s.categoryService.ListCategories(ctx, nil)
+	if err != nil {
+		for i := range results {
+			results[i] = &dataloader.Result[*category.CategoryResponse]{Error: err}
+		}
+		return results
+	}
+	byID := make(map[int64]*category.CategoryResponse, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+
+	// A category that no longer exists resolves to null
+	for i, id := range ids {
+		results[i] = &dataloader.Result[*category.CategoryResponse]{Data: byID[id]}
+	}
+	return results
+}
@@ -0,0 +1,155 @@
+// Package graph serves a read-only GraphQL view of the finance domain. It
+// resolves through the same services as the REST handlers, so access rules
+// and response shapes stay in one place.
+package graph
+
+import (
+	"context"
+	"fmt"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/app/service/user"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Schema executes GraphQL queries on behalf of a user
+type Schema struct {
+	schema          graphql.Schema
+	limits          Limits
+	userService     user.Service
+	cardService     card.Service
+	txService       transaction.Service
+	categoryService category.Service
+	logger          *logger.Logger
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewSchema(
+	userService user.Service,
+	cardService card.Service,
+	txService transaction.Service,
+	categoryService category.Service,
+	limits Limits,
+	logger *logger.Logger,
+) (*Schema, error) {
+	s := &Schema{
+		limits:          limits,
+		userService:     userService,
+		cardService:     cardService,
+		txService:       txService,
+		categoryService: categoryService,
+		logger:          logger,
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: s.queryType()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs a query as userID. Queries over the depth or complexity limit
+// are rejected before anything is resolved.
+func (s *Schema) Execute(ctx context.Context, userID uuid.UUID, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := s.limits.check(&s.schema, doc, req.OperationName, req.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(&fieldError{message: err.Error(), code: apperror.CodeValidation})}
+	}
+
+	ctx = context.WithValue(ctx, requestKey{}, &request{
+		userID:  userID,
+		loaders: s.newLoaders(userID),
+	})
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+// request is the per-request state resolvers share
+type request struct {
+	userID  uuid.UUID
+	loaders *loaders
+}
+
+type requestKey struct{}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// fieldError is a resolver error as clients see it, with the same code the
+// REST API would send in extensions.code
+type fieldError struct {
+	message string
+	code    apperror.Code
+}
+
+func (e *fieldError) Error() string {
+	return e.message
+}
+
+func (e *fieldError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// fieldError renders err like the error middleware: internal details are
+// logged, never returned
+func (s *Schema) fieldError(ctx context.Context, err error) error {
+	_, body := apperror.NewResponse(err, "")
+	if body.Code == apperror.CodeInternal {
+		s.logger.WithContext(ctx).Error("GraphQL resolver failed", logger.Error(err))
+	}
+	return &fieldError{message: body.Message, code: body.Code}
+}
+
+// resolve adapts a resolver to graphql-go, handing it the request state and
+// rendering its errors
+func (s *Schema) resolve(fn func(p graphql.ResolveParams, r *request) (interface{}, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		result, err := fn(p, requestFrom(p.Context))
+		if err != nil {
+			return nil, s.fieldError(p.Context, err)
+		}
+		return result, nil
+	}
+}
+
+// deferred wraps a loader thunk so graphql-go resolves it after the other
+// fields at the same level have queued their keys, letting them batch
+func deferred[V any](s *Schema, ctx context.Context, thunk dataloader.Thunk[V]) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := thunk()
+		if err != nil {
+			return nil, s.fieldError(ctx, err)
+		}
+		return value, nil
+	}
+}
@@ -0,0 +1,217 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/app/service/user"
+	"pfn-backend/internal/graph"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fakes record who asked and how often; loaders call them from their own
+// goroutines, hence the locks
+
+type fakeUsers struct {
+	user.Service
+	callers []uuid.UUID
+}
+
+func (f *fakeUsers) GetProfile(ctx context.Context, userID uuid.UUID) (*user.ProfileResponse, error) {
+	f.callers = append(f.callers, userID)
+	return &user.ProfileResponse{ID: userID, Email: "ann@example.com"}, nil
+}
+
+type fakeCards struct {
+	card.Service
+	mu      sync.Mutex
+	cards   []card.CardResponse
+	callers []uuid.UUID
+	lists   int
+	singles int
+}
+
+func (f *fakeCards) GetUserCards(ctx context.Context, userID uuid.UUID, filter card.CardListFilter) ([]card.CardResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lists++
+	f.callers = append(f.callers, userID)
+	return append([]card.CardResponse{}, f.cards...), nil
+}
+
+func (f *fakeCards) GetCard(ctx context.Context, cardID int64, userID uuid.UUID) (*card.CardResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.singles++
+	return nil, apperror.NotFound("card not found")
+}
+
+type fakeTransactions struct {
+	transaction.Service
+	page    transaction.TransactionListResponse
+	callers []uuid.UUID
+	filters []transaction.TransactionFilter
+}
+
+func (f *fakeTransactions) GetUserTransactions(ctx context.Context, userID uuid.UUID, filter transaction.TransactionFilter) (*transaction.TransactionListResponse, error) {
+	f.callers = append(f.callers, userID)
+	f.filters = append(f.filters, filter)
+	page := f.page
+	return &page, nil
+}
+
+func (f *fakeTransactions) GetTransaction(ctx context.Context, id int64, userID uuid.UUID) (*transaction.TransactionResponse, error) {
+	return nil, apperror.NotFound("transaction not found")
+}
+
+type fakeCategories struct {
+	category.Service
+	mu         sync.Mutex
+	categories []category.CategoryResponse
+	calls      int
+}
+
+func (f *fakeCategories) ListCategories(ctx context.Context, categoryType *string) ([]category.CategoryResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return append([]category.CategoryResponse{}, f.categories...), nil
+}
+
+type fixture struct {
+	users        *fakeUsers
+	cards        *fakeCards
+	transactions *fakeTransactions
+	categories   *fakeCategories
+	schema       *graph.Schema
+}
+
+func newFixture(t *testing.T, limits graph.Limits) *fixture {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+
+	categoryID := func(id int64) *int64 { return &id }
+	f := &fixture{
+		users: &fakeUsers{},
+		cards: &fakeCards{cards: []card.CardResponse{
+			{ID: 1, AccountType: "Card", Alias: "Daily", Balance: 5000000000},
+			{ID: 2, AccountType: "Savings", Alias: "Rainy day"},
+		}},
+		transactions: &fakeTransactions{page: transaction.TransactionListResponse{
+			Transactions: []transaction.TransactionResponse{
+				{ID: 10, CardID: 1, CategoryID: categoryID(5), TransactionType: "Expense", Amount: 1200},
+				{ID: 11, CardID: 2, CategoryID: categoryID(6), TransactionType: "Income", Amount: 300},
+				{ID: 12, CardID: 1, CategoryID: categoryID(5), TransactionType: "Expense", Amount: 450},
+			},
+			Total: 3,
+			Limit: 20,
+		}},
+		categories: &fakeCategories{categories: []category.CategoryResponse{
+			{ID: 5, Name: "Groceries", CategoryType: "Expense"},
+			{ID: 6, Name: "Salary", CategoryType: "Income"},
+		}},
+	}
+	f.schema, err = graph.NewSchema(f.users, f.cards, f.transactions, f.categories, limits, log)
+	require.NoError(t, err)
+	return f
+}
+
+// run executes a query and returns the result as clients see it
+func (f *fixture) run(t *testing.T, userID uuid.UUID, query string, variables map[string]interface{}) map[string]interface{} {
+	result := f.schema.Execute(context.Background(), userID, graph.Request{Query: query, Variables: variables})
+	body, err := json.Marshal(result)
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &out))
+	return out
+}
+
+func TestExecute_BatchesCardAndCategoryLookups(t *testing.T) {
+	f := newFixture(t, graph.Limits{})
+
+	out := f.run(t, uuid.New(), `{
+		transactions { items { id card { alias balance } category { name } } }
+	}`, nil)
+	require.Nil(t, out["errors"])
+
+	items := out["data"].(map[string]interface{})["transactions"].(map[string]interface{})["items"].([]interface{})
+	require.Len(t, items, 3)
+	first := items[0].(map[string]interface{})
+	assert.Equal(t, "Daily", first["card"].(map[string]interface{})["alias"])
+	assert.Equal(t, float64(5000000000), first["card"].(map[string]interface{})["balance"])
+	assert.Equal(t, "Groceries", first["category"].(map[string]interface{})["name"])
+	assert.Equal(t, "Rainy day", items[1].(map[string]interface{})["card"].(map[string]interface{})["alias"])
+
+	// Three transactions, one lookup each for their cards and categories
+	assert.Equal(t, 1, f.cards.lists)
+	assert.Equal(t, 0, f.cards.singles)
+	assert.Equal(t, 1, f.categories.calls)
+}
+
+func TestExecute_ResolvesAsTheCaller(t *testing.T) {
+	f := newFixture(t, graph.Limits{})
+	caller := uuid.New()
+
+	out := f.run(t, caller, `{
+		me { id email cards { id } }
+		transactions(limit: 5) { total }
+	}`, nil)
+	require.Nil(t, out["errors"])
+
+	me := out["data"].(map[string]interface{})["me"].(map[string]interface{})
+	assert.Equal(t, caller.String(), me["id"])
+	assert.Equal(t, []uuid.UUID{caller}, f.users.callers)
+	assert.Equal(t, []uuid.UUID{caller}, f.cards.callers)
+	assert.Equal(t, []uuid.UUID{caller}, f.transactions.callers)
+	assert.Equal(t, 5, f.transactions.filters[0].Limit)
+}
+
+func TestExecute_RendersServiceErrors(t *testing.T) {
+	f := newFixture(t, graph.Limits{})
+
+	out := f.run(t, uuid.New(), `{ transaction(id: "99") { id } }`, nil)
+
+	errs := out["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	first := errs[0].(map[string]interface{})
+	assert.Equal(t, "transaction not found", first["message"])
+	assert.Equal(t, "not_found", first["extensions"].(map[string]interface{})["code"])
+}
+
+func TestExecute_EnforcesDepthLimit(t *testing.T) {
+	f := newFixture(t, graph.Limits{MaxDepth: 3})
+
+	out := f.run(t, uuid.New(), `{ transactions { items { card { id } } } }`, nil)
+
+	require.NotNil(t, out["errors"])
+	assert.Contains(t, out["errors"].([]interface{})[0].(map[string]interface{})["message"], "depth 4 exceeds the limit of 3")
+	assert.Nil(t, out["data"])
+	assert.Empty(t, f.transactions.callers)
+
+	// Introspection is not counted
+	out = f.run(t, uuid.New(), `{ __schema { types { name fields { name type { name ofType { name } } } } } }`, nil)
+	assert.Nil(t, out["errors"])
+}
+
+func TestExecute_EnforcesComplexityLimit(t *testing.T) {
+	f := newFixture(t, graph.Limits{MaxComplexity: 100})
+	query := `query Page($limit: Int) { transactions(limit: $limit) { items { id amount } } }`
+
+	// 1 for transactions + 1 for items + limit * 2 fields
+	out := f.run(t, uuid.New(), query, map[string]interface{}{"limit": float64(49)})
+	assert.Nil(t, out["errors"])
+
+	out = f.run(t, uuid.New(), query, map[string]interface{}{"limit": float64(50)})
+	require.NotNil(t, out["errors"])
+	assert.Contains(t, out["errors"].([]interface{})[0].(map[string]interface{})["message"], "complexity 102 exceeds the limit of 100")
+	assert.Len(t, f.transactions.callers, 1)
+}
@@ -0,0 +1,577 @@
+package graph
+
+import (
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/app/service/user"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// maxPageSize matches the largest page the REST listing allows
+const maxPageSize = 100
+
+// Amount is a 64-bit integer in minor units; GraphQL's Int is only 32 bits
+var Amount = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Amount",
+	Description: "A 64-bit integer amount in minor units (cents).",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case int64:
+			return v
+		case *int64:
+			if v == nil {
+				return nil
+			}
+			return *v
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case float64:
+			return int64(v)
+		case int:
+			return int64(v)
+		}
+		return nil
+	},
+	ParseLiteral: func(value ast.Value) interface{} {
+		if v, ok := value.(*ast.IntValue); ok {
+			if n, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				return n
+			}
+		}
+		return nil
+	},
+})
+
+func enum(name string, values ...string) *graphql.Enum {
+	config := graphql.EnumValueConfigMap{}
+	for _, value := range values {
+		config[value] = &graphql.EnumValueConfig{Value: value}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{Name: name, Values: config})
+}
+
+var (
+	accountTypeEnum = enum("AccountType",
+		entity.AccountTypeCard, entity.AccountTypeCash, entity.AccountTypeChecking,
+		entity.AccountTypeSavings, entity.AccountTypeCredit, entity.AccountTypeLoan)
+	transactionTypeEnum = enum("TransactionType",
+		entity.TransactionTypeIncome, entity.TransactionTypeExpense,
+		entity.TransactionTypeTransfer, entity.TransactionTypeAdjustment)
+	categoryTypeEnum = enum("CategoryType",
+		entity.TransactionTypeIncome, entity.TransactionTypeExpense, entity.TransactionTypeTransfer)
+)
+
+// prop resolves a field from a source of type T
+func prop[T any](typ graphql.Output, get func(T) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(T)), nil
+		},
+	}
+}
+
+// optional turns the empty string the services use for "not set" into null
+func optional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func optionalID(id *int64) interface{} {
+	if id == nil {
+		return nil
+	}
+	return formatID(*id)
+}
+
+func idArg(args map[string]interface{}, name string) (*int64, error) {
+	raw, ok := args[name].(string)
+	if !ok {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, apperror.Validation("%s must be a numeric ID", name).WithField(name, "must be a numeric ID")
+	}
+	return &id, nil
+}
+
+func stringArg(args map[string]interface{}, name string) *string {
+	if s, ok := args[name].(string); ok {
+		return &s
+	}
+	return nil
+}
+
+func timeArg(args map[string]interface{}, name string) *time.Time {
+	if t, ok := args[name].(time.Time); ok {
+		return &t
+	}
+	return nil
+}
+
+func (s *Schema) categoryType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Category",
+		Fields: graphql.Fields{
+			"id": prop(graphql.NewNonNull(graphql.ID), func(c *category.CategoryResponse) interface{} {
+				return formatID(c.ID)
+			}),
+			"name": prop(graphql.NewNonNull(graphql.String), func(c *category.CategoryResponse) interface{} {
+				return c.Name
+			}),
+			"type": prop(graphql.NewNonNull(categoryTypeEnum), func(c *category.CategoryResponse) interface{} {
+				return c.CategoryType
+			}),
+			"icon": prop(graphql.String, func(c *category.CategoryResponse) interface{} {
+				return optional(c.Icon)
+			}),
+			"isSystem": prop(graphql.NewNonNull(graphql.Boolean), func(c *category.CategoryResponse) interface{} {
+				return c.IsSystem
+			}),
+		},
+	})
+}
+
+func (s *Schema) cardType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Card",
+		Description: "An account; payment cards are accounts of type Card.",
+		Fields: graphql.Fields{
+			"id": prop(graphql.NewNonNull(graphql.ID), func(c *card.CardResponse) interface{} {
+				return formatID(c.ID)
+			}),
+			"accountType": prop(graphql.NewNonNull(accountTypeEnum), func(c *card.CardResponse) interface{} {
+				return c.AccountType
+			}),
+			"alias": prop(graphql.String, func(c *card.CardResponse) interface{} {
+				return optional(c.Alias)
+			}),
+			"cardNumberLast4": prop(graphql.String, func(c *card.CardResponse) interface{} {
+				return optional(c.CardNumberLast4)
+			}),
+			"holderName": prop(graphql.String, func(c *card.CardResponse) interface{} {
+				return optional(c.HolderName)
+			}),
+			"expiryDate": prop(graphql.String, func(c *card.CardResponse) interface{} {
+				return optional(c.ExpiryDate)
+			}),
+			"cardType": prop(graphql.String, func(c *card.CardResponse) interface{} {
+				return optional(c.CardType)
+			}),
+			"balance": prop(graphql.NewNonNull(Amount), func(c *card.CardResponse) interface{} {
+				return c.Balance
+			}),
+			"isLiability": prop(graphql.NewNonNull(graphql.Boolean), func(c *card.CardResponse) interface{} {
+				return c.IsLiability
+			}),
+			"creditLimit": prop(Amount, func(c *card.CardResponse) interface{} {
+				return c.CreditLimit
+			}),
+			"availableCredit": prop(Amount, func(c *card.CardResponse) interface{} {
+				return c.AvailableCredit
+			}),
+			"color": prop(graphql.String, func(c *card.CardResponse) interface{} {
+				return optional(c.Color)
+			}),
+			"isFrozen": prop(graphql.NewNonNull(graphql.Boolean), func(c *card.CardResponse) interface{} {
+				return c.IsFrozen
+			}),
+			"isExpired": prop(graphql.NewNonNull(graphql.Boolean), func(c *card.CardResponse) interface{} {
+				return c.IsExpired
+			}),
+			"householdId": prop(graphql.ID, func(c *card.CardResponse) interface{} {
+				return optionalID(c.HouseholdID)
+			}),
+			"createdAt": prop(graphql.NewNonNull(graphql.DateTime), func(c *card.CardResponse) interface{} {
+				return c.CreatedAt
+			}),
+			"updatedAt": prop(graphql.NewNonNull(graphql.DateTime), func(c *card.CardResponse) interface{} {
+				return c.UpdatedAt
+			}),
+			"version": prop(graphql.NewNonNull(graphql.Int), func(c *card.CardResponse) interface{} {
+				return c.Version
+			}),
+		},
+	})
+}
+
+func (s *Schema) userType(cardType *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": prop(graphql.NewNonNull(graphql.ID), func(u *user.ProfileResponse) interface{} {
+				return u.ID.String()
+			}),
+			"email": prop(graphql.NewNonNull(graphql.String), func(u *user.ProfileResponse) interface{} {
+				return u.Email
+			}),
+			"firstName": prop(graphql.String, func(u *user.ProfileResponse) interface{} {
+				return optional(u.FirstName)
+			}),
+			"lastName": prop(graphql.String, func(u *user.ProfileResponse) interface{} {
+				return optional(u.LastName)
+			}),
+			"fullName": prop(graphql.String, func(u *user.ProfileResponse) interface{} {
+				return optional(u.FullName)
+			}),
+			"role": prop(graphql.NewNonNull(graphql.String), func(u *user.ProfileResponse) interface{} {
+				return u.Role
+			}),
+			"isActive": prop(graphql.NewNonNull(graphql.Boolean), func(u *user.ProfileResponse) interface{} {
+				return u.IsActive
+			}),
+			"cards": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(cardType))),
+				Description: "The user's own accounts followed by those shared with them.",
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					return s.listCards(p, r, card.CardListFilter{})
+				}),
+			},
+		},
+	})
+}
+
+func (s *Schema) transactionType(cardType, categoryType *graphql.Object) *graphql.Object {
+	payeeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Payee",
+		Fields: graphql.Fields{
+			"id": prop(graphql.NewNonNull(graphql.ID), func(p *transaction.PayeeInfo) interface{} {
+				return formatID(p.ID)
+			}),
+			"name": prop(graphql.NewNonNull(graphql.String), func(p *transaction.PayeeInfo) interface{} {
+				return p.Name
+			}),
+		},
+	})
+
+	splitType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Split",
+		Fields: graphql.Fields{
+			"id": prop(graphql.NewNonNull(graphql.ID), func(sp *transaction.SplitResponse) interface{} {
+				return formatID(sp.ID)
+			}),
+			"category": {
+				Type: categoryType,
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					split := p.Source.(*transaction.SplitResponse)
+					return deferred(s, p.Context, r.loaders.categories.Load(p.Context, split.CategoryID)), nil
+				}),
+			},
+			"amount": prop(graphql.NewNonNull(Amount), func(sp *transaction.SplitResponse) interface{} {
+				return sp.Amount
+			}),
+			"note": prop(graphql.String, func(sp *transaction.SplitResponse) interface{} {
+				return optional(sp.Note)
+			}),
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.Fields{
+			"id": prop(graphql.NewNonNull(graphql.ID), func(tx *transaction.TransactionResponse) interface{} {
+				return formatID(tx.ID)
+			}),
+			"card": {
+				Type: graphql.NewNonNull(cardType),
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					tx := p.Source.(*transaction.TransactionResponse)
+					return deferred(s, p.Context, r.loaders.cards.Load(p.Context, tx.CardID)), nil
+				}),
+			},
+			"category": {
+				Type:        categoryType,
+				Description: "Null for uncategorized and split transactions.",
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					tx := p.Source.(*transaction.TransactionResponse)
+					if tx.CategoryID == nil {
+						return nil, nil
+					}
+					return deferred(s, p.Context, r.loaders.categories.Load(p.Context, *tx.CategoryID)), nil
+				}),
+			},
+			"payee": prop(payeeType, func(tx *transaction.TransactionResponse) interface{} {
+				return tx.Payee
+			}),
+			"transactionType": prop(graphql.NewNonNull(transactionTypeEnum), func(tx *transaction.TransactionResponse) interface{} {
+				return tx.TransactionType
+			}),
+			"amount": prop(graphql.NewNonNull(Amount), func(tx *transaction.TransactionResponse) interface{} {
+				return tx.Amount
+			}),
+			"transactionDate": prop(graphql.NewNonNull(graphql.DateTime), func(tx *transaction.TransactionResponse) interface{} {
+				return tx.TransactionDate
+			}),
+			"description": prop(graphql.String, func(tx *transaction.TransactionResponse) interface{} {
+				return optional(tx.Description)
+			}),
+			"tags": prop(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(tx *transaction.TransactionResponse) interface{} {
+				return append([]string{}, tx.Tags...)
+			}),
+			"splits": prop(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(splitType))), func(tx *transaction.TransactionResponse) interface{} {
+				splits := make([]*transaction.SplitResponse, len(tx.Splits))
+				for i := range tx.Splits {
+					splits[i] = &tx.Splits[i]
+				}
+				return splits
+			}),
+			"createdBy": prop(graphql.ID, func(tx *transaction.TransactionResponse) interface{} {
+				if tx.CreatedBy == nil {
+					return nil
+				}
+				return tx.CreatedBy.String()
+			}),
+			"createdAt": prop(graphql.NewNonNull(graphql.DateTime), func(tx *transaction.TransactionResponse) interface{} {
+				return tx.CreatedAt
+			}),
+			"version": prop(graphql.NewNonNull(graphql.Int), func(tx *transaction.TransactionResponse) interface{} {
+				return tx.Version
+			}),
+		},
+	})
+}
+
+func (s *Schema) queryType() *graphql.Object {
+	cardType := s.cardType()
+	categoryType := s.categoryType()
+	transactionType := s.transactionType(cardType, categoryType)
+
+	transactionPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TransactionPage",
+		Fields: graphql.Fields{
+			"items": prop(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))), func(page *transaction.TransactionListResponse) interface{} {
+				items := make([]*transaction.TransactionResponse, len(page.Transactions))
+				for i := range page.Transactions {
+					items[i] = &page.Transactions[i]
+				}
+				return items
+			}),
+			"total": prop(graphql.NewNonNull(graphql.Int), func(page *transaction.TransactionListResponse) interface{} {
+				return page.Total
+			}),
+			"limit": prop(graphql.NewNonNull(graphql.Int), func(page *transaction.TransactionListResponse) interface{} {
+				return page.Limit
+			}),
+			"offset": prop(graphql.NewNonNull(graphql.Int), func(page *transaction.TransactionListResponse) interface{} {
+				return page.Offset
+			}),
+		},
+	})
+
+	statsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stats",
+		Fields: graphql.Fields{
+			"totalIncome": prop(graphql.NewNonNull(Amount), func(st *transaction.StatsResponse) interface{} {
+				return st.TotalIncome
+			}),
+			"totalExpense": prop(graphql.NewNonNull(Amount), func(st *transaction.StatsResponse) interface{} {
+				return st.TotalExpense
+			}),
+			"totalTransfer": prop(graphql.NewNonNull(Amount), func(st *transaction.StatsResponse) interface{} {
+				return st.TotalTransfer
+			}),
+			"netIncome": prop(graphql.NewNonNull(Amount), func(st *transaction.StatsResponse) interface{} {
+				return st.NetIncome
+			}),
+			"count": prop(graphql.NewNonNull(graphql.Int), func(st *transaction.StatsResponse) interface{} {
+				return st.Count
+			}),
+		},
+	})
+
+	categoryStatType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CategoryStat",
+		Fields: graphql.Fields{
+			"category": {
+				Type:        categoryType,
+				Description: "Null for the uncategorized total.",
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					stat := p.Source.(*transaction.CategoryStatResponse)
+					if stat.CategoryID == nil {
+						return nil, nil
+					}
+					return deferred(s, p.Context, r.loaders.categories.Load(p.Context, *stat.CategoryID)), nil
+				}),
+			},
+			"total": prop(graphql.NewNonNull(Amount), func(st *transaction.CategoryStatResponse) interface{} {
+				return st.Total
+			}),
+			"count": prop(graphql.NewNonNull(graphql.Int), func(st *transaction.CategoryStatResponse) interface{} {
+				return st.Count
+			}),
+		},
+	})
+
+	dateRange := graphql.FieldConfigArgument{
+		"startDate": {Type: graphql.DateTime},
+		"endDate":   {Type: graphql.DateTime},
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": {
+				Type: graphql.NewNonNull(s.userType(cardType)),
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					return s.userService.GetProfile(p.Context, r.userID)
+				}),
+			},
+			"cards": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(cardType))),
+				Args: graphql.FieldConfigArgument{
+					"accountType": {Type: accountTypeEnum},
+				},
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					return s.listCards(p, r, card.CardListFilter{AccountType: stringArg(p.Args, "accountType")})
+				}),
+			},
+			"card": {
+				Type: cardType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return deferred(s, p.Context, r.loaders.cards.Load(p.Context, *id)), nil
+				}),
+			},
+			"transactions": {
+				Type: graphql.NewNonNull(transactionPageType),
+				Args: graphql.FieldConfigArgument{
+					"cardId":          {Type: graphql.ID},
+					"transactionType": {Type: transactionTypeEnum},
+					"categoryId":      {Type: graphql.ID},
+					"payeeId":         {Type: graphql.ID},
+					"startDate":       {Type: graphql.DateTime},
+					"endDate":         {Type: graphql.DateTime},
+					"limit":           {Type: graphql.Int, DefaultValue: 20},
+					"offset":          {Type: graphql.Int, DefaultValue: 0},
+				},
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					filter := transaction.TransactionFilter{
+						TransactionType: stringArg(p.Args, "transactionType"),
+						StartDate:       timeArg(p.Args, "startDate"),
+						EndDate:         timeArg(p.Args, "endDate"),
+					}
+					var err error
+					if filter.CardID, err = idArg(p.Args, "cardId"); err != nil {
+						return nil, err
+					}
+					if filter.CategoryID, err = idArg(p.Args, "categoryId"); err != nil {
+						return nil, err
+					}
+					if filter.PayeeID, err = idArg(p.Args, "payeeId"); err != nil {
+						return nil, err
+					}
+
+					filter.Limit, _ = p.Args["limit"].(int)
+					filter.Offset, _ = p.Args["offset"].(int)
+					if filter.Limit < 1 || filter.Limit > maxPageSize {
+						return nil, apperror.Validation("limit must be between 1 and %d", maxPageSize).WithField("limit", "is out of range")
+					}
+					if filter.Offset < 0 {
+						return nil, apperror.Validation("offset must not be negative").WithField("offset", "is out of range")
+					}
+
+					return s.txService.GetUserTransactions(p.Context, r.userID, filter)
+				}),
+			},
+			"transaction": {
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					id, err := idArg(p.Args, "id")
+					if err != nil {
+						return nil, err
+					}
+					return s.txService.GetTransaction(p.Context, *id, r.userID)
+				}),
+			},
+			"categories": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryType))),
+				Args: graphql.FieldConfigArgument{
+					"type": {Type: categoryTypeEnum},
+				},
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					categories, err := s.categoryService.ListCategories(p.Context, stringArg(p.Args, "type"))
+					if err != nil {
+						return nil, err
+					}
+					items := make([]*category.CategoryResponse, len(categories))
+					for i := range categories {
+						items[i] = &categories[i]
+						r.loaders.categories.Prime(p.Context, items[i].ID, items[i])
+					}
+					return items, nil
+				}),
+			},
+			"stats": {
+				Type: graphql.NewNonNull(statsType),
+				Args: dateRange,
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					return s.txService.GetStats(p.Context, r.userID, timeArg(p.Args, "startDate"), timeArg(p.Args, "endDate"))
+				}),
+			},
+			"categoryStats": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryStatType))),
+				Args: graphql.FieldConfigArgument{
+					"transactionType": {Type: categoryTypeEnum},
+					"startDate":       {Type: graphql.DateTime},
+					"endDate":         {Type: graphql.DateTime},
+				},
+				Resolve: s.resolve(func(p graphql.ResolveParams, r *request) (interface{}, error) {
+					stats, err := s.txService.GetCategoryStats(p.Context, r.userID, transaction.CategoryStatsFilter{
+						TransactionType: stringArg(p.Args, "transactionType"),
+						StartDate:       timeArg(p.Args, "startDate"),
+						EndDate:         timeArg(p.Args, "endDate"),
+					})
+					if err != nil {
+						return nil, err
+					}
+					items := make([]*transaction.CategoryStatResponse, len(stats))
+					for i := range stats {
+						items[i] = &stats[i]
+					}
+					return items, nil
+				}),
+			},
+		},
+	})
+}
+
+// listCards lists the user's cards and primes the card loader with them, so
+// transactions resolved later in the query do not fetch them again
+func (s *Schema) listCards(p graphql.ResolveParams, r *request, filter card.CardListFilter) (interface{}, error) {
+	cards, err := s.cardService.GetUserCards(p.Context, r.userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*card.CardResponse, len(cards))
+	for i := range cards {
+		items[i] = &cards[i]
+		r.loaders.cards.Prime(p.Context, items[i].ID, items[i])
+	}
+	return items, nil
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/admin"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdminHandler struct {
+	adminService admin.Service
+}
+
+func NewAdminHandler(adminService admin.Service) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// ListUsers godoc
+// @Summary List and search users (admin, support)
+// @Tags admin
+// @Security Bearer
+// @Produce json
+// @Param q query string false "Matches email, first or last name"
+// @Param role query string false "user, admin or support"
+// @Param is_active query bool false "Filter by active state"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} admin.UserListResponse
+// @Failure 400,401,403 {object} apperror.Response
+// @Router /api/v1/admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var query admin.UserQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.adminService.ListUsers(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUser godoc
+// @Summary Get a user (admin, support)
+// @Tags admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} admin.UserResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	response, err := h.adminService.GetUser(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate a user and sign them out (admin)
+// @Tags admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} admin.UserResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/admin/users/{id}/deactivate [post]
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	actorID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	response, err := h.adminService.DeactivateUser(c.Request.Context(), actorID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate a deactivated user (admin)
+// @Tags admin
+// @Security Bearer
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} admin.UserResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/admin/users/{id}/reactivate [post]
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	actorID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	response, err := h.adminService.ReactivateUser(c.Request.Context(), actorID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetRole godoc
+// @Summary Change a user's role (admin)
+// @Tags admin
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body admin.RoleRequest true "New role"
+// @Success 200 {object} admin.UserResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/admin/users/{id}/role [put]
+func (h *AdminHandler) SetRole(c *gin.Context) {
+	actorID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	var req admin.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.adminService.SetRole(c.Request.Context(), actorID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ForceLogout godoc
+// @Summary End every session of a user (admin)
+// @Tags admin
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/admin/users/{id}/logout [post]
+func (h *AdminHandler) ForceLogout(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	if err := h.adminService.ForceLogout(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCategoryUsage godoc
+// @Summary System category usage across all users (admin, support)
+// @Tags admin
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} admin.CategoryUsageResponse
+// @Failure 401,403 {object} apperror.Response
+// @Router /api/v1/admin/categories/usage [get]
+func (h *AdminHandler) GetCategoryUsage(c *gin.Context) {
+	usage, err := h.adminService.GetCategoryUsage(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyService apikey.Service
+}
+
+func NewAPIKeyHandler(apiKeyService apikey.Service) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateKey godoc
+// @Summary Create a personal API key (the key is only shown here)
+// @Tags api-keys
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body apikey.CreateKeyRequest true "API key data"
+// @Success 201 {object} apikey.KeyResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/api-keys [post]
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req apikey.CreateKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.apiKeyService.CreateKey(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetKeys godoc
+// @Summary List API keys
+// @Tags api-keys
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} apikey.KeyResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/api-keys [get]
+func (h *APIKeyHandler) GetKeys(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	keys, err := h.apiKeyService.GetKeys(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeKey godoc
+// @Summary Revoke an API key
+// @Tags api-keys
+// @Security Bearer
+// @Param id path int true "API key ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid api key ID"))
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), keyID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/audit"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService audit.Service
+}
+
+func NewAuditHandler(auditService audit.Service) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAuditLog godoc
+// @Summary Changes to your cards, transactions and profile, newest first
+// @Tags audit
+// @Security Bearer
+// @Produce json
+// @Param entity_type query string false "card, transaction, category or user"
+// @Param entity_id query string false "Entity ID"
+// @Param action query string false "create, update, delete or restore"
+// @Param start_date query string false "Start date (RFC3339)"
+// @Param end_date query string false "End date (RFC3339)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} audit.AuditListResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/audit [get]
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var query audit.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.auditService.GetUserLog(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAdminAuditLog godoc
+// @Summary Search the audit trail of all users (admin, support)
+// @Tags admin
+// @Security Bearer
+// @Produce json
+// @Param user_id query string false "Owner of the changed data"
+// @Param actor_id query string false "User who made the change"
+// @Param request_id query string false "Request ID (X-Request-ID)"
+// @Param entity_type query string false "card, transaction, category or user"
+// @Param entity_id query string false "Entity ID"
+// @Param action query string false "create, update, delete or restore"
+// @Param start_date query string false "Start date (RFC3339)"
+// @Param end_date query string false "End date (RFC3339)"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} audit.AuditListResponse
+// @Failure 400,401,403 {object} apperror.Response
+// @Router /api/v1/admin/audit [get]
+func (h *AuditHandler) GetAdminAuditLog(c *gin.Context) {
+	var query audit.AdminAuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.auditService.GetLog(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/pkg/apperror"
 	"pfn-backend/internal/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -28,19 +29,19 @@ func NewAuthHandler(authService auth.Service, logger *logger.Logger) *AuthHandle
 // @Produce json
 // @Param request body auth.RegisterRequest true "Registration data"
 // @Success 201 {object} auth.AuthResponse
-// @Failure 400 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Response
 // @Router /api/v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req auth.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.authService.Register(c.Request.Context(), req)
 	if err != nil {
 		h.logger.Error("Registration failed", logger.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -54,19 +55,21 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Produce json
 // @Param request body auth.LoginRequest true "Login credentials"
 // @Success 200 {object} auth.AuthResponse
-// @Failure 400,401 {object} map[string]interface{}
+// @Failure 400,401 {object} apperror.Response
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req auth.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
 
 	response, err := h.authService.Login(c.Request.Context(), req)
 	if err != nil {
 		h.logger.Error("Login failed", logger.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -80,19 +83,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce json
 // @Param request body auth.RefreshTokenRequest true "Refresh token"
 // @Success 200 {object} auth.TokenResponse
-// @Failure 400,401 {object} map[string]interface{}
+// @Failure 400,401 {object} apperror.Response
 // @Router /api/v1/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req auth.RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.authService.RefreshToken(c.Request.Context(), req)
 	if err != nil {
 		h.logger.Error("Token refresh failed", logger.Error(err))
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -104,18 +107,18 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 // @Tags auth
 // @Security Bearer
 // @Success 200 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Response
 // @Router /api/v1/auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	if err := h.authService.Logout(c.Request.Context(), userID); err != nil {
 		h.logger.Error("Logout failed", logger.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+		c.Error(err)
 		return
 	}
 
@@ -129,19 +132,19 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // @Produce json
 // @Param request body auth.ForgotPasswordRequest true "Email"
 // @Success 200 {object} auth.MessageResponse
-// @Failure 400 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Response
 // @Router /api/v1/auth/forgot-password [post]
 func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	var req auth.ForgotPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.authService.ForgotPassword(c.Request.Context(), req)
 	if err != nil {
 		h.logger.Error("Forgot password failed", logger.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process request"})
+		c.Error(err)
 		return
 	}
 
@@ -155,19 +158,19 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 // @Produce json
 // @Param request body auth.ResetPasswordRequest true "Reset token and new password"
 // @Success 200 {object} auth.MessageResponse
-// @Failure 400 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Response
 // @Router /api/v1/auth/reset-password [post]
 func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	var req auth.ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.authService.ResetPassword(c.Request.Context(), req)
 	if err != nil {
 		h.logger.Error("Reset password failed", logger.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -182,24 +185,24 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 // @Produce json
 // @Param request body auth.ChangePasswordRequest true "Old and new password"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400,401 {object} map[string]interface{}
+// @Failure 400,401 {object} apperror.Response
 // @Router /api/v1/auth/change-password [post]
 func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	var req auth.ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	if err := h.authService.ChangePassword(c.Request.Context(), userID, req); err != nil {
 		h.logger.Error("Change password failed", logger.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
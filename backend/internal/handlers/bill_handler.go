@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"pfn-backend/internal/app/service/bill"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BillHandler struct {
+	billService bill.Service
+}
+
+func NewBillHandler(billService bill.Service) *BillHandler {
+	return &BillHandler{
+		billService: billService,
+	}
+}
+
+// CreateBill godoc
+// @Summary Create a bill
+// @Tags bills
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body bill.BillRequest true "Bill data"
+// @Success 201 {object} bill.BillResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/bills [post]
+func (h *BillHandler) CreateBill(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req bill.BillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.billService.CreateBill(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetBills godoc
+// @Summary List bills
+// @Tags bills
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} bill.BillResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/bills [get]
+func (h *BillHandler) GetBills(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	bills, err := h.billService.GetBills(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+// GetUpcoming godoc
+// @Summary Calendar of bills due in the next days, plus overdue bills
+// @Tags bills
+// @Security Bearer
+// @Produce json
+// @Param days query int false "Window in days" default(30)
+// @Success 200 {object} bill.UpcomingResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/bills/upcoming [get]
+func (h *BillHandler) GetUpcoming(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var query bill.UpcomingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.billService.GetUpcoming(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOverdue godoc
+// @Summary List overdue bills
+// @Tags bills
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} bill.BillResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/bills/overdue [get]
+func (h *BillHandler) GetOverdue(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	bills, err := h.billService.GetOverdue(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+// GetBill godoc
+// @Summary Get bill with its payments
+// @Tags bills
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Bill ID"
+// @Success 200 {object} bill.BillResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/bills/{id} [get]
+func (h *BillHandler) GetBill(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	billID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid bill ID"))
+		return
+	}
+
+	response, err := h.billService.GetBill(c.Request.Context(), billID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateBill godoc
+// @Summary Replace bill
+// @Tags bills
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Bill ID"
+// @Param request body bill.BillRequest true "Bill data"
+// @Success 200 {object} bill.BillResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/bills/{id} [put]
+func (h *BillHandler) UpdateBill(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	billID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid bill ID"))
+		return
+	}
+
+	var req bill.BillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.billService.UpdateBill(c.Request.Context(), billID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteBill godoc
+// @Summary Delete bill and its payment history
+// @Tags bills
+// @Security Bearer
+// @Param id path int true "Bill ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/bills/{id} [delete]
+func (h *BillHandler) DeleteBill(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	billID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid bill ID"))
+		return
+	}
+
+	if err := h.billService.DeleteBill(c.Request.Context(), billID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PayBill godoc
+// @Summary Mark the current occurrence paid by linking or creating a transaction
+// @Tags bills
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Bill ID"
+// @Param request body bill.PayBillRequest true "Payment data"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} bill.BillResponse
+// @Failure 400,401,403,404,409 {object} apperror.Response
+// @Router /api/v1/bills/{id}/pay [post]
+func (h *BillHandler) PayBill(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	billID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid bill ID"))
+		return
+	}
+
+	// Every field is optional, so an empty body pays from the bill's card today
+	var req bill.PayBillRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.billService.PayBill(c.Request.Context(), billID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
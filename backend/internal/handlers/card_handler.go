@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/pkg/apperror"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -25,25 +26,59 @@ func NewCardHandler(cardService card.Service) *CardHandler {
 // @Accept json
 // @Produce json
 // @Param request body card.CreateCardRequest true "Card data"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success 201 {object} card.CardResponse
-// @Failure 400,401 {object} map[string]interface{}
+// @Failure 400,401,409 {object} apperror.Response
 // @Router /api/v1/cards [post]
 func (h *CardHandler) CreateCard(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	var req card.CreateCardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.cardService.CreateCard(c.Request.Context(), userID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// CreateAccount godoc
+// @Summary Create a new account (card, cash, checking, savings, credit or loan)
+// @Tags accounts
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body card.CreateAccountRequest true "Account data"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} card.CardResponse
+// @Failure 400,401,409 {object} apperror.Response
+// @Router /api/v1/accounts [post]
+func (h *CardHandler) CreateAccount(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req card.CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.cardService.CreateAccount(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -55,19 +90,60 @@ func (h *CardHandler) CreateCard(c *gin.Context) {
 // @Tags cards
 // @Security Bearer
 // @Produce json
+// @Param account_type query string false "Account type" Enums(Card, Cash, Checking, Savings, Credit, Loan)
+// @Param expiring_within_days query int false "Only cards expiring within the given number of days"
 // @Success 200 {array} card.CardResponse
-// @Failure 401 {object} map[string]interface{}
+// @Failure 400,401 {object} apperror.Response
 // @Router /api/v1/cards [get]
 func (h *CardHandler) GetUserCards(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var filter card.CardListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(apperror.FromBinding(err))
 		return
 	}
+	filter.CardsOnly = true
 
-	cards, err := h.cardService.GetUserCards(c.Request.Context(), userID)
+	cards, err := h.cardService.GetUserCards(c.Request.Context(), userID, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get cards"})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+// GetUserAccounts godoc
+// @Summary Get all user accounts
+// @Tags accounts
+// @Security Bearer
+// @Produce json
+// @Param account_type query string false "Account type" Enums(Card, Cash, Checking, Savings, Credit, Loan)
+// @Param expiring_within_days query int false "Only cards expiring within the given number of days"
+// @Success 200 {array} card.CardResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/accounts [get]
+func (h *CardHandler) GetUserAccounts(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var filter card.CardListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	cards, err := h.cardService.GetUserCards(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -81,27 +157,30 @@ func (h *CardHandler) GetUserCards(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Card ID"
 // @Success 200 {object} card.CardResponse
-// @Failure 400,401,404 {object} map[string]interface{}
+// @Header 200 {string} ETag "Version of the card, for If-Match"
+// @Failure 400,401,403,404 {object} apperror.Response
 // @Router /api/v1/cards/{id} [get]
+// @Router /api/v1/accounts/{id} [get]
 func (h *CardHandler) GetCard(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	cardID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		c.Error(apperror.Validation("invalid card ID"))
 		return
 	}
 
 	card, err := h.cardService.GetCard(c.Request.Context(), cardID, userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, card.Version)
 	c.JSON(http.StatusOK, card)
 }
 
@@ -113,34 +192,44 @@ func (h *CardHandler) GetCard(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Card ID"
 // @Param request body card.UpdateCardRequest true "Card update data"
+// @Param If-Match header string false "ETag the update is based on"
 // @Success 200 {object} card.CardResponse
-// @Failure 400,401,404 {object} map[string]interface{}
+// @Header 200 {string} ETag "New version of the card"
+// @Failure 400,401,403,404,412 {object} apperror.Response
 // @Router /api/v1/cards/{id} [put]
+// @Router /api/v1/accounts/{id} [put]
 func (h *CardHandler) UpdateCard(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	cardID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		c.Error(apperror.Validation("invalid card ID"))
 		return
 	}
 
 	var req card.UpdateCardRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	response, err := h.cardService.UpdateCard(c.Request.Context(), cardID, userID, req)
+	response, err := h.cardService.UpdateCard(c.Request.Context(), cardID, userID, req, version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, response.Version)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -151,24 +240,25 @@ func (h *CardHandler) UpdateCard(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Card ID"
 // @Success 200 {object} card.CardResponse
-// @Failure 400,401,404 {object} map[string]interface{}
+// @Failure 400,401,403,404 {object} apperror.Response
 // @Router /api/v1/cards/{id}/freeze [post]
+// @Router /api/v1/accounts/{id}/freeze [post]
 func (h *CardHandler) ToggleFreeze(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	cardID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		c.Error(apperror.Validation("invalid card ID"))
 		return
 	}
 
 	response, err := h.cardService.ToggleFreeze(c.Request.Context(), cardID, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -180,24 +270,32 @@ func (h *CardHandler) ToggleFreeze(c *gin.Context) {
 // @Tags cards
 // @Security Bearer
 // @Param id path int true "Card ID"
+// @Param If-Match header string false "ETag the deletion is based on"
 // @Success 204
-// @Failure 400,401,404 {object} map[string]interface{}
+// @Failure 400,401,403,404,412 {object} apperror.Response
 // @Router /api/v1/cards/{id} [delete]
+// @Router /api/v1/accounts/{id} [delete]
 func (h *CardHandler) DeleteCard(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	cardID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		c.Error(apperror.Validation("invalid card ID"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.cardService.DeleteCard(c.Request.Context(), cardID, userID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.cardService.DeleteCard(c.Request.Context(), cardID, userID, version); err != nil {
+		c.Error(err)
 		return
 	}
 
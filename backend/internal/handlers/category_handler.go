@@ -24,7 +24,7 @@ func NewCategoryHandler(categoryService category.Service) *CategoryHandler {
 // @Produce json
 // @Param type query string false "Category type" Enums(Income, Expense, Transfer)
 // @Success 200 {array} category.CategoryResponse
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Response
 // @Router /api/v1/categories [get]
 func (h *CategoryHandler) ListCategories(c *gin.Context) {
 	categoryType := c.Query("type")
@@ -36,7 +36,7 @@ func (h *CategoryHandler) ListCategories(c *gin.Context) {
 
 	categories, err := h.categoryService.ListCategories(c.Request.Context(), typePtr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get categories"})
+		c.Error(err)
 		return
 	}
 
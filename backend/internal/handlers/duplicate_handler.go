@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/duplicate"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateHandler struct {
+	duplicateService duplicate.Service
+}
+
+func NewDuplicateHandler(duplicateService duplicate.Service) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+	}
+}
+
+// ListDuplicates godoc
+// @Summary List probable duplicate transactions
+// @Tags transactions
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} duplicate.PairResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/transactions/duplicates [get]
+func (h *DuplicateHandler) ListDuplicates(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	pairs, err := h.duplicateService.ListDuplicates(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pairs)
+}
+
+// MergeDuplicates godoc
+// @Summary Merge a duplicate into the kept transaction and correct the card balance
+// @Tags transactions
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body duplicate.MergeRequest true "Transactions to merge"
+// @Success 200 {object} duplicate.MergeResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/transactions/duplicates/merge [post]
+func (h *DuplicateHandler) MergeDuplicates(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req duplicate.MergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.duplicateService.Merge(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DismissDuplicate godoc
+// @Summary Mark two transactions as not duplicates
+// @Tags transactions
+// @Security Bearer
+// @Accept json
+// @Param request body duplicate.DismissRequest true "Transactions to dismiss"
+// @Success 204
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/transactions/duplicates/dismiss [post]
+func (h *DuplicateHandler) DismissDuplicate(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req duplicate.DismissRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	if err := h.duplicateService.Dismiss(c.Request.Context(), userID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
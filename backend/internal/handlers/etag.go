@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag tags a response with the resource's version
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// ifMatchVersion reads the version a client expects from If-Match. It
+// returns nil when the header is absent or "*", which match any version. A
+// value that is not one of our ETags can never match.
+func ifMatchVersion(c *gin.Context) (*int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	version, err := strconv.ParseInt(strings.Trim(tag, `"`), 10, 64)
+	if err != nil || len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return nil, apperror.Stale("If-Match does not match the current version")
+	}
+	return &version, nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/graph"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLHandler struct {
+	schema *graph.Schema
+}
+
+func NewGraphQLHandler(schema *graph.Schema) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+	}
+}
+
+// Query godoc
+// @Summary Run a GraphQL query
+// @Description Read-only queries over the profile, cards, transactions, categories and stats. Errors in the query are reported in the body's errors with status 200.
+// @Tags graphql
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body graph.Request true "GraphQL query, operation name and variables"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400,401 {object} apperror.Response
+// @Router /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req graph.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.schema.Execute(c.Request.Context(), userID, req))
+}
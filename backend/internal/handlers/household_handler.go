@@ -0,0 +1,492 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/household"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type HouseholdHandler struct {
+	householdService household.Service
+}
+
+func NewHouseholdHandler(householdService household.Service) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdService: householdService,
+	}
+}
+
+// CreateHousehold godoc
+// @Summary Create a household (the caller becomes its owner)
+// @Tags households
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body household.HouseholdRequest true "Household data"
+// @Success 201 {object} household.HouseholdResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/households [post]
+func (h *HouseholdHandler) CreateHousehold(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req household.HouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.householdService.CreateHousehold(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetHouseholds godoc
+// @Summary List households the caller belongs to
+// @Tags households
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} household.HouseholdResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/households [get]
+func (h *HouseholdHandler) GetHouseholds(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	households, err := h.householdService.GetHouseholds(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, households)
+}
+
+// GetHousehold godoc
+// @Summary Get household with members, shared cards and (for owners) pending invitations
+// @Tags households
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Household ID"
+// @Success 200 {object} household.HouseholdResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id} [get]
+func (h *HouseholdHandler) GetHousehold(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	response, err := h.householdService.GetHousehold(c.Request.Context(), householdID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateHousehold godoc
+// @Summary Rename household (owner)
+// @Tags households
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param request body household.HouseholdRequest true "Household data"
+// @Success 200 {object} household.HouseholdResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id} [put]
+func (h *HouseholdHandler) UpdateHousehold(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	var req household.HouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.householdService.UpdateHousehold(c.Request.Context(), householdID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteHousehold godoc
+// @Summary Delete household and unshare its cards (owner)
+// @Tags households
+// @Security Bearer
+// @Param id path int true "Household ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id} [delete]
+func (h *HouseholdHandler) DeleteHousehold(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	if err := h.householdService.DeleteHousehold(c.Request.Context(), householdID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Invite godoc
+// @Summary Invite an email address into the household (owner)
+// @Tags households
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param request body household.InviteRequest true "Invitation data"
+// @Success 201 {object} household.InvitationResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id}/invitations [post]
+func (h *HouseholdHandler) Invite(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	var req household.InviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.householdService.Invite(c.Request.Context(), householdID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke a pending invitation (owner)
+// @Tags households
+// @Security Bearer
+// @Param id path int true "Household ID"
+// @Param invitationId path int true "Invitation ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id}/invitations/{invitationId} [delete]
+func (h *HouseholdHandler) RevokeInvitation(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(c.Param("invitationId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid invitation ID"))
+		return
+	}
+
+	if err := h.householdService.RevokeInvitation(c.Request.Context(), householdID, invitationID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetInvitations godoc
+// @Summary List pending invitations addressed to the caller's email
+// @Tags households
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} household.InvitationResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/households/invitations [get]
+func (h *HouseholdHandler) GetInvitations(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	invitations, err := h.householdService.GetInvitations(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation and join the household
+// @Tags households
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} household.HouseholdResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/invitations/{id}/accept [post]
+func (h *HouseholdHandler) AcceptInvitation(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid invitation ID"))
+		return
+	}
+
+	response, err := h.householdService.AcceptInvitation(c.Request.Context(), invitationID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeclineInvitation godoc
+// @Summary Decline an invitation
+// @Tags households
+// @Security Bearer
+// @Param id path int true "Invitation ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/invitations/{id}/decline [post]
+func (h *HouseholdHandler) DeclineInvitation(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid invitation ID"))
+		return
+	}
+
+	if err := h.householdService.DeclineInvitation(c.Request.Context(), invitationID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateMember godoc
+// @Summary Change a member's role (owner)
+// @Tags households
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param userId path string true "Member user ID"
+// @Param request body household.MemberRoleRequest true "New role"
+// @Success 200 {object} household.HouseholdResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id}/members/{userId} [put]
+func (h *HouseholdHandler) UpdateMember(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	var req household.MemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.householdService.UpdateMember(c.Request.Context(), householdID, memberID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member (owner) or leave the household (self)
+// @Tags households
+// @Security Bearer
+// @Param id path int true "Household ID"
+// @Param userId path string true "Member user ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id}/members/{userId} [delete]
+func (h *HouseholdHandler) RemoveMember(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid user ID"))
+		return
+	}
+
+	if err := h.householdService.RemoveMember(c.Request.Context(), householdID, memberID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ShareCard godoc
+// @Summary Share one of your cards into the household
+// @Tags households
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Household ID"
+// @Param request body household.ShareCardRequest true "Card to share"
+// @Success 200 {object} household.HouseholdResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id}/cards [post]
+func (h *HouseholdHandler) ShareCard(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	var req household.ShareCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.householdService.ShareCard(c.Request.Context(), householdID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UnshareCard godoc
+// @Summary Stop sharing a card (card owner or household owner)
+// @Tags households
+// @Security Bearer
+// @Param id path int true "Household ID"
+// @Param cardId path int true "Card ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/households/{id}/cards/{cardId} [delete]
+func (h *HouseholdHandler) UnshareCard(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	householdID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid household ID"))
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("cardId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid card ID"))
+		return
+	}
+
+	if err := h.householdService.UnshareCard(c.Request.Context(), householdID, cardID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/networth"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NetWorthHandler struct {
+	netWorthService networth.Service
+}
+
+func NewNetWorthHandler(netWorthService networth.Service) *NetWorthHandler {
+	return &NetWorthHandler{
+		netWorthService: netWorthService,
+	}
+}
+
+// GetHistory godoc
+// @Summary Get net worth history
+// @Tags networth
+// @Security Bearer
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} networth.HistoryResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/networth [get]
+func (h *NetWorthHandler) GetHistory(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var query networth.HistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.netWorthService.GetHistory(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Recompute godoc
+// @Summary Rebuild missing net worth snapshots from the transaction ledger
+// @Tags networth
+// @Security Bearer
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} networth.HistoryResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/networth/recompute [post]
+func (h *NetWorthHandler) Recompute(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var query networth.HistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.netWorthService.Recompute(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// TakeSnapshot godoc
+// @Summary Record today's net worth snapshot
+// @Tags networth
+// @Security Bearer
+// @Produce json
+// @Success 201 {object} networth.SnapshotResponse
+// @Failure 401,500 {object} apperror.Response
+// @Router /api/v1/networth/snapshots [post]
+func (h *NetWorthHandler) TakeSnapshot(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	response, err := h.netWorthService.TakeSnapshot(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService notification.Service
+}
+
+func NewNotificationHandler(notificationService notification.Service) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications godoc
+// @Summary List notifications, newest first
+// @Tags notifications
+// @Security Bearer
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param type query string false "Notification type"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} notification.NotificationListResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var filter notification.NotificationFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.notificationService.GetNotifications(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkNotifications godoc
+// @Summary Mark notifications read or unread (all of them when ids is empty)
+// @Tags notifications
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body notification.MarkRequest true "Read state"
+// @Success 200 {object} notification.MarkResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/notifications [patch]
+func (h *NotificationHandler) MarkNotifications(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req notification.MarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.notificationService.MarkNotifications(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkNotification godoc
+// @Summary Mark one notification read or unread
+// @Tags notifications
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Param request body notification.ReadRequest true "Read state"
+// @Success 200 {object} notification.MarkResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/notifications/{id} [patch]
+func (h *NotificationHandler) MarkNotification(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	notificationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid notification ID"))
+		return
+	}
+
+	var req notification.ReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.notificationService.MarkNotification(c.Request.Context(), notificationID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
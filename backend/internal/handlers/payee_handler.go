@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/payee"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PayeeHandler struct {
+	payeeService payee.Service
+}
+
+func NewPayeeHandler(payeeService payee.Service) *PayeeHandler {
+	return &PayeeHandler{
+		payeeService: payeeService,
+	}
+}
+
+// CreatePayee godoc
+// @Summary Create a payee
+// @Tags payees
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body payee.CreatePayeeRequest true "Payee data"
+// @Success 201 {object} payee.PayeeResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/payees [post]
+func (h *PayeeHandler) CreatePayee(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req payee.CreatePayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.payeeService.CreatePayee(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetPayees godoc
+// @Summary List payees
+// @Tags payees
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} payee.PayeeResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/payees [get]
+func (h *PayeeHandler) GetPayees(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payees, err := h.payeeService.GetPayees(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payees)
+}
+
+// GetPayee godoc
+// @Summary Get payee
+// @Tags payees
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Success 200 {object} payee.PayeeResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id} [get]
+func (h *PayeeHandler) GetPayee(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	response, err := h.payeeService.GetPayee(c.Request.Context(), payeeID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdatePayee godoc
+// @Summary Rename payee
+// @Tags payees
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Param request body payee.UpdatePayeeRequest true "Payee update data"
+// @Success 200 {object} payee.PayeeResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id} [put]
+func (h *PayeeHandler) UpdatePayee(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	var req payee.UpdatePayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.payeeService.UpdatePayee(c.Request.Context(), payeeID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeletePayee godoc
+// @Summary Delete payee (its transactions are unlinked)
+// @Tags payees
+// @Security Bearer
+// @Param id path int true "Payee ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id} [delete]
+func (h *PayeeHandler) DeletePayee(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	if err := h.payeeService.DeletePayee(c.Request.Context(), payeeID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddAlias godoc
+// @Summary Add an alias pattern to a payee
+// @Tags payees
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Param request body payee.AliasRequest true "Alias data"
+// @Success 201 {object} payee.PayeeResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id}/aliases [post]
+func (h *PayeeHandler) AddAlias(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	var req payee.AliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.payeeService.AddAlias(c.Request.Context(), payeeID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// DeleteAlias godoc
+// @Summary Remove an alias from a payee
+// @Tags payees
+// @Security Bearer
+// @Param id path int true "Payee ID"
+// @Param aliasId path int true "Alias ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id}/aliases/{aliasId} [delete]
+func (h *PayeeHandler) DeleteAlias(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	aliasID, err := strconv.ParseInt(c.Param("aliasId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid alias ID"))
+		return
+	}
+
+	if err := h.payeeService.DeleteAlias(c.Request.Context(), payeeID, aliasID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MergePayees godoc
+// @Summary Merge payees into this payee
+// @Tags payees
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Target payee ID"
+// @Param request body payee.MergePayeesRequest true "Payees to merge"
+// @Success 200 {object} payee.PayeeResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id}/merge [post]
+func (h *PayeeHandler) MergePayees(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	var req payee.MergePayeesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.payeeService.MergePayees(c.Request.Context(), payeeID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SplitPayee godoc
+// @Summary Split aliases and transactions off into another payee
+// @Tags payees
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Source payee ID"
+// @Param request body payee.SplitPayeeRequest true "Split data"
+// @Success 200 {object} payee.SplitPayeeResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id}/split [post]
+func (h *PayeeHandler) SplitPayee(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	var req payee.SplitPayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.payeeService.SplitPayee(c.Request.Context(), payeeID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetStats godoc
+// @Summary Spending per payee
+// @Tags payees
+// @Security Bearer
+// @Produce json
+// @Param start_date query string false "Start date (RFC3339)"
+// @Param end_date query string false "End date (RFC3339)"
+// @Success 200 {array} payee.PayeeStatResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/payees/stats [get]
+func (h *PayeeHandler) GetStats(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var query payee.PayeeStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	stats, err := h.payeeService.GetStats(c.Request.Context(), userID, nil, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetPayeeStats godoc
+// @Summary Spending for a single payee
+// @Tags payees
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Param start_date query string false "Start date (RFC3339)"
+// @Param end_date query string false "End date (RFC3339)"
+// @Success 200 {object} payee.PayeeStatResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/payees/{id}/stats [get]
+func (h *PayeeHandler) GetPayeeStats(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	payeeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid payee ID"))
+		return
+	}
+
+	var query payee.PayeeStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	stats, err := h.payeeService.GetStats(c.Request.Context(), userID, &payeeID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A payee without transactions in range has empty totals
+	response := payee.PayeeStatResponse{PayeeID: payeeID}
+	if len(stats) > 0 {
+		response = stats[0]
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/reconciliation"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService reconciliation.Service
+}
+
+func NewReconciliationHandler(reconciliationService reconciliation.Service) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// Reconcile godoc
+// @Summary Compare card balances with the transaction ledger
+// @Tags reconciliation
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} reconciliation.ReportResponse
+// @Failure 401,500 {object} apperror.Response
+// @Router /api/v1/reconciliation [get]
+func (h *ReconciliationHandler) Reconcile(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	report, err := h.reconciliationService.Reconcile(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// AdjustBalance godoc
+// @Summary Set a card balance by recording an adjustment transaction
+// @Tags reconciliation
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Card ID"
+// @Param request body reconciliation.AdjustBalanceRequest true "Target balance"
+// @Success 200 {object} reconciliation.AdjustmentResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/reconciliation/cards/{id}/adjust [post]
+func (h *ReconciliationHandler) AdjustBalance(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid card ID"))
+		return
+	}
+
+	var req reconciliation.AdjustBalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.reconciliationService.AdjustBalance(c.Request.Context(), cardID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
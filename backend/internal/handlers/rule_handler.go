@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/rule"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RuleHandler struct {
+	ruleService rule.Service
+}
+
+func NewRuleHandler(ruleService rule.Service) *RuleHandler {
+	return &RuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+// CreateRule godoc
+// @Summary Create a categorization rule
+// @Tags rules
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body rule.RuleRequest true "Rule data"
+// @Success 201 {object} rule.RuleResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/rules [post]
+func (h *RuleHandler) CreateRule(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req rule.RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.ruleService.CreateRule(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetRules godoc
+// @Summary List categorization rules in evaluation order
+// @Tags rules
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} rule.RuleResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/rules [get]
+func (h *RuleHandler) GetRules(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	rules, err := h.ruleService.GetRules(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// GetRule godoc
+// @Summary Get categorization rule
+// @Tags rules
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} rule.RuleResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/rules/{id} [get]
+func (h *RuleHandler) GetRule(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	ruleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid rule ID"))
+		return
+	}
+
+	response, err := h.ruleService.GetRule(c.Request.Context(), ruleID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateRule godoc
+// @Summary Replace a categorization rule
+// @Tags rules
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param request body rule.RuleRequest true "Rule data"
+// @Success 200 {object} rule.RuleResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/rules/{id} [put]
+func (h *RuleHandler) UpdateRule(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	ruleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid rule ID"))
+		return
+	}
+
+	var req rule.RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.ruleService.UpdateRule(c.Request.Context(), ruleID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteRule godoc
+// @Summary Delete categorization rule
+// @Tags rules
+// @Security Bearer
+// @Param id path int true "Rule ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/rules/{id} [delete]
+func (h *RuleHandler) DeleteRule(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	ruleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid rule ID"))
+		return
+	}
+
+	if err := h.ruleService.DeleteRule(c.Request.Context(), ruleID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ApplyRules godoc
+// @Summary Re-apply rules to existing transactions (dry_run previews the changes)
+// @Tags rules
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body rule.ApplyRulesRequest true "Apply options"
+// @Success 200 {object} rule.ApplyRulesResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/rules/apply [post]
+func (h *RuleHandler) ApplyRules(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req rule.ApplyRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.ruleService.ApplyToExisting(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
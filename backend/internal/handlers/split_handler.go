@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/split"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SplitHandler struct {
+	splitService split.Service
+}
+
+func NewSplitHandler(splitService split.Service) *SplitHandler {
+	return &SplitHandler{
+		splitService: splitService,
+	}
+}
+
+// CreateSplit godoc
+// @Summary Record an expense shared with other users or named contacts
+// @Tags splits
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body split.CreateSplitRequest true "Shared expense data"
+// @Success 201 {object} split.SplitResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/splits [post]
+func (h *SplitHandler) CreateSplit(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req split.CreateSplitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.splitService.CreateSplit(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetSplits godoc
+// @Summary List shared expenses you created or share in
+// @Tags splits
+// @Security Bearer
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} split.SplitListResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/splits [get]
+func (h *SplitHandler) GetSplits(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var query split.SplitQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.splitService.GetSplits(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetSplit godoc
+// @Summary Get a shared expense with every participant's share
+// @Tags splits
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Shared expense ID"
+// @Success 200 {object} split.SplitResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/splits/{id} [get]
+func (h *SplitHandler) GetSplit(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	splitID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid split ID"))
+		return
+	}
+
+	response, err := h.splitService.GetSplit(c.Request.Context(), splitID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteSplit godoc
+// @Summary Delete a shared expense (creator only)
+// @Tags splits
+// @Security Bearer
+// @Param id path int true "Shared expense ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/splits/{id} [delete]
+func (h *SplitHandler) DeleteSplit(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	splitID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid split ID"))
+		return
+	}
+
+	if err := h.splitService.DeleteSplit(c.Request.Context(), splitID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBalances godoc
+// @Summary Net balance with each counterparty (positive: they owe you)
+// @Tags splits
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} split.BalanceResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/splits/balances [get]
+func (h *SplitHandler) GetBalances(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	balances, err := h.splitService.GetBalances(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// Settle godoc
+// @Summary Settle up with a counterparty, posting the payment as a transaction
+// @Tags splits
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body split.SettleRequest true "Settlement data"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 201 {object} split.SettlementResponse
+// @Failure 400,401,409 {object} apperror.Response
+// @Router /api/v1/splits/settle [post]
+func (h *SplitHandler) Settle(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req split.SettleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.splitService.Settle(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetContacts godoc
+// @Summary List your split contacts
+// @Tags splits
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} split.ContactResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/splits/contacts [get]
+func (h *SplitHandler) GetContacts(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	contacts, err := h.splitService.GetContacts(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contacts)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/suggestion"
+	"pfn-backend/internal/pkg/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SuggestionHandler struct {
+	suggestionService suggestion.Service
+}
+
+func NewSuggestionHandler(suggestionService suggestion.Service) *SuggestionHandler {
+	return &SuggestionHandler{
+		suggestionService: suggestionService,
+	}
+}
+
+// SuggestCategory godoc
+// @Summary Suggest categories for a description learned from transaction history
+// @Tags transactions
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body suggestion.SuggestCategoryRequest true "Transaction data"
+// @Success 200 {object} suggestion.SuggestCategoryResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/transactions/suggest-category [post]
+func (h *SuggestionHandler) SuggestCategory(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req suggestion.SuggestCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.suggestionService.SuggestCategory(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
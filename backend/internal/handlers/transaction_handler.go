@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,67 +27,174 @@ func NewTransactionHandler(txService transaction.Service) *TransactionHandler {
 // @Accept json
 // @Produce json
 // @Param request body transaction.CreateTransactionRequest true "Transaction data"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success 201 {object} transaction.TransactionResponse
-// @Failure 400,401 {object} map[string]interface{}
+// @Failure 400,401,409 {object} apperror.Response
 // @Router /api/v1/transactions [post]
 func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	var req transaction.CreateTransactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.txService.CreateTransaction(c.Request.Context(), userID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, response)
 }
 
+// BulkTransactions godoc
+// @Summary Create, recategorize, tag or delete many transactions at once
+// @Description Atomic mode (the default) writes nothing unless every item succeeds; best_effort keeps the items that do.
+// @Tags transactions
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body transaction.BulkRequest true "Operation and its targets"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} transaction.BulkResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/transactions/bulk [post]
+func (h *TransactionHandler) BulkTransactions(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req transaction.BulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.txService.Bulk(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetUserTransactions godoc
 // @Summary Get user transactions with filters
 // @Tags transactions
 // @Security Bearer
 // @Produce json
+// @Param card_id query int false "Card ID (includes cards shared through a household)"
 // @Param transaction_type query string false "Transaction type" Enums(Income, Expense, Transfer)
 // @Param category_id query int false "Category ID"
+// @Param payee_id query int false "Payee ID"
 // @Param start_date query string false "Start date (RFC3339)"
 // @Param end_date query string false "End date (RFC3339)"
 // @Param limit query int false "Limit" default(20)
 // @Param offset query int false "Offset" default(0)
 // @Success 200 {object} transaction.TransactionListResponse
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Response
 // @Router /api/v1/transactions [get]
 func (h *TransactionHandler) GetUserTransactions(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	var filter transaction.TransactionFilter
 	if err := c.ShouldBindQuery(&filter); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	response, err := h.txService.GetUserTransactions(c.Request.Context(), userID, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get transactions"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// GetTransaction godoc
+// @Summary Get transaction by ID
+// @Tags transactions
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} transaction.TransactionResponse
+// @Header 200 {string} ETag "Version of the transaction, for If-Match"
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/transactions/{id} [get]
+func (h *TransactionHandler) GetTransaction(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid transaction ID"))
+		return
+	}
+
+	response, err := h.txService.GetTransaction(c.Request.Context(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, response.Version)
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteTransaction godoc
+// @Summary Delete a transaction
+// @Description Moves the transaction to the trash and reverses its effect on the card balance
+// @Tags transactions
+// @Security Bearer
+// @Param id path int true "Transaction ID"
+// @Param If-Match header string false "ETag the deletion is based on"
+// @Success 204
+// @Failure 400,401,403,404,412 {object} apperror.Response
+// @Router /api/v1/transactions/{id} [delete]
+func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid transaction ID"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.txService.DeleteTransaction(c.Request.Context(), id, userID, version); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // GetStats godoc
 // @Summary Get transaction statistics
 // @Tags transactions
@@ -94,12 +203,12 @@ func (h *TransactionHandler) GetUserTransactions(c *gin.Context) {
 // @Param start_date query string false "Start date (RFC3339)"
 // @Param end_date query string false "End date (RFC3339)"
 // @Success 200 {object} transaction.StatsResponse
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Response
 // @Router /api/v1/transactions/stats [get]
 func (h *TransactionHandler) GetStats(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
@@ -108,7 +217,7 @@ func (h *TransactionHandler) GetStats(c *gin.Context) {
 	if startDateStr := c.Query("start_date"); startDateStr != "" {
 		parsed, err := time.Parse(time.RFC3339, startDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format"})
+			c.Error(apperror.Validation("invalid start_date format"))
 			return
 		}
 		startDate = &parsed
@@ -117,7 +226,7 @@ func (h *TransactionHandler) GetStats(c *gin.Context) {
 	if endDateStr := c.Query("end_date"); endDateStr != "" {
 		parsed, err := time.Parse(time.RFC3339, endDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format"})
+			c.Error(apperror.Validation("invalid end_date format"))
 			return
 		}
 		endDate = &parsed
@@ -125,7 +234,40 @@ func (h *TransactionHandler) GetStats(c *gin.Context) {
 
 	stats, err := h.txService.GetStats(c.Request.Context(), userID, startDate, endDate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get statistics"})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetCategoryStats godoc
+// @Summary Get totals per category (split transactions count per split line)
+// @Tags transactions
+// @Security Bearer
+// @Produce json
+// @Param transaction_type query string false "Transaction type" Enums(Income, Expense, Transfer)
+// @Param start_date query string false "Start date (RFC3339)"
+// @Param end_date query string false "End date (RFC3339)"
+// @Success 200 {array} transaction.CategoryStatResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/transactions/stats/categories [get]
+func (h *TransactionHandler) GetCategoryStats(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var filter transaction.CategoryStatsFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	stats, err := h.txService.GetCategoryStats(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/trash"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashService trash.Service
+}
+
+func NewTrashHandler(trashService trash.Service) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetTrash godoc
+// @Summary List deleted cards and transactions that can still be restored
+// @Tags trash
+// @Security Bearer
+// @Produce json
+// @Success 200 {object} trash.TrashResponse
+// @Failure 401,500 {object} apperror.Response
+// @Router /api/v1/trash [get]
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	response, err := h.trashService.GetTrash(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreCard godoc
+// @Summary Restore a deleted card
+// @Description Restores the card together with the transactions deleted along with it
+// @Tags trash
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Card ID"
+// @Success 200 {object} trash.RestoreResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/trash/cards/{id}/restore [post]
+func (h *TrashHandler) RestoreCard(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid card ID"))
+		return
+	}
+
+	response, err := h.trashService.RestoreCard(c.Request.Context(), cardID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreTransaction godoc
+// @Summary Restore a deleted transaction
+// @Description Restores the transaction and re-applies it to the card balance. The card must not be deleted; anyone who may post to it can restore.
+// @Tags trash
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} trash.RestoreResponse
+// @Failure 400,401,403,404,409 {object} apperror.Response
+// @Router /api/v1/trash/transactions/{id}/restore [post]
+func (h *TrashHandler) RestoreTransaction(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid transaction ID"))
+		return
+	}
+
+	response, err := h.trashService.RestoreTransaction(c.Request.Context(), transactionID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
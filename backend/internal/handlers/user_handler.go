@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"pfn-backend/internal/app/service/user"
+	"pfn-backend/internal/pkg/apperror"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,18 +24,18 @@ func NewUserHandler(userService user.Service) *UserHandler {
 // @Security Bearer
 // @Produce json
 // @Success 200 {object} user.ProfileResponse
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Response
 // @Router /api/v1/users/me [get]
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	profile, err := h.userService.GetProfile(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get profile"})
+		c.Error(err)
 		return
 	}
 
@@ -49,24 +50,24 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 // @Produce json
 // @Param request body user.UpdateProfileRequest true "Profile update data"
 // @Success 200 {object} user.ProfileResponse
-// @Failure 400,401 {object} map[string]interface{}
+// @Failure 400,401 {object} apperror.Response
 // @Router /api/v1/users/me [put]
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	userID, exists := getUserIDFromContext(c)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(apperror.Unauthorized("unauthorized"))
 		return
 	}
 
 	var req user.UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
 	profile, err := h.userService.UpdateProfile(c.Request.Context(), userID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
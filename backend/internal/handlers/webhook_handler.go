@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"net/http"
+	"pfn-backend/internal/app/service/webhook"
+	"pfn-backend/internal/pkg/apperror"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService webhook.Service
+}
+
+func NewWebhookHandler(webhookService webhook.Service) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook endpoint (the signing secret is only shown here)
+// @Tags webhooks
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param request body webhook.WebhookRequest true "Webhook data"
+// @Success 201 {object} webhook.WebhookResponse
+// @Failure 400,401 {object} apperror.Response
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req webhook.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.webhookService.CreateWebhook(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetWebhooks godoc
+// @Summary List webhooks
+// @Tags webhooks
+// @Security Bearer
+// @Produce json
+// @Success 200 {array} webhook.WebhookResponse
+// @Failure 401 {object} apperror.Response
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhooks, err := h.webhookService.GetWebhooks(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary Get webhook
+// @Tags webhooks
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} webhook.WebhookResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid webhook ID"))
+		return
+	}
+
+	response, err := h.webhookService.GetWebhook(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateWebhook godoc
+// @Summary Replace webhook URL, events or active state
+// @Tags webhooks
+// @Security Bearer
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param request body webhook.WebhookRequest true "Webhook data"
+// @Success 200 {object} webhook.WebhookResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid webhook ID"))
+		return
+	}
+
+	var req webhook.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.webhookService.UpdateWebhook(c.Request.Context(), webhookID, userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook and its delivery log
+// @Tags webhooks
+// @Security Bearer
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid webhook ID"))
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), webhookID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RotateSecret godoc
+// @Summary Replace the signing secret (the new secret is only shown here)
+// @Tags webhooks
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} webhook.WebhookResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid webhook ID"))
+		return
+	}
+
+	response, err := h.webhookService.RotateSecret(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Ping godoc
+// @Summary Send a test ping event now and return the delivery result
+// @Tags webhooks
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} webhook.DeliveryResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/webhooks/{id}/ping [post]
+func (h *WebhookHandler) Ping(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid webhook ID"))
+		return
+	}
+
+	response, err := h.webhookService.Ping(c.Request.Context(), webhookID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDeliveries godoc
+// @Summary Delivery log of a webhook, newest first
+// @Tags webhooks
+// @Security Bearer
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Delivery status" Enums(pending, succeeded, failed)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} webhook.DeliveryListResponse
+// @Failure 400,401,403,404 {object} apperror.Response
+// @Router /api/v1/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userID, exists := getUserIDFromContext(c)
+	if !exists {
+		c.Error(apperror.Unauthorized("unauthorized"))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.Validation("invalid webhook ID"))
+		return
+	}
+
+	var filter webhook.DeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	response, err := h.webhookService.GetDeliveries(c.Request.Context(), webhookID, userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"net/http"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/pkg/apperror"
 	"pfn-backend/internal/pkg/jwt"
 	"pfn-backend/internal/pkg/logger"
 	"strings"
@@ -11,42 +14,80 @@ import (
 )
 
 type AuthMiddleware struct {
-	jwtManager *jwt.JWTManager
-	logger     *logger.Logger
+	jwtManager    *jwt.JWTManager
+	apiKeyService apikey.Service
+	userRepo      repository.UserRepository
+	logger        *logger.Logger
 }
 
-func NewAuthMiddleware(jwtManager *jwt.JWTManager, logger *logger.Logger) *AuthMiddleware {
+func NewAuthMiddleware(jwtManager *jwt.JWTManager, apiKeyService apikey.Service, userRepo repository.UserRepository, logger *logger.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtManager: jwtManager,
-		logger:     logger,
+		jwtManager:    jwtManager,
+		apiKeyService: apiKeyService,
+		userRepo:      userRepo,
+		logger:        logger,
 	}
 }
 
-// RequireAuth validates JWT and sets user context
+// APIKeyHeader is an alternative to sending an API key as a Bearer token
+const APIKeyHeader = "X-API-Key"
+
+// RequireAuth validates JWT and sets user context. API keys are refused;
+// route groups open to them use RequireScopes instead.
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return m.authenticate("", "")
+}
+
+// RequireScopes is RequireAuth that also accepts API keys. A key needs
+// readScope for GET and HEAD requests and writeScope for everything else.
+func (m *AuthMiddleware) RequireScopes(readScope, writeScope string) gin.HandlerFunc {
+	return m.authenticate(readScope, writeScope)
+}
+
+func (m *AuthMiddleware) authenticate(readScope, writeScope string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
-			c.Abort()
-			return
+		token := c.GetHeader(APIKeyHeader)
+		if token == "" {
+			authHeader := c.GetHeader("Authorization")
+			if authHeader == "" {
+				c.Error(apperror.Unauthorized("missing authorization header"))
+				c.Abort()
+				return
+			}
+
+			// Extract token from "Bearer <token>"
+			parts := strings.Split(authHeader, " ")
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				c.Error(apperror.Unauthorized("invalid authorization format"))
+				c.Abort()
+				return
+			}
+			token = parts[1]
 		}
 
-		// Extract token from "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization format"})
-			c.Abort()
+		if strings.HasPrefix(token, apikey.KeyPrefix) {
+			m.authenticateAPIKey(c, token, readScope, writeScope)
 			return
 		}
 
-		token := parts[1]
-
 		// Validate token
 		claims, err := m.jwtManager.ValidateAccessToken(token)
 		if err != nil {
 			m.logger.Error("Token validation failed", logger.Error(err))
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			c.Error(apperror.Unauthorized("invalid or expired token"))
+			c.Abort()
+			return
+		}
+
+		// Deactivation, forced logouts and role changes apply to tokens already issued
+		user, err := m.userRepo.FindByID(c.Request.Context(), claims.UserID)
+		if err != nil || !user.IsActive {
+			c.Error(apperror.Unauthorized("account is deactivated"))
+			c.Abort()
+			return
+		}
+		if !user.AcceptsToken(claims.IssuedAtTime()) {
+			c.Error(apperror.Unauthorized("session has been signed out"))
 			c.Abort()
 			return
 		}
@@ -54,11 +95,59 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		// Set user context
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("role", user.Role)
 
 		c.Next()
 	}
 }
 
+// RequireRole only lets through sessions whose role is one of roles. It must
+// run after RequireAuth; API keys never carry a role.
+func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		c.Error(apperror.Forbidden("insufficient permissions"))
+		c.Abort()
+	}
+}
+
+func (m *AuthMiddleware) authenticateAPIKey(c *gin.Context, token, readScope, writeScope string) {
+	identity, err := m.apiKeyService.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	required := writeScope
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		required = readScope
+	}
+	if required == "" {
+		c.Error(apperror.Forbidden("API keys cannot access this endpoint"))
+		c.Abort()
+		return
+	}
+	if !identity.HasScope(required) {
+		c.Error(apperror.Forbidden("API key is missing scope %s", required))
+		c.Abort()
+		return
+	}
+
+	// Set user context
+	c.Set("user_id", identity.UserID)
+	c.Set("api_key_id", identity.KeyID)
+
+	c.Next()
+}
+
 // OptionalAuth validates JWT if present but doesn't require it
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,172 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/middleware"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/jwt"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issuedKeys is an apikey.Service that knows a fixed set of raw keys
+type issuedKeys struct {
+	apikey.Service
+	keys map[string]*apikey.Identity
+}
+
+func (s *issuedKeys) Authenticate(ctx context.Context, rawKey string) (*apikey.Identity, error) {
+	identity, ok := s.keys[rawKey]
+	if !ok {
+		return nil, apperror.Unauthorized("invalid api key")
+	}
+	return identity, nil
+}
+
+// storedUsers is a UserRepository over a map
+type storedUsers struct {
+	repository.UserRepository
+	users map[uuid.UUID]*entity.User
+}
+
+func (r *storedUsers) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperror.NotFound("user not found")
+	}
+	return user, nil
+}
+
+func newLogger(t *testing.T) *logger.Logger {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return log
+}
+
+func TestRequireScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	owner := uuid.New()
+	readKey := apikey.KeyPrefix + "read"
+	writeKey := apikey.KeyPrefix + "write"
+	keys := &issuedKeys{keys: map[string]*apikey.Identity{
+		readKey:  {KeyID: 1, UserID: owner, Scopes: []string{entity.ScopeTransactionsRead}},
+		writeKey: {KeyID: 2, UserID: owner, Scopes: []string{entity.ScopeTransactionsRead, entity.ScopeTransactionsWrite}},
+	}}
+
+	log := newLogger(t)
+	auth := middleware.NewAuthMiddleware(nil, keys, nil, log)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware(log))
+	router.Any("/transactions", auth.RequireScopes(entity.ScopeTransactionsRead, entity.ScopeTransactionsWrite), func(c *gin.Context) {
+		userID, _ := middleware.GetUserIDFromContext(c)
+		assert.Equal(t, owner, userID)
+		c.Status(http.StatusNoContent)
+	})
+	router.Any("/reports", auth.RequireScopes(entity.ScopeTransactionsRead, ""), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	router.POST("/suggest-category", auth.RequireScopes(entity.ScopeTransactionsRead, entity.ScopeTransactionsRead), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	router.GET("/profile", auth.RequireAuth(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		key        string
+		wantStatus int
+	}{
+		{"read scope can read", http.MethodGet, "/transactions", "X-API-Key", readKey, http.StatusNoContent},
+		{"read scope can send HEAD", http.MethodHead, "/transactions", "X-API-Key", readKey, http.StatusNoContent},
+		{"read scope cannot write", http.MethodPost, "/transactions", "X-API-Key", readKey, http.StatusForbidden},
+		{"read scope cannot delete", http.MethodDelete, "/transactions", "X-API-Key", readKey, http.StatusForbidden},
+		{"write scope can write", http.MethodPatch, "/transactions", "X-API-Key", writeKey, http.StatusNoContent},
+		{"key as a bearer token", http.MethodPost, "/transactions", "Authorization", "Bearer " + writeKey, http.StatusNoContent},
+		{"unknown key", http.MethodGet, "/transactions", "X-API-Key", apikey.KeyPrefix + "revoked", http.StatusUnauthorized},
+		{"read-only group refuses writes", http.MethodPost, "/reports", "X-API-Key", writeKey, http.StatusForbidden},
+		{"read-only group allows reads", http.MethodGet, "/reports", "X-API-Key", readKey, http.StatusNoContent},
+		{"read scope can post a lookup", http.MethodPost, "/suggest-category", "X-API-Key", readKey, http.StatusNoContent},
+		{"session-only route refuses keys", http.MethodGet, "/profile", "X-API-Key", writeKey, http.StatusForbidden},
+		{"missing credentials", http.MethodGet, "/transactions", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.key)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestRequireAuth_UsesTheStoredUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	active, deactivated, demoted, deleted := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	signedOut, signedInAgain := uuid.New(), uuid.New()
+	before, after := time.Now().Add(-time.Minute), time.Now().Add(time.Second)
+	users := &storedUsers{users: map[uuid.UUID]*entity.User{
+		active:        {ID: active, Role: entity.RoleAdmin, IsActive: true},
+		deactivated:   {ID: deactivated, Role: entity.RoleAdmin, IsActive: false},
+		demoted:       {ID: demoted, Role: entity.RoleUser, IsActive: true},
+		signedOut:     {ID: signedOut, Role: entity.RoleAdmin, IsActive: true, SessionsValidAfter: &after},
+		signedInAgain: {ID: signedInAgain, Role: entity.RoleAdmin, IsActive: true, SessionsValidAfter: &before},
+	}}
+	jwtManager := jwt.NewJWTManager("access-secret-access-secret-0123", "refresh-secret-refresh-secret-01", "test", time.Minute, time.Hour, time.Hour)
+
+	log := newLogger(t)
+	auth := middleware.NewAuthMiddleware(jwtManager, nil, users, log)
+	router := gin.New()
+	router.Use(middleware.ErrorMiddleware(log))
+	router.GET("/admin/users", auth.RequireAuth(), auth.RequireRole(entity.RoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	// Every token was issued while its user was an active admin
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		wantStatus int
+	}{
+		{"active admin", active, http.StatusNoContent},
+		{"deactivated since", deactivated, http.StatusUnauthorized},
+		{"demoted since", demoted, http.StatusForbidden},
+		{"deleted since", deleted, http.StatusUnauthorized},
+		{"signed out since", signedOut, http.StatusUnauthorized},
+		{"signed in after a forced logout", signedInAgain, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwtManager.GenerateAccessToken(tt.userID, "ann@example.com", entity.RoleAdmin)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
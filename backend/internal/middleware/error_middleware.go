@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ErrorMiddleware renders the error a handler or middleware attached with
+// c.Error. Errors from pkg/apperror keep their code and status; anything else
+// is logged and answered with a bare 500.
+func ErrorMiddleware(loggerInstance *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		renderError(c, loggerInstance)
+	}
+}
+
+// renderError writes the last error attached to the context unless a
+// response has already been written
+func renderError(c *gin.Context, loggerInstance *logger.Logger) {
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	if _, ok := apperror.As(err); !ok {
+		loggerInstance.Error("Request failed",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("request_id", c.GetString("request_id")),
+			zap.Error(err),
+		)
+	}
+	abortWithError(c, err)
+}
+
+// abortWithError writes the error envelope and stops the handler chain
+func abortWithError(c *gin.Context, err error) {
+	status, response := apperror.NewResponse(err, c.GetString("request_id"))
+	c.AbortWithStatusJSON(status, response)
+}
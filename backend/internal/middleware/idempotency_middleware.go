@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"pfn-backend/internal/app/service/idempotency"
+	"pfn-backend/internal/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key of a retryable POST
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+type IdempotencyMiddleware struct {
+	idempotencyService idempotency.Service
+	logger             *logger.Logger
+}
+
+func NewIdempotencyMiddleware(idempotencyService idempotency.Service, logger *logger.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		idempotencyService: idempotencyService,
+		logger:             logger,
+	}
+}
+
+// Idempotent makes a route safe to retry. Requests with an Idempotency-Key
+// header run once per user and key; retries with the same body get the
+// stored response, and a different body under the same key is a conflict.
+// Requests without the header pass through. Must run after authentication.
+func (m *IdempotencyMiddleware) Idempotent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		userID, ok := c.Get("user_id")
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		stored, err := m.idempotencyService.Begin(ctx, userID.(uuid.UUID), key, c.Request.Method, c.Request.URL.Path, body)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if stored != nil {
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(stored.StatusCode, "application/json; charset=utf-8", stored.Body)
+			c.Abort()
+			return
+		}
+
+		// The outcome is recorded even when the client has gone away
+		ctx = context.WithoutCancel(ctx)
+		defer func() {
+			// A panic leaves nothing to replay; free the key and let the
+			// recovery middleware answer
+			if r := recover(); r != nil {
+				m.release(ctx, userID.(uuid.UUID), key)
+				panic(r)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Render handler errors here so they are recorded with the response
+		renderError(c, m.logger)
+
+		// Server errors may be transient, so the key is freed for the retry
+		if recorder.Status() >= http.StatusInternalServerError {
+			m.release(ctx, userID.(uuid.UUID), key)
+			return
+		}
+		if err := m.idempotencyService.Complete(ctx, userID.(uuid.UUID), key, recorder.Status(), recorder.body.Bytes()); err != nil {
+			m.logger.Error("Failed to store idempotent response", logger.Error(err))
+		}
+	}
+}
+
+// release frees key so the next request with it runs again
+func (m *IdempotencyMiddleware) release(ctx context.Context, userID uuid.UUID, key string) {
+	if err := m.idempotencyService.Release(ctx, userID, key); err != nil {
+		m.logger.Error("Failed to release idempotency key", logger.Error(err))
+	}
+}
+
+// responseRecorder copies the response body while writing it through
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
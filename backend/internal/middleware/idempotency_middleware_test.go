@@ -0,0 +1,213 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/idempotency"
+	"pfn-backend/internal/middleware"
+	"pfn-backend/internal/pkg/apperror"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeys is an IdempotencyKeyRepository over a map keyed by key. Like the
+// database, it refuses writes once the context is cancelled.
+type memoryKeys struct {
+	repository.IdempotencyKeyRepository
+	nextID int64
+	keys   map[string]*entity.IdempotencyKey
+}
+
+func (r *memoryKeys) Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if _, ok := r.keys[key.Key]; ok {
+		return false, nil
+	}
+	r.nextID++
+	stored := *key
+	stored.ID = r.nextID
+	r.keys[key.Key] = &stored
+	return true, nil
+}
+
+func (r *memoryKeys) FindByKey(ctx context.Context, userID uuid.UUID, key string) (*entity.IdempotencyKey, error) {
+	record, ok := r.keys[key]
+	if !ok || record.UserID != userID {
+		return nil, apperror.NotFound("idempotency key not found")
+	}
+	found := *record
+	return &found, nil
+}
+
+func (r *memoryKeys) Complete(ctx context.Context, id int64, statusCode int, body []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, record := range r.keys {
+		if record.ID == id {
+			record.StatusCode = statusCode
+			record.ResponseBody = body
+		}
+	}
+	return nil
+}
+
+func (r *memoryKeys) Delete(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for key, record := range r.keys {
+		if record.ID == id {
+			delete(r.keys, key)
+		}
+	}
+	return nil
+}
+
+// newIdempotentRouter serves POST /settle through Idempotent as owner. The
+// handler is told how many times it has been called, this call included.
+func newIdempotentRouter(t *testing.T, owner uuid.UUID, handler func(c *gin.Context, call int)) (*gin.Engine, *memoryKeys) {
+	gin.SetMode(gin.TestMode)
+	log := newLogger(t)
+	keys := &memoryKeys{keys: make(map[string]*entity.IdempotencyKey)}
+	idempotent := middleware.NewIdempotencyMiddleware(idempotency.NewService(keys, time.Hour, log), log)
+
+	calls := 0
+	router := gin.New()
+	router.Use(middleware.RecoveryMiddleware(log), middleware.ErrorMiddleware(log))
+	router.POST("/settle", func(c *gin.Context) {
+		c.Set("user_id", owner)
+	}, idempotent.Idempotent(), func(c *gin.Context) {
+		calls++
+		handler(c, calls)
+	})
+	return router, keys
+}
+
+func settle(router *gin.Engine, ctx context.Context, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/settle", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotent(t *testing.T) {
+	created := func(c *gin.Context, call int) {
+		c.JSON(http.StatusCreated, gin.H{"call": call})
+	}
+
+	tests := []struct {
+		name         string
+		handler      func(c *gin.Context, call int)
+		retryBody    string
+		wantStatus   [2]int
+		wantCalls    int
+		wantReplayed bool
+	}{
+		{
+			name:         "retry replays the stored response",
+			handler:      created,
+			retryBody:    `{"amount":5000}`,
+			wantStatus:   [2]int{http.StatusCreated, http.StatusCreated},
+			wantCalls:    1,
+			wantReplayed: true,
+		},
+		{
+			name:       "retry with a different body conflicts",
+			handler:    created,
+			retryBody:  `{"amount":6000}`,
+			wantStatus: [2]int{http.StatusCreated, http.StatusConflict},
+			wantCalls:  1,
+		},
+		{
+			name: "client errors are replayed",
+			handler: func(c *gin.Context, call int) {
+				c.Error(apperror.Validation("counterparty is required"))
+			},
+			retryBody:    `{"amount":5000}`,
+			wantStatus:   [2]int{http.StatusBadRequest, http.StatusBadRequest},
+			wantCalls:    1,
+			wantReplayed: true,
+		},
+		{
+			name: "server error frees the key",
+			handler: func(c *gin.Context, call int) {
+				if call == 1 {
+					c.Error(errors.New("database is down"))
+					return
+				}
+				created(c, call)
+			},
+			retryBody:  `{"amount":5000}`,
+			wantStatus: [2]int{http.StatusInternalServerError, http.StatusCreated},
+			wantCalls:  2,
+		},
+		{
+			name: "panic frees the key",
+			handler: func(c *gin.Context, call int) {
+				if call == 1 {
+					panic("nil map")
+				}
+				created(c, call)
+			},
+			retryBody:  `{"amount":5000}`,
+			wantStatus: [2]int{http.StatusInternalServerError, http.StatusCreated},
+			wantCalls:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			router, _ := newIdempotentRouter(t, uuid.New(), func(c *gin.Context, call int) {
+				calls = call
+				tt.handler(c, call)
+			})
+
+			first := settle(router, context.Background(), "settle-1", `{"amount":5000}`)
+			retry := settle(router, context.Background(), "settle-1", tt.retryBody)
+
+			assert.Equal(t, tt.wantStatus[0], first.Code)
+			assert.Equal(t, tt.wantStatus[1], retry.Code)
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantReplayed {
+				assert.Equal(t, "true", retry.Header().Get(middleware.IdempotentReplayedHeader))
+				assert.Equal(t, first.Body.String(), retry.Body.String())
+			} else {
+				assert.Empty(t, retry.Header().Get(middleware.IdempotentReplayedHeader))
+			}
+		})
+	}
+}
+
+func TestIdempotent_ClientGoneAway(t *testing.T) {
+	owner := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	router, keys := newIdempotentRouter(t, owner, func(c *gin.Context, call int) {
+		// The client disconnects while the handler runs
+		cancel()
+		c.JSON(http.StatusCreated, gin.H{"call": call})
+	})
+
+	settle(router, ctx, "settle-1", `{"amount":5000}`)
+
+	require.Contains(t, keys.keys, "settle-1")
+	assert.Equal(t, http.StatusCreated, keys.keys["settle-1"].StatusCode)
+
+	retry := settle(router, context.Background(), "settle-1", `{"amount":5000}`)
+	assert.Equal(t, "true", retry.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.JSONEq(t, `{"call":1}`, retry.Body.String())
+}
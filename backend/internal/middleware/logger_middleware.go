@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"pfn-backend/internal/pkg/logger"
 	"time"
 
@@ -17,6 +18,12 @@ func LoggerMiddleware(loggerInstance *logger.Logger) gin.HandlerFunc {
 		// Generate request ID
 		requestID := uuid.New().String()
 		c.Set("request_id", requestID)
+		c.Header("X-Request-ID", requestID)
+
+		// Services only see the request context
+		ctx := context.WithValue(c.Request.Context(), logger.RequestIDKey, requestID)
+		ctx = context.WithValue(ctx, logger.ClientIPKey, c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
 
 		// Process request
 		c.Next()
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"net/http"
+	"fmt"
 	"pfn-backend/internal/pkg/logger"
 
 	"github.com/gin-gonic/gin"
@@ -17,11 +17,7 @@ func RecoveryMiddleware(loggerInstance *logger.Logger) gin.HandlerFunc {
 					zap.String("path", c.Request.URL.Path),
 				)
 
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": "internal server error",
-				})
-
-				c.Abort()
+				abortWithError(c, fmt.Errorf("panic: %v", err))
 			}
 		}()
 
@@ -0,0 +1,142 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code classifies an error; it decides the HTTP status and is sent to clients
+type Code string
+
+// Error codes
+const (
+	CodeValidation   Code = "validation_error"
+	CodeUnauthorized Code = "unauthorized"
+	CodeForbidden    Code = "forbidden"
+	CodeNotFound     Code = "not_found"
+	CodeConflict     Code = "conflict"
+	CodeStale        Code = "precondition_failed"
+	CodeInternal     Code = "internal_error"
+)
+
+// Status returns the HTTP status for the code
+func (c Code) Status() int {
+	switch c {
+	case CodeValidation:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeStale:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// FieldError is a problem with one request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error meant to be shown to the client. Errors wrapping it with
+// fmt.Errorf("...: %w") keep its code, so services can add context freely.
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// WithField adds a field error and returns e
+func (e *Error) WithField(field, message string) *Error {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+	return e
+}
+
+func newError(code Code, format string, args []interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound reports a missing resource
+func NotFound(format string, args ...interface{}) *Error {
+	return newError(CodeNotFound, format, args)
+}
+
+// Forbidden reports a resource the caller may not access or change
+func Forbidden(format string, args ...interface{}) *Error {
+	return newError(CodeForbidden, format, args)
+}
+
+// Conflict reports a request that clashes with the current state
+func Conflict(format string, args ...interface{}) *Error {
+	return newError(CodeConflict, format, args)
+}
+
+// Stale reports a write based on a version of the resource that has since
+// changed
+func Stale(format string, args ...interface{}) *Error {
+	return newError(CodeStale, format, args)
+}
+
+// Validation reports invalid input
+func Validation(format string, args ...interface{}) *Error {
+	return newError(CodeValidation, format, args)
+}
+
+// Unauthorized reports missing or invalid credentials
+func Unauthorized(format string, args ...interface{}) *Error {
+	return newError(CodeUnauthorized, format, args)
+}
+
+// As finds the first *Error in err's chain
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// Is reports whether err's chain contains an *Error with the code
+func Is(err error, code Code) bool {
+	appErr, ok := As(err)
+	return ok && appErr.Code == code
+}
+
+// Response is the body of every error response
+type Response struct {
+	Code      Code         `json:"code"`
+	Message   string       `json:"message"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// NewResponse renders err for clients. Errors without a code are internal
+// and their details are never exposed.
+func NewResponse(err error, requestID string) (int, Response) {
+	appErr, ok := As(err)
+	if !ok {
+		return http.StatusInternalServerError, Response{
+			Code:      CodeInternal,
+			Message:   "internal server error",
+			RequestID: requestID,
+		}
+	}
+	return appErr.Code.Status(), Response{
+		Code:      appErr.Code,
+		Message:   err.Error(),
+		Fields:    appErr.Fields,
+		RequestID: requestID,
+	}
+}
@@ -0,0 +1,87 @@
+package apperror_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"pfn-backend/internal/pkg/apperror"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    apperror.Code
+		message string
+	}{
+		{"not found", apperror.NotFound("card not found"), http.StatusNotFound, apperror.CodeNotFound, "card not found"},
+		{"wrapped keeps code and context", fmt.Errorf("failed to get card: %w", apperror.NotFound("card not found")), http.StatusNotFound, apperror.CodeNotFound, "failed to get card: card not found"},
+		{"forbidden", apperror.Forbidden("unauthorized access to card"), http.StatusForbidden, apperror.CodeForbidden, "unauthorized access to card"},
+		{"conflict", apperror.Conflict("card is frozen"), http.StatusConflict, apperror.CodeConflict, "card is frozen"},
+		{"stale", apperror.Stale("card was changed by another request"), http.StatusPreconditionFailed, apperror.CodeStale, "card was changed by another request"},
+		{"validation", apperror.Validation("amount must be positive"), http.StatusBadRequest, apperror.CodeValidation, "amount must be positive"},
+		{"unauthorized", apperror.Unauthorized("invalid api key"), http.StatusUnauthorized, apperror.CodeUnauthorized, "invalid api key"},
+		{"untyped errors stay internal", errors.New("pq: connection refused"), http.StatusInternalServerError, apperror.CodeInternal, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := apperror.NewResponse(tt.err, "req-1")
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Equal(t, tt.message, resp.Message)
+			assert.Equal(t, "req-1", resp.RequestID)
+		})
+	}
+}
+
+type createRequest struct {
+	Amount int64  `json:"amount" binding:"required,min=1"`
+	Type   string `json:"transaction_type" binding:"required,oneof=Income Expense"`
+	Splits []struct {
+		Note string `json:"note" binding:"max=3"`
+	} `json:"splits" binding:"dive"`
+}
+
+func TestFromBinding_FieldErrors(t *testing.T) {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(apperror.FieldName)
+
+	req := createRequest{Type: "Gift"}
+	req.Splits = append(req.Splits, struct {
+		Note string `json:"note" binding:"max=3"`
+	}{Note: "groceries"})
+
+	err := apperror.FromBinding(validate.Struct(req))
+	require.Equal(t, apperror.CodeValidation, err.Code)
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "amount", Message: "is required"},
+		{Field: "transaction_type", Message: "must be one of: Income, Expense"},
+		{Field: "splits[0].note", Message: "must have at most 3 characters or items"},
+	}, err.Fields)
+}
+
+func TestFromBinding_MalformedBody(t *testing.T) {
+	var target createRequest
+	decodeErr := jsonDecode(`{"amount": "ten"}`, &target)
+	err := apperror.FromBinding(decodeErr)
+	require.Len(t, err.Fields, 1)
+	assert.Equal(t, "amount", err.Fields[0].Field)
+
+	err = apperror.FromBinding(jsonDecode(`{"amount":`, &target))
+	assert.Equal(t, "malformed JSON body", err.Message)
+	assert.Empty(t, err.Fields)
+}
+
+func jsonDecode(body string, target interface{}) error {
+	return json.NewDecoder(strings.NewReader(body)).Decode(target)
+}
@@ -0,0 +1,114 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FromBinding turns a request binding error into a validation error with one
+// field error per failed rule
+func FromBinding(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		appErr := Validation("request validation failed")
+		for _, fieldErr := range validationErrs {
+			appErr.WithField(fieldPath(fieldErr), ruleMessage(fieldErr))
+		}
+		return appErr
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return Validation("request validation failed").
+			WithField(typeErr.Field, fmt.Sprintf("must be a %s", typeErr.Type.String()))
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return Validation("malformed JSON body")
+	}
+	if errors.Is(err, io.EOF) {
+		return Validation("request body is required")
+	}
+
+	return Validation("%s", err.Error())
+}
+
+// FieldName names struct fields in validation errors the way clients send
+// them: the json tag, else the form or uri tag, else the Go name
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldPath drops the request struct name from the namespace, so nested
+// fields read "card.card_number" and slice items "splits[1].amount"
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldErr.Field()
+}
+
+func ruleMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(param, " ", ", ")
+	case "len":
+		return "must have length " + param
+	case "min":
+		if isNumber(fieldErr.Kind()) {
+			return "must be at least " + param
+		}
+		return "must have at least " + param + " characters or items"
+	case "max":
+		if isNumber(fieldErr.Kind()) {
+			return "must be at most " + param
+		}
+		return "must have at most " + param + " characters or items"
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be at most " + param
+	default:
+		return fmt.Sprintf("failed the %q rule", fieldErr.Tag())
+	}
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package cardutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Brand identifies a card network
+type Brand string
+
+// Supported card brands
+const (
+	BrandVisa       Brand = "Visa"
+	BrandMasterCard Brand = "MasterCard"
+	BrandAmex       Brand = "Amex"
+	BrandJCB        Brand = "JCB"
+	BrandUnionPay   Brand = "UnionPay"
+	BrandDiscover   Brand = "Discover"
+)
+
+// ExpiryLayout is the expected expiry date format (MM/YYYY)
+const ExpiryLayout = "01/2006"
+
+// brandRule describes an IIN range and the valid lengths for a brand
+type brandRule struct {
+	brand   Brand
+	low     int
+	high    int
+	digits  int // number of leading digits compared against low/high
+	lengths []int
+}
+
+// Order matters: Discover co-branded ranges overlap with UnionPay's 62 prefix.
+var brandRules = []brandRule{
+	{BrandAmex, 34, 34, 2, []int{15}},
+	{BrandAmex, 37, 37, 2, []int{15}},
+	{BrandDiscover, 6011, 6011, 4, []int{16, 17, 18, 19}},
+	{BrandDiscover, 622126, 622925, 6, []int{16, 17, 18, 19}},
+	{BrandDiscover, 644, 649, 3, []int{16, 17, 18, 19}},
+	{BrandDiscover, 65, 65, 2, []int{16, 17, 18, 19}},
+	{BrandUnionPay, 62, 62, 2, []int{16, 17, 18, 19}},
+	{BrandJCB, 3528, 3589, 4, []int{16, 17, 18, 19}},
+	{BrandMasterCard, 51, 55, 2, []int{16}},
+	{BrandMasterCard, 2221, 2720, 4, []int{16}},
+	{BrandVisa, 4, 4, 1, []int{13, 16, 19}},
+}
+
+// Normalize strips spaces and dashes from a card number
+func Normalize(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// ValidLuhn checks a card number against the Luhn checksum
+func ValidLuhn(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// DetectBrand returns the brand for a card number based on its IIN range and length
+func DetectBrand(number string) (Brand, error) {
+	for _, rule := range brandRules {
+		if len(number) < rule.digits {
+			continue
+		}
+
+		prefix, err := strconv.Atoi(number[:rule.digits])
+		if err != nil {
+			return "", fmt.Errorf("card number must contain only digits")
+		}
+
+		if prefix < rule.low || prefix > rule.high {
+			continue
+		}
+
+		for _, length := range rule.lengths {
+			if len(number) == length {
+				return rule.brand, nil
+			}
+		}
+		return "", fmt.Errorf("invalid card number length for %s", rule.brand)
+	}
+
+	return "", fmt.Errorf("unsupported card brand")
+}
+
+// Validate normalizes a card number, checks its checksum and detects its brand
+func Validate(number string) (string, Brand, error) {
+	normalized := Normalize(number)
+
+	if !ValidLuhn(normalized) {
+		return "", "", fmt.Errorf("invalid card number")
+	}
+
+	brand, err := DetectBrand(normalized)
+	if err != nil {
+		return "", "", err
+	}
+
+	return normalized, brand, nil
+}
+
+// ParseExpiry parses an MM/YYYY expiry date. The returned time is the first
+// instant after the card stops being valid (the start of the following month).
+func ParseExpiry(expiry string) (time.Time, error) {
+	parsed, err := time.Parse(ExpiryLayout, expiry)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expiry date must be in MM/YYYY format")
+	}
+
+	return parsed.AddDate(0, 1, 0), nil
+}
+
+// IsExpired reports whether a card with the given expiry is expired at now
+func IsExpired(expiry string, now time.Time) bool {
+	end, err := ParseExpiry(expiry)
+	if err != nil {
+		return false
+	}
+	return !now.Before(end)
+}
+
+// ExpiresWithin reports whether a card is still valid at now but expires within d
+func ExpiresWithin(expiry string, now time.Time, d time.Duration) bool {
+	end, err := ParseExpiry(expiry)
+	if err != nil {
+		return false
+	}
+	return now.Before(end) && end.Sub(now) <= d
+}
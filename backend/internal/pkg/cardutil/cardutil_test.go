@@ -0,0 +1,109 @@
+package cardutil_test
+
+import (
+	"pfn-backend/internal/pkg/cardutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidLuhn(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   bool
+	}{
+		{"valid visa", "4111111111111111", true},
+		{"valid amex", "378282246310005", true},
+		{"invalid checksum", "4111111111111112", false},
+		{"non-digit", "4111a11111111111", false},
+		{"too short", "4", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cardutil.ValidLuhn(tt.number))
+		})
+	}
+}
+
+func TestDetectBrand(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		want    cardutil.Brand
+		wantErr bool
+	}{
+		{"visa 16", "4111111111111111", cardutil.BrandVisa, false},
+		{"visa 13", "4222222222222", cardutil.BrandVisa, false},
+		{"mastercard 5x", "5555555555554444", cardutil.BrandMasterCard, false},
+		{"mastercard 2x", "2223003122003222", cardutil.BrandMasterCard, false},
+		{"amex", "378282246310005", cardutil.BrandAmex, false},
+		{"jcb", "3530111333300000", cardutil.BrandJCB, false},
+		{"discover", "6011111111111117", cardutil.BrandDiscover, false},
+		{"discover co-branded 622", "6221260000000000", cardutil.BrandDiscover, false},
+		{"unionpay", "6200000000000005", cardutil.BrandUnionPay, false},
+		{"unionpay 19", "6212345678901234567", cardutil.BrandUnionPay, false},
+		{"wrong length for amex", "3782822463100050", "", true},
+		{"unknown prefix", "9111111111111111", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brand, err := cardutil.DetectBrand(tt.number)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, brand)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("normalizes spaces and dashes", func(t *testing.T) {
+		number, brand, err := cardutil.Validate("4111 1111-1111 1111")
+
+		require.NoError(t, err)
+		assert.Equal(t, "4111111111111111", number)
+		assert.Equal(t, cardutil.BrandVisa, brand)
+	})
+
+	t.Run("rejects bad checksum", func(t *testing.T) {
+		_, _, err := cardutil.Validate("5555555555554445")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("parses MM/YYYY", func(t *testing.T) {
+		end, err := cardutil.ParseExpiry("03/2025")
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), end)
+	})
+
+	t.Run("rejects invalid month", func(t *testing.T) {
+		_, err := cardutil.ParseExpiry("13/2025")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("card valid through end of expiry month", func(t *testing.T) {
+		assert.False(t, cardutil.IsExpired("03/2025", now))
+		assert.True(t, cardutil.IsExpired("02/2025", now))
+	})
+
+	t.Run("expires within window", func(t *testing.T) {
+		assert.True(t, cardutil.ExpiresWithin("04/2025", now, 60*24*time.Hour))
+		assert.False(t, cardutil.ExpiresWithin("12/2025", now, 60*24*time.Hour))
+		assert.False(t, cardutil.ExpiresWithin("02/2025", now, 60*24*time.Hour))
+	})
+}
@@ -0,0 +1,184 @@
+package classifier
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NaiveBayes is a multinomial naive Bayes text classifier with Laplace
+// smoothing. It is safe for concurrent use and can be trained incrementally.
+type NaiveBayes[L comparable] struct {
+	mu          sync.RWMutex
+	docs        int
+	docCounts   map[L]int
+	tokenCounts map[L]map[string]int
+	tokenTotals map[L]int
+	vocabulary  map[string]int
+}
+
+// Prediction is a label with its posterior probability
+type Prediction[L comparable] struct {
+	Label       L
+	Probability float64
+}
+
+// New creates an empty classifier
+func New[L comparable]() *NaiveBayes[L] {
+	return &NaiveBayes[L]{
+		docCounts:   make(map[L]int),
+		tokenCounts: make(map[L]map[string]int),
+		tokenTotals: make(map[L]int),
+		vocabulary:  make(map[string]int),
+	}
+}
+
+// Learn adds a labelled document
+func (nb *NaiveBayes[L]) Learn(tokens []string, label L) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	nb.mu.Lock()
+	defer nb.mu.Unlock()
+
+	nb.docs++
+	nb.docCounts[label]++
+	counts, ok := nb.tokenCounts[label]
+	if !ok {
+		counts = make(map[string]int)
+		nb.tokenCounts[label] = counts
+	}
+	for _, token := range tokens {
+		counts[token]++
+		nb.tokenTotals[label]++
+		nb.vocabulary[token]++
+	}
+}
+
+// Unlearn removes a document previously added with Learn
+func (nb *NaiveBayes[L]) Unlearn(tokens []string, label L) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	nb.mu.Lock()
+	defer nb.mu.Unlock()
+
+	if nb.docCounts[label] == 0 {
+		return
+	}
+
+	nb.docs--
+	nb.docCounts[label]--
+	counts := nb.tokenCounts[label]
+	for _, token := range tokens {
+		if counts[token] == 0 {
+			continue
+		}
+		counts[token]--
+		nb.tokenTotals[label]--
+		if counts[token] == 0 {
+			delete(counts, token)
+		}
+		nb.vocabulary[token]--
+		if nb.vocabulary[token] <= 0 {
+			delete(nb.vocabulary, token)
+		}
+	}
+
+	if nb.docCounts[label] == 0 {
+		delete(nb.docCounts, label)
+		delete(nb.tokenCounts, label)
+		delete(nb.tokenTotals, label)
+	}
+}
+
+// Documents returns the number of documents learned
+func (nb *NaiveBayes[L]) Documents() int {
+	nb.mu.RLock()
+	defer nb.mu.RUnlock()
+	return nb.docs
+}
+
+// Predict returns labels ordered by descending probability. Labels for which
+// allow returns false are skipped; pass nil to consider every label.
+func (nb *NaiveBayes[L]) Predict(tokens []string, allow func(L) bool) []Prediction[L] {
+	nb.mu.RLock()
+	defer nb.mu.RUnlock()
+
+	if nb.docs == 0 || len(tokens) == 0 {
+		return nil
+	}
+
+	vocabularySize := float64(len(nb.vocabulary))
+	labels := make([]L, 0, len(nb.docCounts))
+	scores := make([]float64, 0, len(nb.docCounts))
+	for label, docCount := range nb.docCounts {
+		if allow != nil && !allow(label) {
+			continue
+		}
+
+		score := math.Log(float64(docCount) / float64(nb.docs))
+		denominator := float64(nb.tokenTotals[label]) + vocabularySize
+		for _, token := range tokens {
+			score += math.Log((float64(nb.tokenCounts[label][token]) + 1) / denominator)
+		}
+
+		labels = append(labels, label)
+		scores = append(scores, score)
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	// Normalize log scores into probabilities
+	maxScore := scores[0]
+	for _, score := range scores[1:] {
+		maxScore = math.Max(maxScore, score)
+	}
+	var sum float64
+	for i := range scores {
+		scores[i] = math.Exp(scores[i] - maxScore)
+		sum += scores[i]
+	}
+
+	predictions := make([]Prediction[L], len(labels))
+	for i, label := range labels {
+		predictions[i] = Prediction[L]{Label: label, Probability: scores[i] / sum}
+	}
+	sort.SliceStable(predictions, func(i, j int) bool {
+		return predictions[i].Probability > predictions[j].Probability
+	})
+
+	return predictions
+}
+
+// Tokenize splits text into lowercase word tokens. Single characters and
+// purely numeric tokens (reference numbers, dates) are dropped.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || isNumeric(field) {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
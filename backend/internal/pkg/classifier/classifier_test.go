@@ -0,0 +1,58 @@
+package classifier_test
+
+import (
+	"pfn-backend/internal/pkg/classifier"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := classifier.Tokenize("AMZN Mktp US*2K3 #12345 - a")
+
+	assert.Equal(t, []string{"amzn", "mktp", "us", "2k3"}, tokens)
+}
+
+func TestNaiveBayes_Predict(t *testing.T) {
+	nb := classifier.New[int64]()
+	nb.Learn(classifier.Tokenize("Starbucks coffee"), 1)
+	nb.Learn(classifier.Tokenize("Highlands coffee"), 1)
+	nb.Learn(classifier.Tokenize("Shell fuel station"), 2)
+	nb.Learn(classifier.Tokenize("Petrolimex fuel"), 2)
+
+	t.Run("ranks the most likely label first", func(t *testing.T) {
+		predictions := nb.Predict(classifier.Tokenize("coffee shop"), nil)
+
+		require.Len(t, predictions, 2)
+		assert.Equal(t, int64(1), predictions[0].Label)
+		assert.Greater(t, predictions[0].Probability, predictions[1].Probability)
+		assert.InDelta(t, 1.0, predictions[0].Probability+predictions[1].Probability, 1e-9)
+	})
+
+	t.Run("respects the label filter", func(t *testing.T) {
+		predictions := nb.Predict(classifier.Tokenize("coffee shop"), func(label int64) bool {
+			return label == 2
+		})
+
+		require.Len(t, predictions, 1)
+		assert.Equal(t, int64(2), predictions[0].Label)
+	})
+
+	t.Run("unlearn removes a label", func(t *testing.T) {
+		nb.Unlearn(classifier.Tokenize("Shell fuel station"), 2)
+		nb.Unlearn(classifier.Tokenize("Petrolimex fuel"), 2)
+
+		predictions := nb.Predict(classifier.Tokenize("fuel"), nil)
+
+		require.Len(t, predictions, 1)
+		assert.Equal(t, int64(1), predictions[0].Label)
+		assert.Equal(t, 2, nb.Documents())
+	})
+}
+
+func TestNaiveBayes_Empty(t *testing.T) {
+	nb := classifier.New[int64]()
+
+	assert.Empty(t, nb.Predict([]string{"coffee"}, nil))
+}
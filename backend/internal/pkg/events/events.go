@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"pfn-backend/internal/pkg/logger"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types published by the application services
+const (
+	UserLoggedIn        = "auth.login"
+	PasswordChanged     = "auth.password_changed"
+	CardFrozen          = "card.frozen"
+	CardUnfrozen        = "card.unfrozen"
+	FrozenCardAttempt   = "card.frozen_attempt"
+	TransactionCreated  = "transaction.created"
+	TransactionUpdated  = "transaction.updated"
+	TransactionDeleted  = "transaction.deleted"
+	TransactionRestored = "transaction.restored"
+	// BudgetExceeded is reserved for budgets; nothing publishes it yet
+	BudgetExceeded = "budget.exceeded"
+)
+
+// All subscribes a handler to every event type
+const All = "*"
+
+// Event is something that happened to a user's data
+type Event struct {
+	Type       string                 `json:"type"`
+	UserID     uuid.UUID              `json:"user_id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+// New creates an event that occurred now
+func New(eventType string, userID uuid.UUID, data map[string]interface{}) Event {
+	return Event{
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+}
+
+// Handler reacts to a published event
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher lets services emit events without knowing who consumes them
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Bus is an in-process Publisher that fans events out to subscribed handlers.
+// Handlers run synchronously in subscription order; a failing or panicking
+// handler is logged and never affects the publisher or other handlers.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	logger   *logger.Logger
+}
+
+// NewBus creates an event bus with no subscribers
+func NewBus(logger *logger.Logger) *Bus {
+	return &Bus{
+		handlers: make(map[string][]Handler),
+		logger:   logger,
+	}
+}
+
+// Subscribe registers a handler for an event type, or for every type with All
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish delivers an event to its subscribers. Delivery outlives the
+// caller's cancellation so a finished request still records its events.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[event.Type])+len(b.handlers[All]))
+	handlers = append(handlers, b.handlers[event.Type]...)
+	handlers = append(handlers, b.handlers[All]...)
+	b.mu.RUnlock()
+
+	ctx = context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		b.deliver(ctx, handler, event)
+	}
+}
+
+func (b *Bus) deliver(ctx context.Context, handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked", logger.String("event", event.Type), logger.Any("panic", r))
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		b.logger.Error("Event handler failed", logger.String("event", event.Type), logger.Error(err))
+	}
+}
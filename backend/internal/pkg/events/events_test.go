@@ -0,0 +1,75 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBus(t *testing.T) *events.Bus {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+	return events.NewBus(log)
+}
+
+func TestBus_Publish(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("delivers to type and wildcard subscribers", func(t *testing.T) {
+		bus := newBus(t)
+		var got []string
+		bus.Subscribe(events.CardFrozen, func(ctx context.Context, e events.Event) error {
+			got = append(got, "typed:"+e.Type)
+			return nil
+		})
+		bus.Subscribe(events.All, func(ctx context.Context, e events.Event) error {
+			got = append(got, "all:"+e.Type)
+			return nil
+		})
+
+		bus.Publish(context.Background(), events.New(events.CardFrozen, userID, nil))
+		bus.Publish(context.Background(), events.New(events.UserLoggedIn, userID, nil))
+
+		assert.Equal(t, []string{"typed:card.frozen", "all:card.frozen", "all:auth.login"}, got)
+	})
+
+	t.Run("failing handler does not stop the others", func(t *testing.T) {
+		bus := newBus(t)
+		calls := 0
+		bus.Subscribe(events.All, func(ctx context.Context, e events.Event) error {
+			return errors.New("boom")
+		})
+		bus.Subscribe(events.All, func(ctx context.Context, e events.Event) error {
+			panic("boom")
+		})
+		bus.Subscribe(events.All, func(ctx context.Context, e events.Event) error {
+			calls++
+			return nil
+		})
+
+		bus.Publish(context.Background(), events.New(events.TransactionCreated, userID, nil))
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("handlers outlive a cancelled caller", func(t *testing.T) {
+		bus := newBus(t)
+		var ctxErr error
+		bus.Subscribe(events.All, func(ctx context.Context, e events.Event) error {
+			ctxErr = ctx.Err()
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		bus.Publish(ctx, events.Event{Type: events.PasswordChanged, UserID: userID})
+
+		assert.NoError(t, ctxErr)
+	})
+}
@@ -11,10 +11,20 @@ import (
 type Claims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
-	Type   string    `json:"type"` // access, refresh, reset
+	Role   string    `json:"role,omitempty"` // access tokens only
+	Type   string    `json:"type"`           // access, refresh, reset
 	jwt.RegisteredClaims
 }
 
+// IssuedAtTime returns when the token was issued, or the zero time when it
+// does not say
+func (c *Claims) IssuedAtTime() time.Time {
+	if c.IssuedAt == nil {
+		return time.Time{}
+	}
+	return c.IssuedAt.Time
+}
+
 type TokenPair struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
@@ -41,12 +51,13 @@ func NewJWTManager(accessSecret, refreshSecret, issuer string, accessExpiry, ref
 	}
 }
 
-// GenerateAccessToken creates a new access token
-func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email string) (string, error) {
+// GenerateAccessToken creates a new access token carrying the user's role
+func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email, role string) (string, error) {
 	now := time.Now()
 	claims := Claims{
 		UserID: userID,
 		Email:  email,
+		Role:   role,
 		Type:   "access",
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    m.issuer,
@@ -116,9 +127,10 @@ func (m *JWTManager) GenerateResetToken(userID uuid.UUID, email string) (string,
 	return tokenString, nil
 }
 
-// GenerateTokenPair creates both access and refresh tokens
-func (m *JWTManager) GenerateTokenPair(userID uuid.UUID, email string) (*TokenPair, error) {
-	accessToken, err := m.GenerateAccessToken(userID, email)
+// GenerateTokenPair creates both access and refresh tokens. The role is
+// only embedded in the access token; refreshing re-reads it from the user.
+func (m *JWTManager) GenerateTokenPair(userID uuid.UUID, email, role string) (*TokenPair, error) {
+	accessToken, err := m.GenerateAccessToken(userID, email, role)
 	if err != nil {
 		return nil, err
 	}
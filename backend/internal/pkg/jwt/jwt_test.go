@@ -27,14 +27,14 @@ func TestJWTManager_GenerateAccessToken(t *testing.T) {
 	email := "test@example.com"
 
 	t.Run("generates valid access token", func(t *testing.T) {
-		token, err := manager.GenerateAccessToken(userID, email)
+		token, err := manager.GenerateAccessToken(userID, email, "user")
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, token)
 	})
 
 	t.Run("token contains correct claims", func(t *testing.T) {
-		token, _ := manager.GenerateAccessToken(userID, email)
+		token, _ := manager.GenerateAccessToken(userID, email, "user")
 
 		claims, err := manager.ValidateAccessToken(token)
 
@@ -95,7 +95,7 @@ func TestJWTManager_GenerateTokenPair(t *testing.T) {
 	email := "test@example.com"
 
 	t.Run("generates both tokens", func(t *testing.T) {
-		pair, err := manager.GenerateTokenPair(userID, email)
+		pair, err := manager.GenerateTokenPair(userID, email, "user")
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, pair.AccessToken)
@@ -103,7 +103,7 @@ func TestJWTManager_GenerateTokenPair(t *testing.T) {
 	})
 
 	t.Run("tokens are different", func(t *testing.T) {
-		pair, _ := manager.GenerateTokenPair(userID, email)
+		pair, _ := manager.GenerateTokenPair(userID, email, "user")
 
 		assert.NotEqual(t, pair.AccessToken, pair.RefreshToken)
 	})
@@ -115,7 +115,7 @@ func TestJWTManager_ValidateAccessToken(t *testing.T) {
 	email := "test@example.com"
 
 	t.Run("validates correct token", func(t *testing.T) {
-		token, _ := manager.GenerateAccessToken(userID, email)
+		token, _ := manager.GenerateAccessToken(userID, email, "user")
 
 		claims, err := manager.ValidateAccessToken(token)
 
@@ -123,6 +123,15 @@ func TestJWTManager_ValidateAccessToken(t *testing.T) {
 		assert.Equal(t, userID, claims.UserID)
 	})
 
+	t.Run("carries the role claim", func(t *testing.T) {
+		token, _ := manager.GenerateAccessToken(userID, email, "admin")
+
+		claims, err := manager.ValidateAccessToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin", claims.Role)
+	})
+
 	t.Run("rejects invalid token", func(t *testing.T) {
 		_, err := manager.ValidateAccessToken("invalid.token.here")
 
@@ -149,7 +158,7 @@ func TestJWTManager_ValidateAccessToken(t *testing.T) {
 			1*time.Hour,
 		)
 
-		token, _ := shortManager.GenerateAccessToken(userID, email)
+		token, _ := shortManager.GenerateAccessToken(userID, email, "user")
 		time.Sleep(10 * time.Millisecond)
 
 		_, err := shortManager.ValidateAccessToken(token)
@@ -173,7 +182,7 @@ func TestJWTManager_ValidateRefreshToken(t *testing.T) {
 	})
 
 	t.Run("rejects access token as refresh token", func(t *testing.T) {
-		accessToken, _ := manager.GenerateAccessToken(userID, email)
+		accessToken, _ := manager.GenerateAccessToken(userID, email, "user")
 
 		_, err := manager.ValidateRefreshToken(accessToken)
 
@@ -28,6 +28,8 @@ const (
 	RequestIDKey contextKey = "request_id"
 	// TraceIDKey is the key for trace ID
 	TraceIDKey contextKey = "trace_id"
+	// ClientIPKey is the key for the client IP address
+	ClientIPKey contextKey = "client_ip"
 )
 
 func New(cfg Config) (*Logger, error) {
@@ -103,6 +105,9 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 	if traceID, ok := ctx.Value(TraceIDKey).(string); ok && traceID != "" {
 		fields = append(fields, zap.String("trace_id", traceID))
 	}
+	if clientIP, ok := ctx.Value(ClientIPKey).(string); ok && clientIP != "" {
+		fields = append(fields, zap.String("client_ip", clientIP))
+	}
 	if len(fields) == 0 {
 		return l
 	}
@@ -0,0 +1,100 @@
+package merchant
+
+import (
+	"strings"
+	"unicode"
+)
+
+// processorPrefixes are payment processor markers that precede the merchant name
+var processorPrefixes = []string{
+	"SQ *", "SQ*", "TST* ", "TST*", "PAYPAL *", "PAYPAL*", "SP * ", "SP *", "SP*",
+	"POS ", "DEBIT CARD PURCHASE ", "CARD PURCHASE ", "PURCHASE ",
+}
+
+// domainSuffixes are stripped from merchant words such as AMAZON.COM
+var domainSuffixes = []string{".COM", ".NET", ".ORG", ".CO", ".IO"}
+
+// Normalize reduces a bank statement description to a stable merchant key.
+// Processor prefixes, reference codes after '*', domain suffixes, numbers and
+// punctuation are removed, e.g. "AMZN Mktp US*2K3" becomes "AMZN MKTP US".
+func Normalize(description string) string {
+	s := strings.ToUpper(strings.TrimSpace(description))
+
+	for _, prefix := range processorPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			s = strings.TrimSpace(s[len(prefix):])
+			break
+		}
+	}
+
+	// Everything after '*' is a store or order reference
+	if i := strings.Index(s, "*"); i > 0 {
+		s = s[:i]
+	}
+
+	s = strings.TrimPrefix(s, "WWW.")
+	words := strings.Fields(s)
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		for _, suffix := range domainSuffixes {
+			if strings.HasSuffix(word, suffix) && len(word) > len(suffix) {
+				word = strings.TrimSuffix(word, suffix)
+				break
+			}
+		}
+
+		word = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || r == '&' || r == '\'' {
+				return r
+			}
+			if unicode.IsDigit(r) {
+				return -1
+			}
+			return ' '
+		}, word)
+
+		for _, part := range strings.Fields(word) {
+			kept = append(kept, strings.Trim(part, "'"))
+		}
+	}
+
+	return strings.Join(strings.Fields(strings.Join(kept, " ")), " ")
+}
+
+// DisplayName turns a normalized merchant key into a title-cased name
+func DisplayName(key string) string {
+	words := strings.Fields(strings.ToLower(key))
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// Similarity returns the Jaccard similarity of the words of two normalized
+// descriptions, from 0 (nothing shared) to 1 (same words). Two descriptions
+// without any words are considered identical.
+func Similarity(a, b string) float64 {
+	wordsA := wordSet(Normalize(a))
+	wordsB := wordSet(Normalize(b))
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,42 @@
+package merchant_test
+
+import (
+	"pfn-backend/internal/pkg/merchant"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{"reference after star", "AMZN Mktp US*2K3LR4", "AMZN MKTP US"},
+		{"domain suffix", "Amazon.com", "AMAZON"},
+		{"www prefix", "www.netflix.com", "NETFLIX"},
+		{"square prefix", "SQ *BLUE BOTTLE COFFEE", "BLUE BOTTLE COFFEE"},
+		{"store number", "STARBUCKS #1234 SEATTLE", "STARBUCKS SEATTLE"},
+		{"punctuation", "McDonald's, Inc.", "MCDONALD'S INC"},
+		{"only numbers", "12345", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, merchant.Normalize(tt.description))
+		})
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	assert.Equal(t, "Blue Bottle Coffee", merchant.DisplayName("BLUE BOTTLE COFFEE"))
+	assert.Equal(t, "", merchant.DisplayName(""))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, merchant.Similarity("AMZN Mktp US*2K3", "amzn mktp us*9ZZ"))
+	assert.Equal(t, 1.0, merchant.Similarity("", "#123"))
+	assert.InDelta(t, 0.5, merchant.Similarity("Starbucks Seattle", "STARBUCKS #44"), 1e-9)
+	assert.Equal(t, 0.0, merchant.Similarity("Netflix", "Spotify"))
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"pfn-backend/internal/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Job is a background task that runs at a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs in the background until stopped
+type Scheduler struct {
+	jobs   []Job
+	logger *logger.Logger
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new scheduler
+func New(logger *logger.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+	}
+}
+
+// Register adds a job. Jobs with a non-positive interval are disabled.
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		s.logger.Info("Background job disabled", logger.String("job", job.Name))
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job once and then on its interval
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Background job panicked", logger.String("job", job.Name), logger.Any("panic", r))
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Error("Background job failed", logger.String("job", job.Name), logger.Error(err))
+		return
+	}
+	s.logger.Debug("Background job completed", logger.String("job", job.Name), logger.Duration("duration", time.Since(start)))
+}
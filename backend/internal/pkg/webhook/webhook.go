@@ -0,0 +1,254 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Headers sent with every delivery
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// maxResponseBody bounds how much of a receiver's response is read and
+// discarded so the connection can be reused
+const maxResponseBody = 1024
+
+// ErrBlockedDestination is returned for receivers on loopback, private or
+// link-local addresses, which would let users probe the internal network
+var ErrBlockedDestination = errors.New("destination address is not allowed")
+
+// blockedPrefixes are non-public ranges the net.IP helpers do not cover
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+}
+
+// GenerateSecret returns a random signing secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Sign returns the signature header value for a body sent at timestamp:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">".
+// Including the timestamp lets receivers reject replayed deliveries.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + signature(secret, ts, body)
+}
+
+// Verify checks a signature header against the body, rejecting signatures
+// older than tolerance. A zero tolerance skips the age check.
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			sig = value
+		}
+	}
+	if ts == "" || sig == "" {
+		return fmt.Errorf("malformed signature header")
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed signature timestamp")
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)) > tolerance {
+		return fmt.Errorf("signature timestamp is too old")
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, ts, body))) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func signature(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before retry number attempt (1 for the first
+// retry): initial doubled per attempt and capped at max
+func Backoff(attempt int, initial, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := initial
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max || delay <= 0 {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// Request is a single delivery to a receiver
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID string
+	Body       []byte
+}
+
+// Result describes the receiver's answer to a delivery. The response body is
+// not kept.
+type Result struct {
+	StatusCode int
+	Duration   time.Duration
+}
+
+// Success reports whether the receiver accepted the delivery
+func (r *Result) Success() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Sender posts signed payloads to receivers
+type Sender struct {
+	client       *http.Client
+	resolver     *net.Resolver
+	allowPrivate bool
+}
+
+// NewSender creates a sender whose requests time out after timeout. Unless
+// allowPrivate is set, it refuses to connect to non-public addresses.
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// Control runs with the resolved address, so hostnames that resolve
+		// (or later rebind) to internal addresses are refused as well
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !publicAddr(addr) {
+				return ErrBlockedDestination
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Connect directly so the guard sees the receiver's own address
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// Receivers must answer themselves; following redirects would
+			// resend the signed body to a URL nobody registered
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		resolver:     net.DefaultResolver,
+		allowPrivate: allowPrivate,
+	}
+}
+
+// CheckHost resolves a receiver host and returns ErrBlockedDestination if any
+// of its addresses is not public. Delivery checks again on every connection.
+func (s *Sender) CheckHost(ctx context.Context, host string) error {
+	if s.allowPrivate {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		if !publicAddr(addr) {
+			return ErrBlockedDestination
+		}
+		return nil
+	}
+
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return ErrBlockedDestination
+		}
+	}
+	return nil
+}
+
+// publicAddr reports whether addr is a globally routable unicast address
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Send delivers the request. An error means the receiver could not be
+// reached; a reached receiver's status is reported in the result.
+func (s *Sender) Send(ctx context.Context, req Request) (*Result, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "pfn-webhooks/1")
+	httpReq.Header.Set(EventHeader, req.Event)
+	httpReq.Header.Set(DeliveryHeader, req.DeliveryID)
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, time.Now(), req.Body))
+
+	start := time.Now()
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	return &Result{
+		StatusCode: resp.StatusCode,
+		Duration:   time.Since(start),
+	}, nil
+}
@@ -0,0 +1,169 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pfn-backend/internal/pkg/webhook"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"transaction.created"}`)
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	header := webhook.Sign("secret", now, body)
+
+	t.Run("valid signature", func(t *testing.T) {
+		assert.NoError(t, webhook.Verify("secret", header, body, 5*time.Minute, now.Add(time.Minute)))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		assert.Error(t, webhook.Verify("other", header, body, 5*time.Minute, now))
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		assert.Error(t, webhook.Verify("secret", header, []byte(`{"type":"card.frozen"}`), 5*time.Minute, now))
+	})
+
+	t.Run("replayed after tolerance", func(t *testing.T) {
+		assert.Error(t, webhook.Verify("secret", header, body, 5*time.Minute, now.Add(10*time.Minute)))
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		assert.Error(t, webhook.Verify("secret", "v1=abc", body, 0, now))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	initial, max := 30*time.Second, 10*time.Minute
+
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1, initial, max))
+	assert.Equal(t, time.Minute, webhook.Backoff(2, initial, max))
+	assert.Equal(t, 4*time.Minute, webhook.Backoff(4, initial, max))
+	assert.Equal(t, max, webhook.Backoff(6, initial, max))
+	assert.Equal(t, max, webhook.Backoff(100, initial, max))
+}
+
+func TestSender_Send(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"ping"}`)
+
+	t.Run("receiver gets a verifiable signed request", func(t *testing.T) {
+		var gotHeaders http.Header
+		var gotBody []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotHeaders = r.Header.Clone()
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), webhook.Request{
+			URL:        receiver.URL,
+			Secret:     "secret",
+			Event:      "ping",
+			DeliveryID: "42",
+			Body:       body,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Success())
+		assert.Equal(t, body, gotBody)
+		assert.Equal(t, "ping", gotHeaders.Get(webhook.EventHeader))
+		assert.Equal(t, "42", gotHeaders.Get(webhook.DeliveryHeader))
+		assert.NoError(t, webhook.Verify("secret", gotHeaders.Get(webhook.SignatureHeader), gotBody, time.Minute, time.Now()))
+	})
+
+	t.Run("non-2xx is reported, not an error", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("try later"))
+		}))
+		defer receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), webhook.Request{URL: receiver.URL, Body: body})
+
+		require.NoError(t, err)
+		assert.False(t, result.Success())
+		assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+	})
+
+	t.Run("redirects are not followed", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		}))
+		defer receiver.Close()
+
+		result, err := webhook.NewSender(time.Second, true).Send(context.Background(), webhook.Request{URL: receiver.URL, Body: body})
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusFound, result.StatusCode)
+		assert.False(t, result.Success())
+	})
+
+	t.Run("private receivers are refused", func(t *testing.T) {
+		var called bool
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer receiver.Close()
+
+		_, err := webhook.NewSender(time.Second, false).Send(context.Background(), webhook.Request{URL: receiver.URL, Body: body})
+
+		assert.ErrorIs(t, err, webhook.ErrBlockedDestination)
+		assert.False(t, called)
+	})
+
+	t.Run("unreachable receiver is an error", func(t *testing.T) {
+		receiver := httptest.NewServer(http.NotFoundHandler())
+		url := receiver.URL
+		receiver.Close()
+
+		_, err := webhook.NewSender(time.Second, true).Send(context.Background(), webhook.Request{URL: url, Body: body})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSender_CheckHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"localhost", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.10", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"[::1]", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"2606:4700:4700::1111", false},
+	}
+
+	sender := webhook.NewSender(time.Second, false)
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := sender.CheckHost(context.Background(), tt.host)
+			if tt.blocked {
+				assert.ErrorIs(t, err, webhook.ErrBlockedDestination)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("allowed when private networks are", func(t *testing.T) {
+		assert.NoError(t, webhook.NewSender(time.Second, true).CheckHost(context.Background(), "127.0.0.1"))
+	})
+}
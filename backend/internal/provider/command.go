@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"pfn-backend/internal/app/postgres"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/reconciliation"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+)
+
+// ReconcileCommand runs balance reconciliation from the command line
+type ReconcileCommand struct {
+	reconciliationService reconciliation.Service
+	userRepo              repository.UserRepository
+	db                    *postgres.Database
+}
+
+func ProvideReconcileCommand(
+	reconciliationService reconciliation.Service,
+	userRepo repository.UserRepository,
+	db *postgres.Database,
+) *ReconcileCommand {
+	return &ReconcileCommand{
+		reconciliationService: reconciliationService,
+		userRepo:              userRepo,
+		db:                    db,
+	}
+}
+
+// Run reconciles the given users, or every active user when userID is nil.
+// With fix set, each drifted card keeps its current balance and the drift is
+// recorded as an adjustment transaction.
+func (c *ReconcileCommand) Run(ctx context.Context, userID *uuid.UUID, fix bool, out io.Writer) error {
+	var userIDs []uuid.UUID
+	if userID != nil {
+		userIDs = []uuid.UUID{*userID}
+	} else {
+		ids, err := c.userRepo.FindActiveIDs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get users: %w", err)
+		}
+		userIDs = ids
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tCARD\tEXPECTED\tACTUAL\tDISCREPANCY\tSTATUS")
+
+	discrepant := 0
+	for _, id := range userIDs {
+		report, err := c.reconciliationService.Reconcile(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile user %s: %w", id, err)
+		}
+
+		for _, card := range report.Cards {
+			if card.Reconciled {
+				continue
+			}
+			discrepant++
+
+			status := "drift"
+			if fix {
+				if _, err := c.reconciliationService.AdjustBalance(ctx, card.CardID, id, reconciliation.AdjustBalanceRequest{
+					TargetBalance: card.ActualBalance,
+					Note:          "Reconciliation adjustment",
+				}); err != nil {
+					return fmt.Errorf("failed to adjust card %d: %w", card.CardID, err)
+				}
+				status = "adjusted"
+			}
+
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", id, card.CardID, card.ExpectedBalance, card.ActualBalance, card.Discrepancy, status)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d user(s) checked, %d card(s) with discrepancies\n", len(userIDs), discrepant)
+
+	return nil
+}
+
+// Close releases the database connection
+func (c *ReconcileCommand) Close() error {
+	return c.db.Close()
+}
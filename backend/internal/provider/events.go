@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/app/service/webhook"
+	"pfn-backend/internal/pkg/events"
+	"pfn-backend/internal/pkg/logger"
+)
+
+func ProvideEventBus(logger *logger.Logger) *events.Bus {
+	return events.NewBus(logger)
+}
+
+func ProvideEventPublisher(
+	bus *events.Bus,
+	notificationService notification.Service,
+	webhookService webhook.Service,
+) events.Publisher {
+	// Subscribe before any publishing service is built
+	bus.Subscribe(events.All, notificationService.HandleEvent)
+	bus.Subscribe(events.All, webhookService.HandleEvent)
+	return bus
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/jwt"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/rpc"
+
+	"google.golang.org/grpc"
+)
+
+func ProvideGRPCServer(
+	jwtManager *jwt.JWTManager,
+	userRepo repository.UserRepository,
+	authService auth.Service,
+	cardService card.Service,
+	txService transaction.Service,
+	categoryService category.Service,
+	logger *logger.Logger,
+) *grpc.Server {
+	return rpc.NewServer(jwtManager, userRepo, authService, cardService, txService, categoryService, logger)
+}
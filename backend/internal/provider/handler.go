@@ -1,11 +1,28 @@
 package provider
 
 import (
+	"pfn-backend/internal/app/service/admin"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/app/service/audit"
 	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/app/service/bill"
 	"pfn-backend/internal/app/service/card"
 	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/duplicate"
+	"pfn-backend/internal/app/service/household"
+	"pfn-backend/internal/app/service/networth"
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/app/service/payee"
+	"pfn-backend/internal/app/service/reconciliation"
+	"pfn-backend/internal/app/service/rule"
+	"pfn-backend/internal/app/service/split"
+	"pfn-backend/internal/app/service/suggestion"
 	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/app/service/trash"
 	"pfn-backend/internal/app/service/user"
+	"pfn-backend/internal/app/service/webhook"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/graph"
 	"pfn-backend/internal/handlers"
 	"pfn-backend/internal/pkg/logger"
 )
@@ -40,3 +57,114 @@ func ProvideCategoryHandler(
 ) *handlers.CategoryHandler {
 	return handlers.NewCategoryHandler(categoryService)
 }
+
+func ProvideNetWorthHandler(
+	netWorthService networth.Service,
+) *handlers.NetWorthHandler {
+	return handlers.NewNetWorthHandler(netWorthService)
+}
+
+func ProvideReconciliationHandler(
+	reconciliationService reconciliation.Service,
+) *handlers.ReconciliationHandler {
+	return handlers.NewReconciliationHandler(reconciliationService)
+}
+
+func ProvideRuleHandler(
+	ruleService rule.Service,
+) *handlers.RuleHandler {
+	return handlers.NewRuleHandler(ruleService)
+}
+
+func ProvideSuggestionHandler(
+	suggestionService suggestion.Service,
+) *handlers.SuggestionHandler {
+	return handlers.NewSuggestionHandler(suggestionService)
+}
+
+func ProvidePayeeHandler(
+	payeeService payee.Service,
+) *handlers.PayeeHandler {
+	return handlers.NewPayeeHandler(payeeService)
+}
+
+func ProvideDuplicateHandler(
+	duplicateService duplicate.Service,
+) *handlers.DuplicateHandler {
+	return handlers.NewDuplicateHandler(duplicateService)
+}
+
+func ProvideBillHandler(
+	billService bill.Service,
+) *handlers.BillHandler {
+	return handlers.NewBillHandler(billService)
+}
+
+func ProvideNotificationHandler(
+	notificationService notification.Service,
+) *handlers.NotificationHandler {
+	return handlers.NewNotificationHandler(notificationService)
+}
+
+func ProvideWebhookHandler(
+	webhookService webhook.Service,
+) *handlers.WebhookHandler {
+	return handlers.NewWebhookHandler(webhookService)
+}
+
+func ProvideAPIKeyHandler(
+	apiKeyService apikey.Service,
+) *handlers.APIKeyHandler {
+	return handlers.NewAPIKeyHandler(apiKeyService)
+}
+
+func ProvideAdminHandler(
+	adminService admin.Service,
+) *handlers.AdminHandler {
+	return handlers.NewAdminHandler(adminService)
+}
+
+func ProvideHouseholdHandler(
+	householdService household.Service,
+) *handlers.HouseholdHandler {
+	return handlers.NewHouseholdHandler(householdService)
+}
+
+func ProvideSplitHandler(
+	splitService split.Service,
+) *handlers.SplitHandler {
+	return handlers.NewSplitHandler(splitService)
+}
+
+func ProvideAuditHandler(
+	auditService audit.Service,
+) *handlers.AuditHandler {
+	return handlers.NewAuditHandler(auditService)
+}
+
+func ProvideTrashHandler(
+	trashService trash.Service,
+) *handlers.TrashHandler {
+	return handlers.NewTrashHandler(trashService)
+}
+
+func ProvideGraphQLSchema(
+	cfg *config.Config,
+	userService user.Service,
+	cardService card.Service,
+	txService transaction.Service,
+	categoryService category.Service,
+	logger *logger.Logger,
+) (*graph.Schema, error) {
+	limits := graph.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	}
+	return graph.NewSchema(userService, cardService, txService, categoryService, limits, logger)
+}
+
+func ProvideGraphQLHandler(
+	schema *graph.Schema,
+) *handlers.GraphQLHandler {
+	return handlers.NewGraphQLHandler(schema)
+}
@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/app/service/idempotency"
 	"pfn-backend/internal/config"
 	"pfn-backend/internal/middleware"
 	"pfn-backend/internal/pkg/jwt"
@@ -12,12 +15,22 @@ import (
 type LoggerMiddleware gin.HandlerFunc
 type CORSMiddleware gin.HandlerFunc
 type RecoveryMiddleware gin.HandlerFunc
+type ErrorMiddleware gin.HandlerFunc
 
 func ProvideAuthMiddleware(
 	jwtManager *jwt.JWTManager,
+	apiKeyService apikey.Service,
+	userRepo repository.UserRepository,
 	logger *logger.Logger,
 ) *middleware.AuthMiddleware {
-	return middleware.NewAuthMiddleware(jwtManager, logger)
+	return middleware.NewAuthMiddleware(jwtManager, apiKeyService, userRepo, logger)
+}
+
+func ProvideIdempotencyMiddleware(
+	idempotencyService idempotency.Service,
+	logger *logger.Logger,
+) *middleware.IdempotencyMiddleware {
+	return middleware.NewIdempotencyMiddleware(idempotencyService, logger)
 }
 
 func ProvideLoggerMiddleware(logger *logger.Logger) LoggerMiddleware {
@@ -31,3 +44,7 @@ func ProvideCORSMiddleware(cfg *config.Config) CORSMiddleware {
 func ProvideRecoveryMiddleware(logger *logger.Logger) RecoveryMiddleware {
 	return RecoveryMiddleware(middleware.RecoveryMiddleware(logger))
 }
+
+func ProvideErrorMiddleware(logger *logger.Logger) ErrorMiddleware {
+	return ErrorMiddleware(middleware.ErrorMiddleware(logger))
+}
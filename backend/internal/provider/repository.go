@@ -24,3 +24,51 @@ func ProvideCategoryRepository(db *postgres.Database) repository.CategoryReposit
 func ProvideRefreshTokenRepository(db *postgres.Database) repository.RefreshTokenRepository {
 	return postgres.NewRefreshTokenRepository(db.DB)
 }
+
+func ProvideNetWorthRepository(db *postgres.Database) repository.NetWorthRepository {
+	return postgres.NewNetWorthRepository(db.DB)
+}
+
+func ProvideRuleRepository(db *postgres.Database) repository.RuleRepository {
+	return postgres.NewRuleRepository(db.DB)
+}
+
+func ProvidePayeeRepository(db *postgres.Database) repository.PayeeRepository {
+	return postgres.NewPayeeRepository(db.DB)
+}
+
+func ProvideDuplicateRepository(db *postgres.Database) repository.DuplicateRepository {
+	return postgres.NewDuplicateRepository(db.DB)
+}
+
+func ProvideBillRepository(db *postgres.Database) repository.BillRepository {
+	return postgres.NewBillRepository(db.DB)
+}
+
+func ProvideNotificationRepository(db *postgres.Database) repository.NotificationRepository {
+	return postgres.NewNotificationRepository(db.DB)
+}
+
+func ProvideWebhookRepository(db *postgres.Database) repository.WebhookRepository {
+	return postgres.NewWebhookRepository(db.DB)
+}
+
+func ProvideAPIKeyRepository(db *postgres.Database) repository.APIKeyRepository {
+	return postgres.NewAPIKeyRepository(db.DB)
+}
+
+func ProvideHouseholdRepository(db *postgres.Database) repository.HouseholdRepository {
+	return postgres.NewHouseholdRepository(db.DB)
+}
+
+func ProvideSharedExpenseRepository(db *postgres.Database) repository.SharedExpenseRepository {
+	return postgres.NewSharedExpenseRepository(db.DB)
+}
+
+func ProvideAuditLogRepository(db *postgres.Database) repository.AuditLogRepository {
+	return postgres.NewAuditLogRepository(db.DB)
+}
+
+func ProvideIdempotencyKeyRepository(db *postgres.Database) repository.IdempotencyKeyRepository {
+	return postgres.NewIdempotencyKeyRepository(db.DB)
+}
@@ -16,10 +16,28 @@ func ProvideRouter(
 	cardHandler *handlers.CardHandler,
 	transactionHandler *handlers.TransactionHandler,
 	categoryHandler *handlers.CategoryHandler,
+	netWorthHandler *handlers.NetWorthHandler,
+	reconciliationHandler *handlers.ReconciliationHandler,
+	ruleHandler *handlers.RuleHandler,
+	suggestionHandler *handlers.SuggestionHandler,
+	payeeHandler *handlers.PayeeHandler,
+	duplicateHandler *handlers.DuplicateHandler,
+	billHandler *handlers.BillHandler,
+	notificationHandler *handlers.NotificationHandler,
+	webhookHandler *handlers.WebhookHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	adminHandler *handlers.AdminHandler,
+	householdHandler *handlers.HouseholdHandler,
+	splitHandler *handlers.SplitHandler,
+	auditHandler *handlers.AuditHandler,
+	trashHandler *handlers.TrashHandler,
+	graphqlHandler *handlers.GraphQLHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	idempotencyMiddleware *middleware.IdempotencyMiddleware,
 	loggerMw LoggerMiddleware,
 	corsMw CORSMiddleware,
 	recoveryMw RecoveryMiddleware,
+	errorMw ErrorMiddleware,
 ) *router.Router {
 	return router.New(
 		cfg,
@@ -28,9 +46,27 @@ func ProvideRouter(
 		cardHandler,
 		transactionHandler,
 		categoryHandler,
+		netWorthHandler,
+		reconciliationHandler,
+		ruleHandler,
+		suggestionHandler,
+		payeeHandler,
+		duplicateHandler,
+		billHandler,
+		notificationHandler,
+		webhookHandler,
+		apiKeyHandler,
+		adminHandler,
+		householdHandler,
+		splitHandler,
+		auditHandler,
+		trashHandler,
+		graphqlHandler,
 		authMiddleware,
+		idempotencyMiddleware,
 		gin.HandlerFunc(loggerMw),
 		gin.HandlerFunc(corsMw),
 		gin.HandlerFunc(recoveryMw),
+		gin.HandlerFunc(errorMw),
 	)
 }
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"pfn-backend/internal/app/service/bill"
+	"pfn-backend/internal/app/service/idempotency"
+	"pfn-backend/internal/app/service/networth"
+	"pfn-backend/internal/app/service/trash"
+	"pfn-backend/internal/app/service/webhook"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/pkg/scheduler"
+)
+
+func ProvideScheduler(
+	cfg *config.Config,
+	logger *logger.Logger,
+	netWorthService networth.Service,
+	billService bill.Service,
+	webhookService webhook.Service,
+	trashService trash.Service,
+	idempotencyService idempotency.Service,
+) *scheduler.Scheduler {
+	s := scheduler.New(logger)
+
+	// Snapshots are upserted per day, so running hourly keeps today's current
+	s.Register(scheduler.Job{
+		Name:     "net_worth_snapshot",
+		Interval: cfg.Jobs.NetWorthSnapshotInterval,
+		Run:      netWorthService.TakeDailySnapshots,
+	})
+
+	// Reminders are sent once per due date, so frequent runs don't repeat them
+	s.Register(scheduler.Job{
+		Name:     "bill_reminders",
+		Interval: cfg.Jobs.BillReminderInterval,
+		Run:      billService.SendReminders,
+	})
+
+	// Drains the webhook outbox; each run sends at most webhooks.batch_size deliveries
+	s.Register(scheduler.Job{
+		Name:     "webhook_dispatch",
+		Interval: cfg.Jobs.WebhookDispatchInterval,
+		Run:      webhookService.DispatchPending,
+	})
+
+	// Permanently removes cards and transactions deleted longer than trash.retention ago
+	s.Register(scheduler.Job{
+		Name:     "trash_purge",
+		Interval: cfg.Jobs.TrashPurgeInterval,
+		Run:      trashService.PurgeExpired,
+	})
+
+	// Drops Idempotency-Key responses older than idempotency.ttl
+	s.Register(scheduler.Job{
+		Name:     "idempotency_purge",
+		Interval: cfg.Jobs.IdempotencyPurgeInterval,
+		Run:      idempotencyService.PurgeExpired,
+	})
+
+	return s
+}
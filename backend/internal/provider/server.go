@@ -3,35 +3,45 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"pfn-backend/internal/app/postgres"
 	"pfn-backend/internal/config"
 	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/pkg/scheduler"
 	"pfn-backend/internal/router"
 	"syscall"
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 type Server struct {
-	cfg    *config.Config
-	router *router.Router
-	db     *postgres.Database
-	logger *logger.Logger
+	cfg        *config.Config
+	router     *router.Router
+	grpcServer *grpc.Server
+	db         *postgres.Database
+	scheduler  *scheduler.Scheduler
+	logger     *logger.Logger
 }
 
 func ProvideServer(
 	cfg *config.Config,
 	router *router.Router,
+	grpcServer *grpc.Server,
 	db *postgres.Database,
+	scheduler *scheduler.Scheduler,
 	logger *logger.Logger,
 ) *Server {
 	return &Server{
-		cfg:    cfg,
-		router: router,
-		db:     db,
-		logger: logger,
+		cfg:        cfg,
+		router:     router,
+		grpcServer: grpcServer,
+		db:         db,
+		scheduler:  scheduler,
+		logger:     logger,
 	}
 }
 
@@ -46,6 +56,16 @@ func (s *Server) Start() error {
 		MaxHeaderBytes: 1 << 20,
 	}
 
+	// Claim the gRPC port up front so a conflict fails startup
+	var grpcListener net.Listener
+	if s.cfg.GRPC.Enabled {
+		var err error
+		grpcListener, err = net.Listen("tcp", fmt.Sprintf("%s:%s", s.cfg.App.Host, s.cfg.GRPC.Port))
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC: %w", err)
+		}
+	}
+
 	// Start server in goroutine
 	go func() {
 		s.logger.Info("Starting HTTP server", logger.String("address", addr))
@@ -54,6 +74,18 @@ func (s *Server) Start() error {
 		}
 	}()
 
+	if grpcListener != nil {
+		go func() {
+			s.logger.Info("Starting gRPC server", logger.String("address", grpcListener.Addr().String()))
+			if err := s.grpcServer.Serve(grpcListener); err != nil {
+				s.logger.Fatal("Failed to start gRPC server", logger.Error(err))
+			}
+		}()
+	}
+
+	// Start background jobs
+	s.scheduler.Start()
+
 	// Wait for interrupt signal to gracefully shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -65,11 +97,18 @@ func (s *Server) Start() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
+	err := srv.Shutdown(ctx)
+	if grpcListener != nil {
+		s.stopGRPC(ctx)
+	}
+	if err != nil {
 		s.logger.Error("Server forced to shutdown", logger.Error(err))
 		return err
 	}
 
+	// Stop background jobs before closing the database
+	s.scheduler.Stop()
+
 	// Close database connection
 	if err := s.db.Close(); err != nil {
 		s.logger.Error("Failed to close database", logger.Error(err))
@@ -78,3 +117,20 @@ func (s *Server) Start() error {
 	s.logger.Info("Server exited successfully")
 	return nil
 }
+
+// stopGRPC lets in-flight calls and exports finish until ctx expires, then
+// cuts off whatever is left
+func (s *Server) stopGRPC(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.logger.Error("gRPC server forced to shutdown", logger.Error(ctx.Err()))
+		s.grpcServer.Stop()
+	}
+}
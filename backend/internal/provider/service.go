@@ -2,41 +2,121 @@ package provider
 
 import (
 	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/access"
+	"pfn-backend/internal/app/service/admin"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/app/service/audit"
 	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/app/service/bill"
 	"pfn-backend/internal/app/service/card"
 	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/duplicate"
+	"pfn-backend/internal/app/service/household"
+	"pfn-backend/internal/app/service/idempotency"
+	"pfn-backend/internal/app/service/networth"
+	"pfn-backend/internal/app/service/notification"
+	"pfn-backend/internal/app/service/payee"
+	"pfn-backend/internal/app/service/reconciliation"
+	"pfn-backend/internal/app/service/rule"
+	"pfn-backend/internal/app/service/split"
+	"pfn-backend/internal/app/service/suggestion"
 	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/app/service/trash"
 	"pfn-backend/internal/app/service/user"
+	"pfn-backend/internal/app/service/webhook"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/pkg/events"
 	"pfn-backend/internal/pkg/jwt"
 	"pfn-backend/internal/pkg/logger"
+	pkgwebhook "pfn-backend/internal/pkg/webhook"
 )
 
 func ProvideAuthService(
 	userRepo repository.UserRepository,
 	refreshTokenRepo repository.RefreshTokenRepository,
 	jwtManager *jwt.JWTManager,
+	publisher events.Publisher,
+	auditor audit.Recorder,
 	logger *logger.Logger,
 ) auth.Service {
-	return auth.NewService(userRepo, refreshTokenRepo, jwtManager, logger)
+	return auth.NewService(userRepo, refreshTokenRepo, jwtManager, publisher, auditor, logger)
 }
 
 func ProvideUserService(
 	userRepo repository.UserRepository,
+	auditor audit.Recorder,
 ) user.Service {
-	return user.NewService(userRepo)
+	return user.NewService(userRepo, auditor)
+}
+
+func ProvideAccessService(
+	householdRepo repository.HouseholdRepository,
+) access.Service {
+	return access.NewService(householdRepo)
 }
 
 func ProvideCardService(
 	cardRepo repository.CardRepository,
+	accessService access.Service,
+	suggestionService suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
 ) card.Service {
-	return card.NewService(cardRepo)
+	return card.NewService(cardRepo, accessService, suggestionService, publisher, auditor)
 }
 
 func ProvideTransactionService(
 	txRepo repository.TransactionRepository,
 	cardRepo repository.CardRepository,
+	categoryRepo repository.CategoryRepository,
+	accessService access.Service,
+	ruleService rule.Service,
+	payeeService payee.Service,
+	suggestionService suggestion.Service,
+	duplicateService duplicate.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
 ) transaction.Service {
-	return transaction.NewService(txRepo, cardRepo)
+	return transaction.NewService(txRepo, cardRepo, categoryRepo, accessService, ruleService, payeeService, suggestionService, duplicateService, publisher, auditor)
+}
+
+func ProvideDuplicateService(
+	duplicateRepo repository.DuplicateRepository,
+	txRepo repository.TransactionRepository,
+	suggestionService suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
+	cfg *config.Config,
+) duplicate.Service {
+	return duplicate.NewService(duplicateRepo, txRepo, suggestionService, publisher, auditor, cfg.Duplicates)
+}
+
+func ProvidePayeeService(
+	payeeRepo repository.PayeeRepository,
+	txRepo repository.TransactionRepository,
+	auditor audit.Recorder,
+) payee.Service {
+	return payee.NewService(payeeRepo, txRepo, auditor)
+}
+
+func ProvideRuleService(
+	ruleRepo repository.RuleRepository,
+	txRepo repository.TransactionRepository,
+	cardRepo repository.CardRepository,
+	categoryRepo repository.CategoryRepository,
+	suggestionService suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
+) rule.Service {
+	return rule.NewService(ruleRepo, txRepo, cardRepo, categoryRepo, suggestionService, publisher, auditor)
+}
+
+func ProvideSuggestionService(
+	txRepo repository.TransactionRepository,
+	categoryRepo repository.CategoryRepository,
+	cfg *config.Config,
+) suggestion.Service {
+	return suggestion.NewService(txRepo, categoryRepo, cfg.Suggestions.MaxModels)
 }
 
 func ProvideCategoryService(
@@ -44,3 +124,127 @@ func ProvideCategoryService(
 ) category.Service {
 	return category.NewService(categoryRepo)
 }
+
+func ProvideNetWorthService(
+	netWorthRepo repository.NetWorthRepository,
+	cardRepo repository.CardRepository,
+	txRepo repository.TransactionRepository,
+	userRepo repository.UserRepository,
+	logger *logger.Logger,
+) networth.Service {
+	return networth.NewService(netWorthRepo, cardRepo, txRepo, userRepo, logger)
+}
+
+func ProvideReconciliationService(
+	cardRepo repository.CardRepository,
+	txRepo repository.TransactionRepository,
+	auditor audit.Recorder,
+) reconciliation.Service {
+	return reconciliation.NewService(cardRepo, txRepo, auditor)
+}
+
+func ProvideAuditRecorder(
+	auditRepo repository.AuditLogRepository,
+	logger *logger.Logger,
+) audit.Recorder {
+	return audit.NewRecorder(auditRepo, logger)
+}
+
+func ProvideAuditService(
+	auditRepo repository.AuditLogRepository,
+) audit.Service {
+	return audit.NewService(auditRepo)
+}
+
+func ProvideNotifier(
+	notificationRepo repository.NotificationRepository,
+) notification.Notifier {
+	return notification.NewInAppNotifier(notificationRepo)
+}
+
+func ProvideNotificationService(
+	notificationRepo repository.NotificationRepository,
+	notifier notification.Notifier,
+) notification.Service {
+	return notification.NewService(notificationRepo, notifier)
+}
+
+func ProvideBillService(
+	billRepo repository.BillRepository,
+	cardRepo repository.CardRepository,
+	categoryRepo repository.CategoryRepository,
+	txRepo repository.TransactionRepository,
+	txService transaction.Service,
+	notifier notification.Notifier,
+	logger *logger.Logger,
+) bill.Service {
+	return bill.NewService(billRepo, cardRepo, categoryRepo, txRepo, txService, notifier, logger)
+}
+
+func ProvideWebhookService(
+	webhookRepo repository.WebhookRepository,
+	cfg *config.Config,
+	logger *logger.Logger,
+) webhook.Service {
+	return webhook.NewService(webhookRepo, pkgwebhook.NewSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks), cfg.Webhooks, logger)
+}
+
+func ProvideAPIKeyService(
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	logger *logger.Logger,
+) apikey.Service {
+	return apikey.NewService(apiKeyRepo, userRepo, logger)
+}
+
+func ProvideAdminService(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	categoryRepo repository.CategoryRepository,
+	auditor audit.Recorder,
+	logger *logger.Logger,
+) admin.Service {
+	return admin.NewService(userRepo, refreshTokenRepo, categoryRepo, auditor, logger)
+}
+
+func ProvideHouseholdService(
+	householdRepo repository.HouseholdRepository,
+	cardRepo repository.CardRepository,
+	userRepo repository.UserRepository,
+	accessService access.Service,
+	notifier notification.Notifier,
+	auditor audit.Recorder,
+	logger *logger.Logger,
+) household.Service {
+	return household.NewService(householdRepo, cardRepo, userRepo, accessService, notifier, auditor, logger)
+}
+
+func ProvideSplitService(
+	sharedExpenseRepo repository.SharedExpenseRepository,
+	userRepo repository.UserRepository,
+	txService transaction.Service,
+	logger *logger.Logger,
+) split.Service {
+	return split.NewService(sharedExpenseRepo, userRepo, txService, logger)
+}
+
+func ProvideTrashService(
+	cardRepo repository.CardRepository,
+	txRepo repository.TransactionRepository,
+	accessService access.Service,
+	suggestionService suggestion.Service,
+	publisher events.Publisher,
+	auditor audit.Recorder,
+	cfg *config.Config,
+	logger *logger.Logger,
+) trash.Service {
+	return trash.NewService(cardRepo, txRepo, accessService, suggestionService, publisher, auditor, cfg.Trash.Retention, logger)
+}
+
+func ProvideIdempotencyService(
+	idempotencyRepo repository.IdempotencyKeyRepository,
+	cfg *config.Config,
+	logger *logger.Logger,
+) idempotency.Service {
+	return idempotency.NewService(idempotencyRepo, cfg.Idempotency.TTL, logger)
+}
@@ -1,21 +1,46 @@
 package router
 
 import (
+	"net/http"
+	"pfn-backend/internal/app/entity"
 	"pfn-backend/internal/config"
+	"pfn-backend/internal/docs"
 	"pfn-backend/internal/handlers"
 	"pfn-backend/internal/middleware"
+	"pfn-backend/internal/pkg/apperror"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 type Router struct {
-	engine             *gin.Engine
-	authHandler        *handlers.AuthHandler
-	userHandler        *handlers.UserHandler
-	cardHandler        *handlers.CardHandler
-	transactionHandler *handlers.TransactionHandler
-	categoryHandler    *handlers.CategoryHandler
-	authMiddleware     *middleware.AuthMiddleware
+	engine                *gin.Engine
+	authHandler           *handlers.AuthHandler
+	userHandler           *handlers.UserHandler
+	cardHandler           *handlers.CardHandler
+	transactionHandler    *handlers.TransactionHandler
+	categoryHandler       *handlers.CategoryHandler
+	netWorthHandler       *handlers.NetWorthHandler
+	reconciliationHandler *handlers.ReconciliationHandler
+	ruleHandler           *handlers.RuleHandler
+	suggestionHandler     *handlers.SuggestionHandler
+	payeeHandler          *handlers.PayeeHandler
+	duplicateHandler      *handlers.DuplicateHandler
+	billHandler           *handlers.BillHandler
+	notificationHandler   *handlers.NotificationHandler
+	webhookHandler        *handlers.WebhookHandler
+	apiKeyHandler         *handlers.APIKeyHandler
+	adminHandler          *handlers.AdminHandler
+	householdHandler      *handlers.HouseholdHandler
+	splitHandler          *handlers.SplitHandler
+	auditHandler          *handlers.AuditHandler
+	trashHandler          *handlers.TrashHandler
+	graphqlHandler        *handlers.GraphQLHandler
+	authMiddleware        *middleware.AuthMiddleware
+	idempotencyMiddleware *middleware.IdempotencyMiddleware
 }
 
 func New(
@@ -25,31 +50,72 @@ func New(
 	cardHandler *handlers.CardHandler,
 	transactionHandler *handlers.TransactionHandler,
 	categoryHandler *handlers.CategoryHandler,
+	netWorthHandler *handlers.NetWorthHandler,
+	reconciliationHandler *handlers.ReconciliationHandler,
+	ruleHandler *handlers.RuleHandler,
+	suggestionHandler *handlers.SuggestionHandler,
+	payeeHandler *handlers.PayeeHandler,
+	duplicateHandler *handlers.DuplicateHandler,
+	billHandler *handlers.BillHandler,
+	notificationHandler *handlers.NotificationHandler,
+	webhookHandler *handlers.WebhookHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	adminHandler *handlers.AdminHandler,
+	householdHandler *handlers.HouseholdHandler,
+	splitHandler *handlers.SplitHandler,
+	auditHandler *handlers.AuditHandler,
+	trashHandler *handlers.TrashHandler,
+	graphqlHandler *handlers.GraphQLHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	idempotencyMiddleware *middleware.IdempotencyMiddleware,
 	loggerMw gin.HandlerFunc,
 	corsMw gin.HandlerFunc,
 	recoveryMw gin.HandlerFunc,
+	errorMw gin.HandlerFunc,
 ) *Router {
 	// Set Gin mode based on environment
 	if cfg.App.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Validation errors name fields the way clients send them
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(apperror.FieldName)
+	}
+
 	engine := gin.New()
 
-	// Global middleware
+	// Global middleware; errors render after the request ID is assigned
 	engine.Use(recoveryMw)
 	engine.Use(loggerMw)
+	engine.Use(errorMw)
 	engine.Use(corsMw)
 
 	router := &Router{
-		engine:             engine,
-		authHandler:        authHandler,
-		userHandler:        userHandler,
-		cardHandler:        cardHandler,
-		transactionHandler: transactionHandler,
-		categoryHandler:    categoryHandler,
-		authMiddleware:     authMiddleware,
+		engine:                engine,
+		authHandler:           authHandler,
+		userHandler:           userHandler,
+		cardHandler:           cardHandler,
+		transactionHandler:    transactionHandler,
+		categoryHandler:       categoryHandler,
+		netWorthHandler:       netWorthHandler,
+		reconciliationHandler: reconciliationHandler,
+		ruleHandler:           ruleHandler,
+		suggestionHandler:     suggestionHandler,
+		payeeHandler:          payeeHandler,
+		duplicateHandler:      duplicateHandler,
+		billHandler:           billHandler,
+		notificationHandler:   notificationHandler,
+		webhookHandler:        webhookHandler,
+		apiKeyHandler:         apiKeyHandler,
+		adminHandler:          adminHandler,
+		householdHandler:      householdHandler,
+		splitHandler:          splitHandler,
+		auditHandler:          auditHandler,
+		trashHandler:          trashHandler,
+		graphqlHandler:        graphqlHandler,
+		authMiddleware:        authMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
 	}
 
 	router.setupRoutes()
@@ -59,12 +125,19 @@ func New(
 
 func (r *Router) setupRoutes() {
 	// Health check
-	r.engine.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "personal-finance-management",
-		})
+	r.engine.GET("/health", health)
+
+	// API documentation, generated from the handler annotations
+	r.engine.GET("/api/docs", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, "/api/docs/ui/index.html")
+	})
+	r.engine.GET("/api/docs/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", docs.OpenAPI)
 	})
+	r.engine.GET("/api/docs/ui/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/api/docs/openapi.json")))
+
+	// GraphQL (protected, sessions only)
+	r.engine.POST("/graphql", r.authMiddleware.RequireAuth(), r.graphqlHandler.Query)
 
 	// API v1 routes
 	v1 := r.engine.Group("/api/v1")
@@ -91,11 +164,20 @@ func (r *Router) setupRoutes() {
 			users.PUT("/me", r.userHandler.UpdateProfile)
 		}
 
-		// Card routes (protected)
+		// API key routes (protected, sessions only)
+		apiKeys := v1.Group("/api-keys")
+		apiKeys.Use(r.authMiddleware.RequireAuth())
+		{
+			apiKeys.POST("", r.apiKeyHandler.CreateKey)
+			apiKeys.GET("", r.apiKeyHandler.GetKeys)
+			apiKeys.DELETE("/:id", r.apiKeyHandler.RevokeKey)
+		}
+
+		// Card routes (protected, open to API keys with cards scopes)
 		cards := v1.Group("/cards")
-		cards.Use(r.authMiddleware.RequireAuth())
+		cards.Use(r.authMiddleware.RequireScopes(entity.ScopeCardsRead, entity.ScopeCardsWrite))
 		{
-			cards.POST("", r.cardHandler.CreateCard)
+			cards.POST("", r.idempotencyMiddleware.Idempotent(), r.cardHandler.CreateCard)
 			cards.GET("", r.cardHandler.GetUserCards)
 			cards.GET("/:id", r.cardHandler.GetCard)
 			cards.PUT("/:id", r.cardHandler.UpdateCard)
@@ -103,13 +185,151 @@ func (r *Router) setupRoutes() {
 			cards.DELETE("/:id", r.cardHandler.DeleteCard)
 		}
 
-		// Transaction routes (protected)
+		// Account routes (protected); cards are accounts of type Card
+		accounts := v1.Group("/accounts")
+		accounts.Use(r.authMiddleware.RequireScopes(entity.ScopeCardsRead, entity.ScopeCardsWrite))
+		{
+			accounts.POST("", r.idempotencyMiddleware.Idempotent(), r.cardHandler.CreateAccount)
+			accounts.GET("", r.cardHandler.GetUserAccounts)
+			accounts.GET("/:id", r.cardHandler.GetCard)
+			accounts.PUT("/:id", r.cardHandler.UpdateCard)
+			accounts.POST("/:id/freeze", r.cardHandler.ToggleFreeze)
+			accounts.DELETE("/:id", r.cardHandler.DeleteCard)
+		}
+
+		// Transaction routes (protected, open to API keys with transactions scopes)
 		transactions := v1.Group("/transactions")
-		transactions.Use(r.authMiddleware.RequireAuth())
+		transactions.Use(r.authMiddleware.RequireScopes(entity.ScopeTransactionsRead, entity.ScopeTransactionsWrite))
 		{
-			transactions.POST("", r.transactionHandler.CreateTransaction)
+			transactions.POST("", r.idempotencyMiddleware.Idempotent(), r.transactionHandler.CreateTransaction)
+			transactions.POST("/bulk", r.idempotencyMiddleware.Idempotent(), r.transactionHandler.BulkTransactions)
 			transactions.GET("", r.transactionHandler.GetUserTransactions)
 			transactions.GET("/stats", r.transactionHandler.GetStats)
+			transactions.GET("/stats/categories", r.transactionHandler.GetCategoryStats)
+			transactions.GET("/:id", r.transactionHandler.GetTransaction)
+			transactions.DELETE("/:id", r.transactionHandler.DeleteTransaction)
+			transactions.GET("/duplicates", r.duplicateHandler.ListDuplicates)
+			transactions.POST("/duplicates/merge", r.duplicateHandler.MergeDuplicates)
+			transactions.POST("/duplicates/dismiss", r.duplicateHandler.DismissDuplicate)
+		}
+		// Suggesting a category only reads, so a read scope covers the POST
+		v1.POST("/transactions/suggest-category",
+			r.authMiddleware.RequireScopes(entity.ScopeTransactionsRead, entity.ScopeTransactionsRead),
+			r.suggestionHandler.SuggestCategory)
+
+		// Household routes (protected)
+		households := v1.Group("/households")
+		households.Use(r.authMiddleware.RequireAuth())
+		{
+			households.POST("", r.householdHandler.CreateHousehold)
+			households.GET("", r.householdHandler.GetHouseholds)
+			households.GET("/invitations", r.householdHandler.GetInvitations)
+			households.POST("/invitations/:id/accept", r.householdHandler.AcceptInvitation)
+			households.POST("/invitations/:id/decline", r.householdHandler.DeclineInvitation)
+			households.GET("/:id", r.householdHandler.GetHousehold)
+			households.PUT("/:id", r.householdHandler.UpdateHousehold)
+			households.DELETE("/:id", r.householdHandler.DeleteHousehold)
+			households.POST("/:id/invitations", r.householdHandler.Invite)
+			households.DELETE("/:id/invitations/:invitationId", r.householdHandler.RevokeInvitation)
+			households.PUT("/:id/members/:userId", r.householdHandler.UpdateMember)
+			households.DELETE("/:id/members/:userId", r.householdHandler.RemoveMember)
+			households.POST("/:id/cards", r.householdHandler.ShareCard)
+			households.DELETE("/:id/cards/:cardId", r.householdHandler.UnshareCard)
+		}
+
+		// Shared expense routes (protected)
+		splits := v1.Group("/splits")
+		splits.Use(r.authMiddleware.RequireAuth())
+		{
+			splits.POST("", r.splitHandler.CreateSplit)
+			splits.GET("", r.splitHandler.GetSplits)
+			splits.GET("/balances", r.splitHandler.GetBalances)
+			splits.POST("/settle", r.idempotencyMiddleware.Idempotent(), r.splitHandler.Settle)
+			splits.GET("/contacts", r.splitHandler.GetContacts)
+			splits.GET("/:id", r.splitHandler.GetSplit)
+			splits.DELETE("/:id", r.splitHandler.DeleteSplit)
+		}
+
+		// Trash routes (protected)
+		trash := v1.Group("/trash")
+		trash.Use(r.authMiddleware.RequireAuth())
+		{
+			trash.GET("", r.trashHandler.GetTrash)
+			trash.POST("/cards/:id/restore", r.trashHandler.RestoreCard)
+			trash.POST("/transactions/:id/restore", r.trashHandler.RestoreTransaction)
+		}
+
+		// Categorization rule routes (protected)
+		rules := v1.Group("/rules")
+		rules.Use(r.authMiddleware.RequireAuth())
+		{
+			rules.POST("", r.ruleHandler.CreateRule)
+			rules.GET("", r.ruleHandler.GetRules)
+			rules.POST("/apply", r.ruleHandler.ApplyRules)
+			rules.GET("/:id", r.ruleHandler.GetRule)
+			rules.PUT("/:id", r.ruleHandler.UpdateRule)
+			rules.DELETE("/:id", r.ruleHandler.DeleteRule)
+		}
+
+		// Payee routes (protected)
+		payees := v1.Group("/payees")
+		payees.Use(r.authMiddleware.RequireAuth())
+		{
+			payees.POST("", r.payeeHandler.CreatePayee)
+			payees.GET("", r.payeeHandler.GetPayees)
+			payees.GET("/stats", r.payeeHandler.GetStats)
+			payees.GET("/:id", r.payeeHandler.GetPayee)
+			payees.PUT("/:id", r.payeeHandler.UpdatePayee)
+			payees.DELETE("/:id", r.payeeHandler.DeletePayee)
+			payees.GET("/:id/stats", r.payeeHandler.GetPayeeStats)
+			payees.POST("/:id/aliases", r.payeeHandler.AddAlias)
+			payees.DELETE("/:id/aliases/:aliasId", r.payeeHandler.DeleteAlias)
+			payees.POST("/:id/merge", r.payeeHandler.MergePayees)
+			payees.POST("/:id/split", r.payeeHandler.SplitPayee)
+		}
+
+		// Bill routes (protected)
+		bills := v1.Group("/bills")
+		bills.Use(r.authMiddleware.RequireAuth())
+		{
+			bills.POST("", r.billHandler.CreateBill)
+			bills.GET("", r.billHandler.GetBills)
+			bills.GET("/upcoming", r.billHandler.GetUpcoming)
+			bills.GET("/overdue", r.billHandler.GetOverdue)
+			bills.GET("/:id", r.billHandler.GetBill)
+			bills.PUT("/:id", r.billHandler.UpdateBill)
+			bills.DELETE("/:id", r.billHandler.DeleteBill)
+			bills.POST("/:id/pay", r.idempotencyMiddleware.Idempotent(), r.billHandler.PayBill)
+		}
+
+		// Notification routes (protected)
+		notifications := v1.Group("/notifications")
+		notifications.Use(r.authMiddleware.RequireAuth())
+		{
+			notifications.GET("", r.notificationHandler.GetNotifications)
+			notifications.PATCH("", r.notificationHandler.MarkNotifications)
+			notifications.PATCH("/:id", r.notificationHandler.MarkNotification)
+		}
+
+		// Audit trail of the caller's own data (protected)
+		auditLog := v1.Group("/audit")
+		auditLog.Use(r.authMiddleware.RequireAuth())
+		{
+			auditLog.GET("", r.auditHandler.GetAuditLog)
+		}
+
+		// Webhook routes (protected)
+		webhooks := v1.Group("/webhooks")
+		webhooks.Use(r.authMiddleware.RequireAuth())
+		{
+			webhooks.POST("", r.webhookHandler.CreateWebhook)
+			webhooks.GET("", r.webhookHandler.GetWebhooks)
+			webhooks.GET("/:id", r.webhookHandler.GetWebhook)
+			webhooks.PUT("/:id", r.webhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", r.webhookHandler.DeleteWebhook)
+			webhooks.POST("/:id/rotate-secret", r.webhookHandler.RotateSecret)
+			webhooks.POST("/:id/ping", r.webhookHandler.Ping)
+			webhooks.GET("/:id/deliveries", r.webhookHandler.GetDeliveries)
 		}
 
 		// Category routes (protected)
@@ -118,9 +338,55 @@ func (r *Router) setupRoutes() {
 		{
 			categories.GET("", r.categoryHandler.ListCategories)
 		}
+
+		// Net worth routes (protected)
+		networth := v1.Group("/networth")
+		networth.Use(r.authMiddleware.RequireAuth())
+		{
+			networth.GET("", r.netWorthHandler.GetHistory)
+			networth.POST("/recompute", r.netWorthHandler.Recompute)
+			networth.POST("/snapshots", r.netWorthHandler.TakeSnapshot)
+		}
+
+		// Reconciliation routes (protected)
+		reconciliation := v1.Group("/reconciliation")
+		reconciliation.Use(r.authMiddleware.RequireAuth())
+		{
+			reconciliation.GET("", r.reconciliationHandler.Reconcile)
+			reconciliation.POST("/cards/:id/adjust", r.reconciliationHandler.AdjustBalance)
+		}
+
+		// Admin routes (admin and support; changes are admin only)
+		admin := v1.Group("/admin")
+		admin.Use(r.authMiddleware.RequireAuth(), r.authMiddleware.RequireRole(entity.RoleAdmin, entity.RoleSupport))
+		{
+			admin.GET("/users", r.adminHandler.ListUsers)
+			admin.GET("/users/:id", r.adminHandler.GetUser)
+			admin.GET("/categories/usage", r.adminHandler.GetCategoryUsage)
+			admin.GET("/audit", r.auditHandler.GetAdminAuditLog)
+
+			adminOnly := admin.Group("", r.authMiddleware.RequireRole(entity.RoleAdmin))
+			adminOnly.POST("/users/:id/deactivate", r.adminHandler.DeactivateUser)
+			adminOnly.POST("/users/:id/reactivate", r.adminHandler.ReactivateUser)
+			adminOnly.PUT("/users/:id/role", r.adminHandler.SetRole)
+			adminOnly.POST("/users/:id/logout", r.adminHandler.ForceLogout)
+		}
 	}
 }
 
 func (r *Router) GetEngine() *gin.Engine {
 	return r.engine
 }
+
+// health godoc
+// @Summary Health check
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health [get]
+func health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"service": "personal-finance-management",
+	})
+}
@@ -0,0 +1,95 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pfn-backend/internal/config"
+	"pfn-backend/internal/docs"
+	"pfn-backend/internal/middleware"
+	"pfn-backend/internal/router"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRouter registers every route with nil handlers; only the route table is
+// of interest
+func newRouter() *router.Router {
+	gin.SetMode(gin.TestMode)
+	noop := func(c *gin.Context) { c.Next() }
+	return router.New(&config.Config{},
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		&middleware.AuthMiddleware{}, &middleware.IdempotencyMiddleware{},
+		noop, noop, noop, noop)
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func TestOpenAPIDocumentsEveryRoute(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(docs.OpenAPI, &spec))
+
+	documented := make(map[string]bool)
+	for path, item := range spec.Paths {
+		for method := range item {
+			switch method {
+			case "get", "post", "put", "patch", "delete":
+				documented[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+
+	served := make(map[string]bool)
+	for _, route := range newRouter().GetEngine().Routes() {
+		// The documentation does not describe itself
+		if strings.HasPrefix(route.Path, "/api/docs") {
+			continue
+		}
+		served[route.Method+" "+pathParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	var undocumented, stale []string
+	for route := range served {
+		if !documented[route] {
+			undocumented = append(undocumented, route)
+		}
+	}
+	for route := range documented {
+		if !served[route] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(undocumented)
+	sort.Strings(stale)
+
+	hint := "annotate the handlers and run go generate ./internal/docs"
+	assert.Empty(t, undocumented, "routes missing from the OpenAPI document; "+hint)
+	assert.Empty(t, stale, "OpenAPI operations without a route; "+hint)
+}
+
+func TestOpenAPIIsServed(t *testing.T) {
+	engine := newRouter().GetEngine()
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs/ui/swagger-initializer.js", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/api/docs/openapi.json")
+}
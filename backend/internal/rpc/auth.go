@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/rpc/pfnv1"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type authServer struct {
+	pfnv1.UnimplementedAuthServiceServer
+	authService auth.Service
+}
+
+func (s *authServer) Login(ctx context.Context, req *pfnv1.LoginRequest) (*pfnv1.AuthResponse, error) {
+	login := auth.LoginRequest{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+	if err := validate(&login); err != nil {
+		return nil, err
+	}
+	login.IPAddress, _ = ctx.Value(logger.ClientIPKey).(string)
+	if agent := metadata.ValueFromIncomingContext(ctx, "user-agent"); len(agent) > 0 {
+		login.UserAgent = agent[0]
+	}
+
+	resp, err := s.authService.Login(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	return &pfnv1.AuthResponse{
+		User: &pfnv1.User{
+			Id:        resp.User.ID.String(),
+			Email:     resp.User.Email,
+			FirstName: resp.User.FirstName,
+			LastName:  resp.User.LastName,
+			FullName:  resp.User.FullName,
+			Role:      resp.User.Role,
+			IsActive:  resp.User.IsActive,
+		},
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+	}, nil
+}
+
+func (s *authServer) RefreshToken(ctx context.Context, req *pfnv1.RefreshTokenRequest) (*pfnv1.TokenResponse, error) {
+	refresh := auth.RefreshTokenRequest{RefreshToken: req.RefreshToken}
+	if err := validate(&refresh); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.authService.RefreshToken(ctx, refresh)
+	if err != nil {
+		return nil, err
+	}
+	return &pfnv1.TokenResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+	}, nil
+}
+
+func (s *authServer) Logout(ctx context.Context, req *pfnv1.LogoutRequest) (*pfnv1.LogoutResponse, error) {
+	if err := s.authService.Logout(ctx, userIDFrom(ctx)); err != nil {
+		return nil, err
+	}
+	return &pfnv1.LogoutResponse{}, nil
+}
@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/rpc/pfnv1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type cardServer struct {
+	pfnv1.UnimplementedCardServiceServer
+	cardService card.Service
+}
+
+func (s *cardServer) ListCards(ctx context.Context, req *pfnv1.ListCardsRequest) (*pfnv1.ListCardsResponse, error) {
+	filter := card.CardListFilter{AccountType: req.AccountType}
+	if err := validate(&filter); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardService.GetUserCards(ctx, userIDFrom(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pfnv1.ListCardsResponse{Cards: make([]*pfnv1.Card, len(cards))}
+	for i := range cards {
+		resp.Cards[i] = toCard(&cards[i])
+	}
+	return resp, nil
+}
+
+func (s *cardServer) GetCard(ctx context.Context, req *pfnv1.GetCardRequest) (*pfnv1.Card, error) {
+	c, err := s.cardService.GetCard(ctx, req.Id, userIDFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toCard(c), nil
+}
+
+func (s *cardServer) CreateCard(ctx context.Context, req *pfnv1.CreateCardRequest) (*pfnv1.Card, error) {
+	create := card.CreateCardRequest{
+		CardNumber: req.CardNumber,
+		HolderName: req.HolderName,
+		ExpiryDate: req.ExpiryDate,
+		CardType:   req.CardType,
+		Alias:      req.Alias,
+		Balance:    req.Balance,
+		Color:      req.Color,
+	}
+	if err := validate(&create); err != nil {
+		return nil, err
+	}
+
+	c, err := s.cardService.CreateCard(ctx, userIDFrom(ctx), create)
+	if err != nil {
+		return nil, err
+	}
+	return toCard(c), nil
+}
+
+func (s *cardServer) UpdateCard(ctx context.Context, req *pfnv1.UpdateCardRequest) (*pfnv1.Card, error) {
+	update := card.UpdateCardRequest{
+		Alias:       req.Alias,
+		Color:       req.Color,
+		CreditLimit: req.CreditLimit,
+	}
+	if err := validate(&update); err != nil {
+		return nil, err
+	}
+
+	c, err := s.cardService.UpdateCard(ctx, req.Id, userIDFrom(ctx), update, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return toCard(c), nil
+}
+
+func (s *cardServer) ToggleFreeze(ctx context.Context, req *pfnv1.ToggleFreezeRequest) (*pfnv1.Card, error) {
+	c, err := s.cardService.ToggleFreeze(ctx, req.Id, userIDFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toCard(c), nil
+}
+
+func (s *cardServer) DeleteCard(ctx context.Context, req *pfnv1.DeleteCardRequest) (*pfnv1.DeleteCardResponse, error) {
+	if err := s.cardService.DeleteCard(ctx, req.Id, userIDFrom(ctx), req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	return &pfnv1.DeleteCardResponse{}, nil
+}
+
+func toCard(c *card.CardResponse) *pfnv1.Card {
+	return &pfnv1.Card{
+		Id:              c.ID,
+		UserId:          c.UserID.String(),
+		AccountType:     c.AccountType,
+		CardNumberLast4: c.CardNumberLast4,
+		HolderName:      c.HolderName,
+		ExpiryDate:      c.ExpiryDate,
+		CardType:        c.CardType,
+		Alias:           c.Alias,
+		Balance:         c.Balance,
+		IsLiability:     c.IsLiability,
+		CreditLimit:     c.CreditLimit,
+		AvailableCredit: c.AvailableCredit,
+		Color:           c.Color,
+		IsFrozen:        c.IsFrozen,
+		HouseholdId:     c.HouseholdID,
+		IsExpired:       c.IsExpired,
+		CreatedAt:       timestamppb.New(c.CreatedAt),
+		UpdatedAt:       timestamppb.New(c.UpdatedAt),
+		Version:         c.Version,
+	}
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"context"
+	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/rpc/pfnv1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type categoryServer struct {
+	pfnv1.UnimplementedCategoryServiceServer
+	categoryService category.Service
+}
+
+func (s *categoryServer) ListCategories(ctx context.Context, req *pfnv1.ListCategoriesRequest) (*pfnv1.ListCategoriesResponse, error) {
+	categories, err := s.categoryService.ListCategories(ctx, req.CategoryType)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pfnv1.ListCategoriesResponse{Categories: make([]*pfnv1.Category, len(categories))}
+	for i, c := range categories {
+		resp.Categories[i] = &pfnv1.Category{
+			Id:           c.ID,
+			Name:         c.Name,
+			CategoryType: c.CategoryType,
+			Icon:         c.Icon,
+			IsSystem:     c.IsSystem,
+			CreatedAt:    timestamppb.New(c.CreatedAt),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"pfn-backend/internal/pkg/apperror"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the ErrorInfo domain of errors from this API
+const ErrorDomain = "pfn-backend"
+
+// grpcCodes maps error codes to their gRPC equivalent. Conflicts are mostly
+// about the state of a record (a frozen card, a pending invitation), and a
+// stale version means the caller should re-read and retry.
+var grpcCodes = map[apperror.Code]codes.Code{
+	apperror.CodeValidation:   codes.InvalidArgument,
+	apperror.CodeUnauthorized: codes.Unauthenticated,
+	apperror.CodeForbidden:    codes.PermissionDenied,
+	apperror.CodeNotFound:     codes.NotFound,
+	apperror.CodeConflict:     codes.FailedPrecondition,
+	apperror.CodeStale:        codes.Aborted,
+}
+
+// appStatus renders an application error. The REST error code is sent as the
+// ErrorInfo reason and field errors as a BadRequest.
+func appStatus(appErr *apperror.Error, message string) *status.Status {
+	code, ok := grpcCodes[appErr.Code]
+	if !ok {
+		code = codes.Internal
+	}
+	st := status.New(code, message)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: string(appErr.Code), Domain: ErrorDomain}}
+	if len(appErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range appErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if detailed, err := st.WithDetails(details...); err == nil {
+		return detailed
+	}
+	return st
+}
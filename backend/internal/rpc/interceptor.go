@@ -0,0 +1,178 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/jwt"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/rpc/pfnv1"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the response header carrying the request ID
+const RequestIDHeader = "x-request-id"
+
+// publicMethods are the calls made without an access token
+var publicMethods = map[string]bool{
+	pfnv1.AuthService_Login_FullMethodName:        true,
+	pfnv1.AuthService_RefreshToken_FullMethodName: true,
+}
+
+// interceptors do for every call what the HTTP middleware does for requests:
+// assign a request ID, recover panics, authenticate, render errors and log
+type interceptors struct {
+	jwtManager *jwt.JWTManager
+	userRepo   repository.UserRepository
+	logger     *logger.Logger
+}
+
+func (i *interceptors) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var resp interface{}
+	err := i.intercept(ctx, info.FullMethod, func(ctx context.Context) (err error) {
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (i *interceptors) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return i.intercept(ss.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	})
+}
+
+// serverStream hands stream handlers the context the interceptor built
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (i *interceptors) intercept(ctx context.Context, method string, call func(context.Context) error) error {
+	start := time.Now()
+
+	requestID := uuid.New().String()
+	clientIP := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+
+	// Services only see the request context
+	ctx = context.WithValue(ctx, logger.RequestIDKey, requestID)
+	ctx = context.WithValue(ctx, logger.ClientIPKey, clientIP)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+	st := i.status(method, requestID, i.run(ctx, method, call))
+
+	i.logger.Info("gRPC Request",
+		logger.String("method", method),
+		logger.String("request_id", requestID),
+		logger.String("code", st.Code().String()),
+		logger.Duration("duration", time.Since(start)),
+		logger.String("client_ip", clientIP),
+	)
+	return st.Err()
+}
+
+func (i *interceptors) run(ctx context.Context, method string, call func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Error("Panic recovered",
+				logger.Any("error", r),
+				logger.String("method", method),
+			)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if !publicMethods[method] {
+		userID, err := i.authenticate(ctx)
+		if err != nil {
+			return err
+		}
+		ctx = context.WithValue(ctx, userIDKey{}, userID)
+	}
+	return call(ctx)
+}
+
+// authenticate validates the access token in the authorization metadata.
+// API keys are refused, as on the session-only REST routes.
+func (i *interceptors) authenticate(ctx context.Context) (uuid.UUID, error) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return uuid.Nil, apperror.Unauthorized("missing authorization metadata")
+	}
+
+	// Extract token from "Bearer <token>"
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return uuid.Nil, apperror.Unauthorized("invalid authorization format")
+	}
+	if strings.HasPrefix(parts[1], apikey.KeyPrefix) {
+		return uuid.Nil, apperror.Forbidden("API keys cannot access the gRPC API")
+	}
+
+	claims, err := i.jwtManager.ValidateAccessToken(parts[1])
+	if err != nil {
+		i.logger.Error("Token validation failed", logger.Error(err))
+		return uuid.Nil, apperror.Unauthorized("invalid or expired token")
+	}
+
+	// Deactivated and signed out users lose access before their token expires
+	user, err := i.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil || !user.IsActive {
+		return uuid.Nil, apperror.Unauthorized("account is deactivated")
+	}
+	if !user.AcceptsToken(claims.IssuedAtTime()) {
+		return uuid.Nil, apperror.Unauthorized("session has been signed out")
+	}
+	return claims.UserID, nil
+}
+
+type userIDKey struct{}
+
+// userIDFrom returns the caller the interceptor authenticated
+func userIDFrom(ctx context.Context) uuid.UUID {
+	userID, _ := ctx.Value(userIDKey{}).(uuid.UUID)
+	return userID
+}
+
+// status renders err like the error middleware: errors from pkg/apperror keep
+// their code and field errors, gRPC statuses pass through, and anything else
+// is logged and answered with a bare INTERNAL
+func (i *interceptors) status(method, requestID string, err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	if appErr, ok := apperror.As(err); ok {
+		return appStatus(appErr, err.Error())
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	i.logger.Error("Request failed",
+		logger.String("method", method),
+		logger.String("request_id", requestID),
+		logger.Error(err),
+	)
+	return status.New(codes.Internal, "internal server error")
+}
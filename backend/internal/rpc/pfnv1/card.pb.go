@@ -0,0 +1,803 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pfn/v1/card.proto
+
+package pfnv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Card is an account; payment cards carry the card fields
+type Card struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Card, Cash, Checking, Savings, Credit or Loan
+	AccountType     string `protobuf:"bytes,3,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	CardNumberLast4 string `protobuf:"bytes,4,opt,name=card_number_last4,json=cardNumberLast4,proto3" json:"card_number_last4,omitempty"`
+	HolderName      string `protobuf:"bytes,5,opt,name=holder_name,json=holderName,proto3" json:"holder_name,omitempty"`
+	// MM/YYYY
+	ExpiryDate string `protobuf:"bytes,6,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	CardType   string `protobuf:"bytes,7,opt,name=card_type,json=cardType,proto3" json:"card_type,omitempty"`
+	Alias      string `protobuf:"bytes,8,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Minor units; the amount owed for Credit and Loan accounts
+	Balance         int64                  `protobuf:"varint,9,opt,name=balance,proto3" json:"balance,omitempty"`
+	IsLiability     bool                   `protobuf:"varint,10,opt,name=is_liability,json=isLiability,proto3" json:"is_liability,omitempty"`
+	CreditLimit     *int64                 `protobuf:"varint,11,opt,name=credit_limit,json=creditLimit,proto3,oneof" json:"credit_limit,omitempty"`
+	AvailableCredit *int64                 `protobuf:"varint,12,opt,name=available_credit,json=availableCredit,proto3,oneof" json:"available_credit,omitempty"`
+	Color           string                 `protobuf:"bytes,13,opt,name=color,proto3" json:"color,omitempty"`
+	IsFrozen        bool                   `protobuf:"varint,14,opt,name=is_frozen,json=isFrozen,proto3" json:"is_frozen,omitempty"`
+	HouseholdId     *int64                 `protobuf:"varint,15,opt,name=household_id,json=householdId,proto3,oneof" json:"household_id,omitempty"`
+	IsExpired       bool                   `protobuf:"varint,16,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version         int64                  `protobuf:"varint,19,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	mi := &file_pfn_v1_card_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Card) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Card) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Card) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *Card) GetCardNumberLast4() string {
+	if x != nil {
+		return x.CardNumberLast4
+	}
+	return ""
+}
+
+func (x *Card) GetHolderName() string {
+	if x != nil {
+		return x.HolderName
+	}
+	return ""
+}
+
+func (x *Card) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *Card) GetCardType() string {
+	if x != nil {
+		return x.CardType
+	}
+	return ""
+}
+
+func (x *Card) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Card) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Card) GetIsLiability() bool {
+	if x != nil {
+		return x.IsLiability
+	}
+	return false
+}
+
+func (x *Card) GetCreditLimit() int64 {
+	if x != nil && x.CreditLimit != nil {
+		return *x.CreditLimit
+	}
+	return 0
+}
+
+func (x *Card) GetAvailableCredit() int64 {
+	if x != nil && x.AvailableCredit != nil {
+		return *x.AvailableCredit
+	}
+	return 0
+}
+
+func (x *Card) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Card) GetIsFrozen() bool {
+	if x != nil {
+		return x.IsFrozen
+	}
+	return false
+}
+
+func (x *Card) GetHouseholdId() int64 {
+	if x != nil && x.HouseholdId != nil {
+		return *x.HouseholdId
+	}
+	return 0
+}
+
+func (x *Card) GetIsExpired() bool {
+	if x != nil {
+		return x.IsExpired
+	}
+	return false
+}
+
+func (x *Card) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Card) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Card) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountType   *string                `protobuf:"bytes,1,opt,name=account_type,json=accountType,proto3,oneof" json:"account_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsRequest) Reset() {
+	*x = ListCardsRequest{}
+	mi := &file_pfn_v1_card_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsRequest) ProtoMessage() {}
+
+func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListCardsRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCardsRequest) GetAccountType() string {
+	if x != nil && x.AccountType != nil {
+		return *x.AccountType
+	}
+	return ""
+}
+
+type ListCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsResponse) Reset() {
+	*x = ListCardsResponse{}
+	mi := &file_pfn_v1_card_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsResponse) ProtoMessage() {}
+
+func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListCardsResponse) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCardsResponse) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type GetCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardRequest) Reset() {
+	*x = GetCardRequest{}
+	mi := &file_pfn_v1_card_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardRequest) ProtoMessage() {}
+
+func (x *GetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardRequest.ProtoReflect.Descriptor instead.
+func (*GetCardRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCardRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateCardRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CardNumber string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	HolderName string                 `protobuf:"bytes,2,opt,name=holder_name,json=holderName,proto3" json:"holder_name,omitempty"`
+	// MM/YYYY
+	ExpiryDate string `protobuf:"bytes,3,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	// Detected from the card number when empty
+	CardType      string `protobuf:"bytes,4,opt,name=card_type,json=cardType,proto3" json:"card_type,omitempty"`
+	Alias         string `protobuf:"bytes,5,opt,name=alias,proto3" json:"alias,omitempty"`
+	Balance       int64  `protobuf:"varint,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	Color         string `protobuf:"bytes,7,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCardRequest) Reset() {
+	*x = CreateCardRequest{}
+	mi := &file_pfn_v1_card_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCardRequest) ProtoMessage() {}
+
+func (x *CreateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCardRequest.ProtoReflect.Descriptor instead.
+func (*CreateCardRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateCardRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetHolderName() string {
+	if x != nil {
+		return x.HolderName
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetExpiryDate() string {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetCardType() string {
+	if x != nil {
+		return x.CardType
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *CreateCardRequest) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *CreateCardRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type UpdateCardRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Alias           string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	Color           string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	CreditLimit     *int64                 `protobuf:"varint,4,opt,name=credit_limit,json=creditLimit,proto3,oneof" json:"credit_limit,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateCardRequest) Reset() {
+	*x = UpdateCardRequest{}
+	mi := &file_pfn_v1_card_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardRequest) ProtoMessage() {}
+
+func (x *UpdateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCardRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCardRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCardRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *UpdateCardRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *UpdateCardRequest) GetCreditLimit() int64 {
+	if x != nil && x.CreditLimit != nil {
+		return *x.CreditLimit
+	}
+	return 0
+}
+
+func (x *UpdateCardRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type ToggleFreezeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToggleFreezeRequest) Reset() {
+	*x = ToggleFreezeRequest{}
+	mi := &file_pfn_v1_card_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToggleFreezeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToggleFreezeRequest) ProtoMessage() {}
+
+func (x *ToggleFreezeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToggleFreezeRequest.ProtoReflect.Descriptor instead.
+func (*ToggleFreezeRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ToggleFreezeRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteCardRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteCardRequest) Reset() {
+	*x = DeleteCardRequest{}
+	mi := &file_pfn_v1_card_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCardRequest) ProtoMessage() {}
+
+func (x *DeleteCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCardRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCardRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteCardRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCardResponse) Reset() {
+	*x = DeleteCardResponse{}
+	mi := &file_pfn_v1_card_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCardResponse) ProtoMessage() {}
+
+func (x *DeleteCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_card_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCardResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCardResponse) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_card_proto_rawDescGZIP(), []int{8}
+}
+
+var File_pfn_v1_card_proto protoreflect.FileDescriptor
+
+const file_pfn_v1_card_proto_rawDesc = "" +
+	"\n" +
+	"\x11pfn/v1/card.proto\x12\x06pfn.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc9\x05\n" +
+	"\x04Card\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\faccount_type\x18\x03 \x01(\tR\vaccountType\x12*\n" +
+	"\x11card_number_last4\x18\x04 \x01(\tR\x0fcardNumberLast4\x12\x1f\n" +
+	"\vholder_name\x18\x05 \x01(\tR\n" +
+	"holderName\x12\x1f\n" +
+	"\vexpiry_date\x18\x06 \x01(\tR\n" +
+	"expiryDate\x12\x1b\n" +
+	"\tcard_type\x18\a \x01(\tR\bcardType\x12\x14\n" +
+	"\x05alias\x18\b \x01(\tR\x05alias\x12\x18\n" +
+	"\abalance\x18\t \x01(\x03R\abalance\x12!\n" +
+	"\fis_liability\x18\n" +
+	" \x01(\bR\visLiability\x12&\n" +
+	"\fcredit_limit\x18\v \x01(\x03H\x00R\vcreditLimit\x88\x01\x01\x12.\n" +
+	"\x10available_credit\x18\f \x01(\x03H\x01R\x0favailableCredit\x88\x01\x01\x12\x14\n" +
+	"\x05color\x18\r \x01(\tR\x05color\x12\x1b\n" +
+	"\tis_frozen\x18\x0e \x01(\bR\bisFrozen\x12&\n" +
+	"\fhousehold_id\x18\x0f \x01(\x03H\x02R\vhouseholdId\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"is_expired\x18\x10 \x01(\bR\tisExpired\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x13 \x01(\x03R\aversionB\x0f\n" +
+	"\r_credit_limitB\x13\n" +
+	"\x11_available_creditB\x0f\n" +
+	"\r_household_id\"K\n" +
+	"\x10ListCardsRequest\x12&\n" +
+	"\faccount_type\x18\x01 \x01(\tH\x00R\vaccountType\x88\x01\x01B\x0f\n" +
+	"\r_account_type\"7\n" +
+	"\x11ListCardsResponse\x12\"\n" +
+	"\x05cards\x18\x01 \x03(\v2\f.pfn.v1.CardR\x05cards\" \n" +
+	"\x0eGetCardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xd9\x01\n" +
+	"\x11CreateCardRequest\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber\x12\x1f\n" +
+	"\vholder_name\x18\x02 \x01(\tR\n" +
+	"holderName\x12\x1f\n" +
+	"\vexpiry_date\x18\x03 \x01(\tR\n" +
+	"expiryDate\x12\x1b\n" +
+	"\tcard_type\x18\x04 \x01(\tR\bcardType\x12\x14\n" +
+	"\x05alias\x18\x05 \x01(\tR\x05alias\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x03R\abalance\x12\x14\n" +
+	"\x05color\x18\a \x01(\tR\x05color\"\xcd\x01\n" +
+	"\x11UpdateCardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12&\n" +
+	"\fcredit_limit\x18\x04 \x01(\x03H\x00R\vcreditLimit\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x05 \x01(\x03H\x01R\x0fexpectedVersion\x88\x01\x01B\x0f\n" +
+	"\r_credit_limitB\x13\n" +
+	"\x11_expected_version\"%\n" +
+	"\x13ToggleFreezeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"h\n" +
+	"\x11DeleteCardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x14\n" +
+	"\x12DeleteCardResponse2\xee\x02\n" +
+	"\vCardService\x12@\n" +
+	"\tListCards\x12\x18.pfn.v1.ListCardsRequest\x1a\x19.pfn.v1.ListCardsResponse\x12/\n" +
+	"\aGetCard\x12\x16.pfn.v1.GetCardRequest\x1a\f.pfn.v1.Card\x125\n" +
+	"\n" +
+	"CreateCard\x12\x19.pfn.v1.CreateCardRequest\x1a\f.pfn.v1.Card\x125\n" +
+	"\n" +
+	"UpdateCard\x12\x19.pfn.v1.UpdateCardRequest\x1a\f.pfn.v1.Card\x129\n" +
+	"\fToggleFreeze\x12\x1b.pfn.v1.ToggleFreezeRequest\x1a\f.pfn.v1.Card\x12C\n" +
+	"\n" +
+	"DeleteCard\x12\x19.pfn.v1.DeleteCardRequest\x1a\x1a.pfn.v1.DeleteCardResponseB&Z$pfn-backend/internal/rpc/pfnv1;pfnv1b\x06proto3"
+
+var (
+	file_pfn_v1_card_proto_rawDescOnce sync.Once
+	file_pfn_v1_card_proto_rawDescData []byte
+)
+
+func file_pfn_v1_card_proto_rawDescGZIP() []byte {
+	file_pfn_v1_card_proto_rawDescOnce.Do(func() {
+		file_pfn_v1_card_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pfn_v1_card_proto_rawDesc), len(file_pfn_v1_card_proto_rawDesc)))
+	})
+	return file_pfn_v1_card_proto_rawDescData
+}
+
+var file_pfn_v1_card_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pfn_v1_card_proto_goTypes = []any{
+	(*Card)(nil),                  // 0: pfn.v1.Card
+	(*ListCardsRequest)(nil),      // 1: pfn.v1.ListCardsRequest
+	(*ListCardsResponse)(nil),     // 2: pfn.v1.ListCardsResponse
+	(*GetCardRequest)(nil),        // 3: pfn.v1.GetCardRequest
+	(*CreateCardRequest)(nil),     // 4: pfn.v1.CreateCardRequest
+	(*UpdateCardRequest)(nil),     // 5: pfn.v1.UpdateCardRequest
+	(*ToggleFreezeRequest)(nil),   // 6: pfn.v1.ToggleFreezeRequest
+	(*DeleteCardRequest)(nil),     // 7: pfn.v1.DeleteCardRequest
+	(*DeleteCardResponse)(nil),    // 8: pfn.v1.DeleteCardResponse
+	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+}
+var file_pfn_v1_card_proto_depIdxs = []int32{
+	9, // 0: pfn.v1.Card.created_at:type_name -> google.protobuf.Timestamp
+	9, // 1: pfn.v1.Card.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: pfn.v1.ListCardsResponse.cards:type_name -> pfn.v1.Card
+	1, // 3: pfn.v1.CardService.ListCards:input_type -> pfn.v1.ListCardsRequest
+	3, // 4: pfn.v1.CardService.GetCard:input_type -> pfn.v1.GetCardRequest
+	4, // 5: pfn.v1.CardService.CreateCard:input_type -> pfn.v1.CreateCardRequest
+	5, // 6: pfn.v1.CardService.UpdateCard:input_type -> pfn.v1.UpdateCardRequest
+	6, // 7: pfn.v1.CardService.ToggleFreeze:input_type -> pfn.v1.ToggleFreezeRequest
+	7, // 8: pfn.v1.CardService.DeleteCard:input_type -> pfn.v1.DeleteCardRequest
+	2, // 9: pfn.v1.CardService.ListCards:output_type -> pfn.v1.ListCardsResponse
+	0, // 10: pfn.v1.CardService.GetCard:output_type -> pfn.v1.Card
+	0, // 11: pfn.v1.CardService.CreateCard:output_type -> pfn.v1.Card
+	0, // 12: pfn.v1.CardService.UpdateCard:output_type -> pfn.v1.Card
+	0, // 13: pfn.v1.CardService.ToggleFreeze:output_type -> pfn.v1.Card
+	8, // 14: pfn.v1.CardService.DeleteCard:output_type -> pfn.v1.DeleteCardResponse
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_pfn_v1_card_proto_init() }
+func file_pfn_v1_card_proto_init() {
+	if File_pfn_v1_card_proto != nil {
+		return
+	}
+	file_pfn_v1_card_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pfn_v1_card_proto_msgTypes[1].OneofWrappers = []any{}
+	file_pfn_v1_card_proto_msgTypes[5].OneofWrappers = []any{}
+	file_pfn_v1_card_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pfn_v1_card_proto_rawDesc), len(file_pfn_v1_card_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pfn_v1_card_proto_goTypes,
+		DependencyIndexes: file_pfn_v1_card_proto_depIdxs,
+		MessageInfos:      file_pfn_v1_card_proto_msgTypes,
+	}.Build()
+	File_pfn_v1_card_proto = out.File
+	file_pfn_v1_card_proto_goTypes = nil
+	file_pfn_v1_card_proto_depIdxs = nil
+}
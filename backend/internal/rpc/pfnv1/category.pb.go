@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pfn/v1/category.proto
+
+package pfnv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Income, Expense or Transfer
+	CategoryType  string                 `protobuf:"bytes,3,opt,name=category_type,json=categoryType,proto3" json:"category_type,omitempty"`
+	Icon          string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	IsSystem      bool                   `protobuf:"varint,5,opt,name=is_system,json=isSystem,proto3" json:"is_system,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_pfn_v1_category_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_category_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_category_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Category) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetCategoryType() string {
+	if x != nil {
+		return x.CategoryType
+	}
+	return ""
+}
+
+func (x *Category) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Category) GetIsSystem() bool {
+	if x != nil {
+		return x.IsSystem
+	}
+	return false
+}
+
+func (x *Category) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryType  *string                `protobuf:"bytes,1,opt,name=category_type,json=categoryType,proto3,oneof" json:"category_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_pfn_v1_category_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_category_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_category_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCategoriesRequest) GetCategoryType() string {
+	if x != nil && x.CategoryType != nil {
+		return *x.CategoryType
+	}
+	return ""
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_pfn_v1_category_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_category_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_category_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+var File_pfn_v1_category_proto protoreflect.FileDescriptor
+
+const file_pfn_v1_category_proto_rawDesc = "" +
+	"\n" +
+	"\x15pfn/v1/category.proto\x12\x06pfn.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbf\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rcategory_type\x18\x03 \x01(\tR\fcategoryType\x12\x12\n" +
+	"\x04icon\x18\x04 \x01(\tR\x04icon\x12\x1b\n" +
+	"\tis_system\x18\x05 \x01(\bR\bisSystem\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"S\n" +
+	"\x15ListCategoriesRequest\x12(\n" +
+	"\rcategory_type\x18\x01 \x01(\tH\x00R\fcategoryType\x88\x01\x01B\x10\n" +
+	"\x0e_category_type\"J\n" +
+	"\x16ListCategoriesResponse\x120\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x10.pfn.v1.CategoryR\n" +
+	"categories2b\n" +
+	"\x0fCategoryService\x12O\n" +
+	"\x0eListCategories\x12\x1d.pfn.v1.ListCategoriesRequest\x1a\x1e.pfn.v1.ListCategoriesResponseB&Z$pfn-backend/internal/rpc/pfnv1;pfnv1b\x06proto3"
+
+var (
+	file_pfn_v1_category_proto_rawDescOnce sync.Once
+	file_pfn_v1_category_proto_rawDescData []byte
+)
+
+func file_pfn_v1_category_proto_rawDescGZIP() []byte {
+	file_pfn_v1_category_proto_rawDescOnce.Do(func() {
+		file_pfn_v1_category_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pfn_v1_category_proto_rawDesc), len(file_pfn_v1_category_proto_rawDesc)))
+	})
+	return file_pfn_v1_category_proto_rawDescData
+}
+
+var file_pfn_v1_category_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pfn_v1_category_proto_goTypes = []any{
+	(*Category)(nil),               // 0: pfn.v1.Category
+	(*ListCategoriesRequest)(nil),  // 1: pfn.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil), // 2: pfn.v1.ListCategoriesResponse
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+}
+var file_pfn_v1_category_proto_depIdxs = []int32{
+	3, // 0: pfn.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: pfn.v1.ListCategoriesResponse.categories:type_name -> pfn.v1.Category
+	1, // 2: pfn.v1.CategoryService.ListCategories:input_type -> pfn.v1.ListCategoriesRequest
+	2, // 3: pfn.v1.CategoryService.ListCategories:output_type -> pfn.v1.ListCategoriesResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_pfn_v1_category_proto_init() }
+func file_pfn_v1_category_proto_init() {
+	if File_pfn_v1_category_proto != nil {
+		return
+	}
+	file_pfn_v1_category_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pfn_v1_category_proto_rawDesc), len(file_pfn_v1_category_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pfn_v1_category_proto_goTypes,
+		DependencyIndexes: file_pfn_v1_category_proto_depIdxs,
+		MessageInfos:      file_pfn_v1_category_proto_msgTypes,
+	}.Build()
+	File_pfn_v1_category_proto = out.File
+	file_pfn_v1_category_proto_goTypes = nil
+	file_pfn_v1_category_proto_depIdxs = nil
+}
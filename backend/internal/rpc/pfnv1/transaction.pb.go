@@ -0,0 +1,1357 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pfn/v1/transaction.proto
+
+package pfnv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Transaction struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId     string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CardId     int64                  `protobuf:"varint,3,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CategoryId *int64                 `protobuf:"varint,4,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Category   *CategoryInfo          `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	PayeeId    *int64                 `protobuf:"varint,6,opt,name=payee_id,json=payeeId,proto3,oneof" json:"payee_id,omitempty"`
+	Payee      *PayeeInfo             `protobuf:"bytes,7,opt,name=payee,proto3" json:"payee,omitempty"`
+	// Income, Expense, Transfer or Adjustment
+	TransactionType string `protobuf:"bytes,8,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"`
+	// Minor units
+	Amount          int64                  `protobuf:"varint,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	TransactionDate *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	Description     string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	Tags            []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	Splits          []*Split               `protobuf:"bytes,13,rep,name=splits,proto3" json:"splits,omitempty"`
+	// Household member who posted it
+	CreatedBy *string                `protobuf:"bytes,14,opt,name=created_by,json=createdBy,proto3,oneof" json:"created_by,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Version   int64                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	// Set on creation when the transaction looks like a double-post
+	Warnings           []string             `protobuf:"bytes,17,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	PossibleDuplicates []*PossibleDuplicate `protobuf:"bytes,18,rep,name=possible_duplicates,json=possibleDuplicates,proto3" json:"possible_duplicates,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Transaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Transaction) GetCardId() int64 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *Transaction) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *Transaction) GetCategory() *CategoryInfo {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *Transaction) GetPayeeId() int64 {
+	if x != nil && x.PayeeId != nil {
+		return *x.PayeeId
+	}
+	return 0
+}
+
+func (x *Transaction) GetPayee() *PayeeInfo {
+	if x != nil {
+		return x.Payee
+	}
+	return nil
+}
+
+func (x *Transaction) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransactionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return nil
+}
+
+func (x *Transaction) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Transaction) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Transaction) GetSplits() []*Split {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+func (x *Transaction) GetCreatedBy() string {
+	if x != nil && x.CreatedBy != nil {
+		return *x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Transaction) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *Transaction) GetPossibleDuplicates() []*PossibleDuplicate {
+	if x != nil {
+		return x.PossibleDuplicates
+	}
+	return nil
+}
+
+type CategoryInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Icon          string                 `protobuf:"bytes,3,opt,name=icon,proto3" json:"icon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryInfo) Reset() {
+	*x = CategoryInfo{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryInfo) ProtoMessage() {}
+
+func (x *CategoryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryInfo.ProtoReflect.Descriptor instead.
+func (*CategoryInfo) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CategoryInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryInfo) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+type PayeeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayeeInfo) Reset() {
+	*x = PayeeInfo{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayeeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayeeInfo) ProtoMessage() {}
+
+func (x *PayeeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayeeInfo.ProtoReflect.Descriptor instead.
+func (*PayeeInfo) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PayeeInfo) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PayeeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Split struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Category      *CategoryInfo          `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Note          string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Split) Reset() {
+	*x = Split{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Split) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Split) ProtoMessage() {}
+
+func (x *Split) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Split.ProtoReflect.Descriptor instead.
+func (*Split) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Split) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Split) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *Split) GetCategory() *CategoryInfo {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *Split) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Split) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type PossibleDuplicate struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Similarity      float64                `protobuf:"fixed64,4,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PossibleDuplicate) Reset() {
+	*x = PossibleDuplicate{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PossibleDuplicate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PossibleDuplicate) ProtoMessage() {}
+
+func (x *PossibleDuplicate) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PossibleDuplicate.ProtoReflect.Descriptor instead.
+func (*PossibleDuplicate) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PossibleDuplicate) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PossibleDuplicate) GetTransactionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return nil
+}
+
+func (x *PossibleDuplicate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PossibleDuplicate) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type TransactionFilter struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CardId          *int64                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3,oneof" json:"card_id,omitempty"`
+	TransactionType *string                `protobuf:"bytes,2,opt,name=transaction_type,json=transactionType,proto3,oneof" json:"transaction_type,omitempty"`
+	CategoryId      *int64                 `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	PayeeId         *int64                 `protobuf:"varint,4,opt,name=payee_id,json=payeeId,proto3,oneof" json:"payee_id,omitempty"`
+	StartDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransactionFilter) Reset() {
+	*x = TransactionFilter{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionFilter) ProtoMessage() {}
+
+func (x *TransactionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionFilter.ProtoReflect.Descriptor instead.
+func (*TransactionFilter) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransactionFilter) GetCardId() int64 {
+	if x != nil && x.CardId != nil {
+		return *x.CardId
+	}
+	return 0
+}
+
+func (x *TransactionFilter) GetTransactionType() string {
+	if x != nil && x.TransactionType != nil {
+		return *x.TransactionType
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *TransactionFilter) GetPayeeId() int64 {
+	if x != nil && x.PayeeId != nil {
+		return *x.PayeeId
+	}
+	return 0
+}
+
+func (x *TransactionFilter) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *TransactionFilter) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type CreateTransactionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CardId     int64                  `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	CategoryId *int64                 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	// Income, Expense or Transfer
+	TransactionType string                 `protobuf:"bytes,3,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"`
+	Amount          int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	TransactionDate *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	Description     string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Tags            []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Splits          []*SplitRequest        `protobuf:"bytes,8,rep,name=splits,proto3" json:"splits,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTransactionRequest) Reset() {
+	*x = CreateTransactionRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionRequest) ProtoMessage() {}
+
+func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTransactionRequest) GetCardId() int64 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetTransactionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return nil
+}
+
+func (x *CreateTransactionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTransactionRequest) GetSplits() []*SplitRequest {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+type SplitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitRequest) Reset() {
+	*x = SplitRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitRequest) ProtoMessage() {}
+
+func (x *SplitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitRequest.ProtoReflect.Descriptor instead.
+func (*SplitRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SplitRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *SplitRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SplitRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *TransactionFilter     `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 1-100, default 20
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTransactionsRequest) GetFilter() *TransactionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListTransactionsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTransactionsResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTransactionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTransactionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteTransactionRequest) Reset() {
+	*x = DeleteTransactionRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTransactionRequest) ProtoMessage() {}
+
+func (x *DeleteTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTransactionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteTransactionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTransactionRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTransactionResponse) Reset() {
+	*x = DeleteTransactionResponse{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTransactionResponse) ProtoMessage() {}
+
+func (x *DeleteTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTransactionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{12}
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetStatsRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type Stats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalIncome   int64                  `protobuf:"varint,1,opt,name=total_income,json=totalIncome,proto3" json:"total_income,omitempty"`
+	TotalExpense  int64                  `protobuf:"varint,2,opt,name=total_expense,json=totalExpense,proto3" json:"total_expense,omitempty"`
+	TotalTransfer int64                  `protobuf:"varint,3,opt,name=total_transfer,json=totalTransfer,proto3" json:"total_transfer,omitempty"`
+	NetIncome     int64                  `protobuf:"varint,4,opt,name=net_income,json=netIncome,proto3" json:"net_income,omitempty"`
+	Count         int64                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Stats) GetTotalIncome() int64 {
+	if x != nil {
+		return x.TotalIncome
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalExpense() int64 {
+	if x != nil {
+		return x.TotalExpense
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalTransfer() int64 {
+	if x != nil {
+		return x.TotalTransfer
+	}
+	return 0
+}
+
+func (x *Stats) GetNetIncome() int64 {
+	if x != nil {
+		return x.NetIncome
+	}
+	return 0
+}
+
+func (x *Stats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ExportTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TransactionFilter     `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTransactionsRequest) Reset() {
+	*x = ExportTransactionsRequest{}
+	mi := &file_pfn_v1_transaction_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTransactionsRequest) ProtoMessage() {}
+
+func (x *ExportTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pfn_v1_transaction_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ExportTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_pfn_v1_transaction_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExportTransactionsRequest) GetFilter() *TransactionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+var File_pfn_v1_transaction_proto protoreflect.FileDescriptor
+
+const file_pfn_v1_transaction_proto_rawDesc = "" +
+	"\n" +
+	"\x18pfn/v1/transaction.proto\x12\x06pfn.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe4\x05\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\acard_id\x18\x03 \x01(\x03R\x06cardId\x12$\n" +
+	"\vcategory_id\x18\x04 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x120\n" +
+	"\bcategory\x18\x05 \x01(\v2\x14.pfn.v1.CategoryInfoR\bcategory\x12\x1e\n" +
+	"\bpayee_id\x18\x06 \x01(\x03H\x01R\apayeeId\x88\x01\x01\x12'\n" +
+	"\x05payee\x18\a \x01(\v2\x11.pfn.v1.PayeeInfoR\x05payee\x12)\n" +
+	"\x10transaction_type\x18\b \x01(\tR\x0ftransactionType\x12\x16\n" +
+	"\x06amount\x18\t \x01(\x03R\x06amount\x12E\n" +
+	"\x10transaction_date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0ftransactionDate\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12%\n" +
+	"\x06splits\x18\r \x03(\v2\r.pfn.v1.SplitR\x06splits\x12\"\n" +
+	"\n" +
+	"created_by\x18\x0e \x01(\tH\x02R\tcreatedBy\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x03R\aversion\x12\x1a\n" +
+	"\bwarnings\x18\x11 \x03(\tR\bwarnings\x12J\n" +
+	"\x13possible_duplicates\x18\x12 \x03(\v2\x19.pfn.v1.PossibleDuplicateR\x12possibleDuplicatesB\x0e\n" +
+	"\f_category_idB\v\n" +
+	"\t_payee_idB\r\n" +
+	"\v_created_by\"F\n" +
+	"\fCategoryInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04icon\x18\x03 \x01(\tR\x04icon\"/\n" +
+	"\tPayeeInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x96\x01\n" +
+	"\x05Split\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\x03R\n" +
+	"categoryId\x120\n" +
+	"\bcategory\x18\x03 \x01(\v2\x14.pfn.v1.CategoryInfoR\bcategory\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"\xac\x01\n" +
+	"\x11PossibleDuplicate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12E\n" +
+	"\x10transaction_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0ftransactionDate\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x04 \x01(\x01R\n" +
+	"similarity\"\xd7\x02\n" +
+	"\x11TransactionFilter\x12\x1c\n" +
+	"\acard_id\x18\x01 \x01(\x03H\x00R\x06cardId\x88\x01\x01\x12.\n" +
+	"\x10transaction_type\x18\x02 \x01(\tH\x01R\x0ftransactionType\x88\x01\x01\x12$\n" +
+	"\vcategory_id\x18\x03 \x01(\x03H\x02R\n" +
+	"categoryId\x88\x01\x01\x12\x1e\n" +
+	"\bpayee_id\x18\x04 \x01(\x03H\x03R\apayeeId\x88\x01\x01\x129\n" +
+	"\n" +
+	"start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendDateB\n" +
+	"\n" +
+	"\b_card_idB\x13\n" +
+	"\x11_transaction_typeB\x0e\n" +
+	"\f_category_idB\v\n" +
+	"\t_payee_id\"\xd7\x02\n" +
+	"\x18CreateTransactionRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\x03R\x06cardId\x12$\n" +
+	"\vcategory_id\x18\x02 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x12)\n" +
+	"\x10transaction_type\x18\x03 \x01(\tR\x0ftransactionType\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12E\n" +
+	"\x10transaction_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0ftransactionDate\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12,\n" +
+	"\x06splits\x18\b \x03(\v2\x14.pfn.v1.SplitRequestR\x06splitsB\x0e\n" +
+	"\f_category_id\"[\n" +
+	"\fSplitRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"z\n" +
+	"\x17ListTransactionsRequest\x121\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.pfn.v1.TransactionFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x97\x01\n" +
+	"\x18ListTransactionsResponse\x127\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x13.pfn.v1.TransactionR\ftransactions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"'\n" +
+	"\x15GetTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"o\n" +
+	"\x18DeleteTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x1b\n" +
+	"\x19DeleteTransactionResponse\"\x83\x01\n" +
+	"\x0fGetStatsRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xab\x01\n" +
+	"\x05Stats\x12!\n" +
+	"\ftotal_income\x18\x01 \x01(\x03R\vtotalIncome\x12#\n" +
+	"\rtotal_expense\x18\x02 \x01(\x03R\ftotalExpense\x12%\n" +
+	"\x0etotal_transfer\x18\x03 \x01(\x03R\rtotalTransfer\x12\x1d\n" +
+	"\n" +
+	"net_income\x18\x04 \x01(\x03R\tnetIncome\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\"N\n" +
+	"\x19ExportTransactionsRequest\x121\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.pfn.v1.TransactionFilterR\x06filter2\xdb\x03\n" +
+	"\x12TransactionService\x12J\n" +
+	"\x11CreateTransaction\x12 .pfn.v1.CreateTransactionRequest\x1a\x13.pfn.v1.Transaction\x12U\n" +
+	"\x10ListTransactions\x12\x1f.pfn.v1.ListTransactionsRequest\x1a .pfn.v1.ListTransactionsResponse\x12D\n" +
+	"\x0eGetTransaction\x12\x1d.pfn.v1.GetTransactionRequest\x1a\x13.pfn.v1.Transaction\x12X\n" +
+	"\x11DeleteTransaction\x12 .pfn.v1.DeleteTransactionRequest\x1a!.pfn.v1.DeleteTransactionResponse\x122\n" +
+	"\bGetStats\x12\x17.pfn.v1.GetStatsRequest\x1a\r.pfn.v1.Stats\x12N\n" +
+	"\x12ExportTransactions\x12!.pfn.v1.ExportTransactionsRequest\x1a\x13.pfn.v1.Transaction0\x01B&Z$pfn-backend/internal/rpc/pfnv1;pfnv1b\x06proto3"
+
+var (
+	file_pfn_v1_transaction_proto_rawDescOnce sync.Once
+	file_pfn_v1_transaction_proto_rawDescData []byte
+)
+
+func file_pfn_v1_transaction_proto_rawDescGZIP() []byte {
+	file_pfn_v1_transaction_proto_rawDescOnce.Do(func() {
+		file_pfn_v1_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pfn_v1_transaction_proto_rawDesc), len(file_pfn_v1_transaction_proto_rawDesc)))
+	})
+	return file_pfn_v1_transaction_proto_rawDescData
+}
+
+var file_pfn_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_pfn_v1_transaction_proto_goTypes = []any{
+	(*Transaction)(nil),               // 0: pfn.v1.Transaction
+	(*CategoryInfo)(nil),              // 1: pfn.v1.CategoryInfo
+	(*PayeeInfo)(nil),                 // 2: pfn.v1.PayeeInfo
+	(*Split)(nil),                     // 3: pfn.v1.Split
+	(*PossibleDuplicate)(nil),         // 4: pfn.v1.PossibleDuplicate
+	(*TransactionFilter)(nil),         // 5: pfn.v1.TransactionFilter
+	(*CreateTransactionRequest)(nil),  // 6: pfn.v1.CreateTransactionRequest
+	(*SplitRequest)(nil),              // 7: pfn.v1.SplitRequest
+	(*ListTransactionsRequest)(nil),   // 8: pfn.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),  // 9: pfn.v1.ListTransactionsResponse
+	(*GetTransactionRequest)(nil),     // 10: pfn.v1.GetTransactionRequest
+	(*DeleteTransactionRequest)(nil),  // 11: pfn.v1.DeleteTransactionRequest
+	(*DeleteTransactionResponse)(nil), // 12: pfn.v1.DeleteTransactionResponse
+	(*GetStatsRequest)(nil),           // 13: pfn.v1.GetStatsRequest
+	(*Stats)(nil),                     // 14: pfn.v1.Stats
+	(*ExportTransactionsRequest)(nil), // 15: pfn.v1.ExportTransactionsRequest
+	(*timestamppb.Timestamp)(nil),     // 16: google.protobuf.Timestamp
+}
+var file_pfn_v1_transaction_proto_depIdxs = []int32{
+	1,  // 0: pfn.v1.Transaction.category:type_name -> pfn.v1.CategoryInfo
+	2,  // 1: pfn.v1.Transaction.payee:type_name -> pfn.v1.PayeeInfo
+	16, // 2: pfn.v1.Transaction.transaction_date:type_name -> google.protobuf.Timestamp
+	3,  // 3: pfn.v1.Transaction.splits:type_name -> pfn.v1.Split
+	16, // 4: pfn.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 5: pfn.v1.Transaction.possible_duplicates:type_name -> pfn.v1.PossibleDuplicate
+	1,  // 6: pfn.v1.Split.category:type_name -> pfn.v1.CategoryInfo
+	16, // 7: pfn.v1.PossibleDuplicate.transaction_date:type_name -> google.protobuf.Timestamp
+	16, // 8: pfn.v1.TransactionFilter.start_date:type_name -> google.protobuf.Timestamp
+	16, // 9: pfn.v1.TransactionFilter.end_date:type_name -> google.protobuf.Timestamp
+	16, // 10: pfn.v1.CreateTransactionRequest.transaction_date:type_name -> google.protobuf.Timestamp
+	7,  // 11: pfn.v1.CreateTransactionRequest.splits:type_name -> pfn.v1.SplitRequest
+	5,  // 12: pfn.v1.ListTransactionsRequest.filter:type_name -> pfn.v1.TransactionFilter
+	0,  // 13: pfn.v1.ListTransactionsResponse.transactions:type_name -> pfn.v1.Transaction
+	16, // 14: pfn.v1.GetStatsRequest.start_date:type_name -> google.protobuf.Timestamp
+	16, // 15: pfn.v1.GetStatsRequest.end_date:type_name -> google.protobuf.Timestamp
+	5,  // 16: pfn.v1.ExportTransactionsRequest.filter:type_name -> pfn.v1.TransactionFilter
+	6,  // 17: pfn.v1.TransactionService.CreateTransaction:input_type -> pfn.v1.CreateTransactionRequest
+	8,  // 18: pfn.v1.TransactionService.ListTransactions:input_type -> pfn.v1.ListTransactionsRequest
+	10, // 19: pfn.v1.TransactionService.GetTransaction:input_type -> pfn.v1.GetTransactionRequest
+	11, // 20: pfn.v1.TransactionService.DeleteTransaction:input_type -> pfn.v1.DeleteTransactionRequest
+	13, // 21: pfn.v1.TransactionService.GetStats:input_type -> pfn.v1.GetStatsRequest
+	15, // 22: pfn.v1.TransactionService.ExportTransactions:input_type -> pfn.v1.ExportTransactionsRequest
+	0,  // 23: pfn.v1.TransactionService.CreateTransaction:output_type -> pfn.v1.Transaction
+	9,  // 24: pfn.v1.TransactionService.ListTransactions:output_type -> pfn.v1.ListTransactionsResponse
+	0,  // 25: pfn.v1.TransactionService.GetTransaction:output_type -> pfn.v1.Transaction
+	12, // 26: pfn.v1.TransactionService.DeleteTransaction:output_type -> pfn.v1.DeleteTransactionResponse
+	14, // 27: pfn.v1.TransactionService.GetStats:output_type -> pfn.v1.Stats
+	0,  // 28: pfn.v1.TransactionService.ExportTransactions:output_type -> pfn.v1.Transaction
+	23, // [23:29] is the sub-list for method output_type
+	17, // [17:23] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_pfn_v1_transaction_proto_init() }
+func file_pfn_v1_transaction_proto_init() {
+	if File_pfn_v1_transaction_proto != nil {
+		return
+	}
+	file_pfn_v1_transaction_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pfn_v1_transaction_proto_msgTypes[5].OneofWrappers = []any{}
+	file_pfn_v1_transaction_proto_msgTypes[6].OneofWrappers = []any{}
+	file_pfn_v1_transaction_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pfn_v1_transaction_proto_rawDesc), len(file_pfn_v1_transaction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pfn_v1_transaction_proto_goTypes,
+		DependencyIndexes: file_pfn_v1_transaction_proto_depIdxs,
+		MessageInfos:      file_pfn_v1_transaction_proto_msgTypes,
+	}.Build()
+	File_pfn_v1_transaction_proto = out.File
+	file_pfn_v1_transaction_proto_goTypes = nil
+	file_pfn_v1_transaction_proto_depIdxs = nil
+}
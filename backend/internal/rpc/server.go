@@ -0,0 +1,84 @@
+// Package rpc serves the gRPC API for internal services. It calls the same
+// services as the REST handlers; the protobuf definitions live in proto/ and
+// the code generated from them in pfnv1.
+package rpc
+
+import (
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/category"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/jwt"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/rpc/pfnv1"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate go run ../../cmd/protogen -root ../..
+
+// NewServer returns a gRPC server with every service registered. Calls other
+// than Login and RefreshToken need an access token in the authorization
+// metadata, as "Bearer <token>".
+func NewServer(
+	jwtManager *jwt.JWTManager,
+	userRepo repository.UserRepository,
+	authService auth.Service,
+	cardService card.Service,
+	txService transaction.Service,
+	categoryService category.Service,
+	logger *logger.Logger,
+) *grpc.Server {
+	// Requests are checked with the REST binding rules, and field errors
+	// name fields the way both APIs spell them
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(apperror.FieldName)
+	}
+
+	i := &interceptors{jwtManager: jwtManager, userRepo: userRepo, logger: logger}
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(i.unary),
+		grpc.StreamInterceptor(i.stream),
+	)
+	pfnv1.RegisterAuthServiceServer(server, &authServer{authService: authService})
+	pfnv1.RegisterCardServiceServer(server, &cardServer{cardService: cardService})
+	pfnv1.RegisterTransactionServiceServer(server, &transactionServer{txService: txService})
+	pfnv1.RegisterCategoryServiceServer(server, &categoryServer{categoryService: categoryService})
+	return server
+}
+
+// validate applies the binding tags of a service request
+func validate(req interface{}) error {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return apperror.FromBinding(err)
+	}
+	return nil
+}
+
+// optionalTime converts a timestamp that may be unset
+func optionalTime(field string, ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, apperror.Validation("request validation failed").WithField(field, "must be a valid timestamp")
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+// optionalUUID formats an ID that may be unset
+func optionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
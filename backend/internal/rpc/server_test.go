@@ -0,0 +1,279 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"pfn-backend/internal/app/entity"
+	"pfn-backend/internal/app/repository"
+	"pfn-backend/internal/app/service/apikey"
+	"pfn-backend/internal/app/service/auth"
+	"pfn-backend/internal/app/service/card"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/pkg/apperror"
+	"pfn-backend/internal/pkg/jwt"
+	"pfn-backend/internal/pkg/logger"
+	"pfn-backend/internal/rpc"
+	"pfn-backend/internal/rpc/pfnv1"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeAuth struct {
+	auth.Service
+	logins []auth.LoginRequest
+}
+
+func (f *fakeAuth) Login(ctx context.Context, req auth.LoginRequest) (*auth.AuthResponse, error) {
+	f.logins = append(f.logins, req)
+	return &auth.AuthResponse{
+		User:        auth.UserData{ID: uuid.New(), Email: req.Email},
+		AccessToken: "access",
+	}, nil
+}
+
+type fakeCards struct {
+	card.Service
+	callers []uuid.UUID
+}
+
+func (f *fakeCards) GetUserCards(ctx context.Context, userID uuid.UUID, filter card.CardListFilter) ([]card.CardResponse, error) {
+	f.callers = append(f.callers, userID)
+	return []card.CardResponse{{ID: 7, UserID: userID, AccountType: "Card", Balance: 5000000000}}, nil
+}
+
+type fakeTransactions struct {
+	transaction.Service
+	total   int
+	filters []transaction.TransactionFilter
+	err     error
+}
+
+// GetUserTransactions serves pages of a listing with total transactions
+func (f *fakeTransactions) GetUserTransactions(ctx context.Context, userID uuid.UUID, filter transaction.TransactionFilter) (*transaction.TransactionListResponse, error) {
+	f.filters = append(f.filters, filter)
+	page := &transaction.TransactionListResponse{Total: int64(f.total), Limit: filter.Limit, Offset: filter.Offset}
+	for id := filter.Offset; id < f.total && id < filter.Offset+filter.Limit; id++ {
+		page.Transactions = append(page.Transactions, transaction.TransactionResponse{ID: int64(id + 1), UserID: userID})
+	}
+	return page, nil
+}
+
+func (f *fakeTransactions) GetTransaction(ctx context.Context, id int64, userID uuid.UUID) (*transaction.TransactionResponse, error) {
+	return nil, f.err
+}
+
+// accounts is a UserRepository in which every user is active unless listed
+// as deactivated, and signed in unless listed as signed out
+type accounts struct {
+	repository.UserRepository
+	deactivated map[uuid.UUID]bool
+	signedOut   map[uuid.UUID]time.Time
+}
+
+func (r *accounts) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user := &entity.User{ID: id, Role: entity.RoleUser, IsActive: !r.deactivated[id]}
+	if at, ok := r.signedOut[id]; ok {
+		user.SessionsValidAfter = &at
+	}
+	return user, nil
+}
+
+type fixture struct {
+	users        *accounts
+	auth         *fakeAuth
+	cards        *fakeCards
+	transactions *fakeTransactions
+	jwtManager   *jwt.JWTManager
+	conn         *grpc.ClientConn
+}
+
+func newFixture(t *testing.T) *fixture {
+	log, err := logger.New(logger.Config{Level: "error", Format: "json", Output: "stdout"})
+	require.NoError(t, err)
+
+	f := &fixture{
+		users:        &accounts{deactivated: make(map[uuid.UUID]bool), signedOut: make(map[uuid.UUID]time.Time)},
+		auth:         &fakeAuth{},
+		cards:        &fakeCards{},
+		transactions: &fakeTransactions{},
+		jwtManager:   jwt.NewJWTManager("access-secret-access-secret-0123", "refresh-secret-refresh-secret-01", "test", time.Minute, time.Hour, time.Hour),
+	}
+	server := rpc.NewServer(f.jwtManager, f.users, f.auth, f.cards, f.transactions, nil, log)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	f.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.conn.Close() })
+	return f
+}
+
+// as returns a context carrying an access token for userID
+func (f *fixture) as(t *testing.T, userID uuid.UUID) context.Context {
+	token, err := f.jwtManager.GenerateAccessToken(userID, "ann@example.com", "user")
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// reason returns the error code sent in the status's ErrorInfo
+func reason(t *testing.T, err error) string {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestServer_RequiresAccessToken(t *testing.T) {
+	f := newFixture(t)
+	client := pfnv1.NewCardServiceClient(f.conn)
+
+	_, err := client.ListCards(context.Background(), &pfnv1.ListCardsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "unauthorized", reason(t, err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer not-a-token")
+	_, err = client.ListCards(ctx, &pfnv1.ListCardsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+apikey.KeyPrefix+"0123456789")
+	_, err = client.ListCards(ctx, &pfnv1.ListCardsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	assert.Empty(t, f.cards.callers)
+}
+
+func TestServer_RefusesDeactivatedUsers(t *testing.T) {
+	f := newFixture(t)
+	caller := uuid.New()
+	ctx := f.as(t, caller)
+	f.users.deactivated[caller] = true
+
+	_, err := pfnv1.NewCardServiceClient(f.conn).ListCards(ctx, &pfnv1.ListCardsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Empty(t, f.cards.callers)
+}
+
+func TestServer_RefusesSignedOutSessions(t *testing.T) {
+	f := newFixture(t)
+	caller := uuid.New()
+	ctx := f.as(t, caller)
+	f.users.signedOut[caller] = time.Now().Add(time.Second)
+
+	_, err := pfnv1.NewCardServiceClient(f.conn).ListCards(ctx, &pfnv1.ListCardsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Empty(t, f.cards.callers)
+}
+
+func TestServer_CallsServicesAsTheCaller(t *testing.T) {
+	f := newFixture(t)
+	caller := uuid.New()
+
+	var header metadata.MD
+	resp, err := pfnv1.NewCardServiceClient(f.conn).ListCards(f.as(t, caller), &pfnv1.ListCardsRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Cards, 1)
+	assert.Equal(t, int64(5000000000), resp.Cards[0].Balance)
+	assert.Equal(t, []uuid.UUID{caller}, f.cards.callers)
+	assert.NotEmpty(t, header.Get(rpc.RequestIDHeader))
+}
+
+func TestServer_LoginIsPublic(t *testing.T) {
+	f := newFixture(t)
+
+	resp, err := pfnv1.NewAuthServiceClient(f.conn).Login(context.Background(), &pfnv1.LoginRequest{Email: "ann@example.com", Password: "secret"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "access", resp.AccessToken)
+	require.Len(t, f.auth.logins, 1)
+	assert.Contains(t, f.auth.logins[0].UserAgent, "grpc-go")
+}
+
+func TestServer_RendersErrors(t *testing.T) {
+	f := newFixture(t)
+	ctx := f.as(t, uuid.New())
+	client := pfnv1.NewTransactionServiceClient(f.conn)
+
+	// Requests are checked with the REST binding rules
+	_, err := client.CreateTransaction(ctx, &pfnv1.CreateTransactionRequest{CardId: 7, TransactionType: "Expense"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "validation_error", reason(t, err))
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"amount", "transaction_date"}, fields)
+
+	f.transactions.err = apperror.NotFound("transaction not found")
+	_, err = client.GetTransaction(ctx, &pfnv1.GetTransactionRequest{Id: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "transaction not found", status.Convert(err).Message())
+
+	f.transactions.err = apperror.Stale("transaction has changed")
+	_, err = client.GetTransaction(ctx, &pfnv1.GetTransactionRequest{Id: 1})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	// Internal details are never sent
+	f.transactions.err = errors.New("connection refused")
+	_, err = client.GetTransaction(ctx, &pfnv1.GetTransactionRequest{Id: 1})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+}
+
+func TestServer_ExportStreamsEveryPage(t *testing.T) {
+	f := newFixture(t)
+	f.transactions.total = 250
+	cardID := int64(7)
+
+	stream, err := pfnv1.NewTransactionServiceClient(f.conn).ExportTransactions(f.as(t, uuid.New()), &pfnv1.ExportTransactionsRequest{
+		Filter: &pfnv1.TransactionFilter{CardId: &cardID},
+	})
+	require.NoError(t, err)
+
+	var ids []int64
+	for {
+		tx, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, tx.Id)
+	}
+
+	require.Len(t, ids, 250)
+	assert.Equal(t, int64(1), ids[0])
+	assert.Equal(t, int64(250), ids[249])
+	require.Len(t, f.transactions.filters, 3)
+	for i, filter := range f.transactions.filters {
+		assert.Equal(t, i*100, filter.Offset)
+		assert.Equal(t, &cardID, filter.CardID)
+	}
+}
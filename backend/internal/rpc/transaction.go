@@ -0,0 +1,219 @@
+package rpc
+
+import (
+	"context"
+	"pfn-backend/internal/app/service/transaction"
+	"pfn-backend/internal/rpc/pfnv1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportPageSize is how many transactions an export reads at a time
+const exportPageSize = 100
+
+type transactionServer struct {
+	pfnv1.UnimplementedTransactionServiceServer
+	txService transaction.Service
+}
+
+func (s *transactionServer) CreateTransaction(ctx context.Context, req *pfnv1.CreateTransactionRequest) (*pfnv1.Transaction, error) {
+	create := transaction.CreateTransactionRequest{
+		CardID:          req.CardId,
+		CategoryID:      req.CategoryId,
+		TransactionType: req.TransactionType,
+		Amount:          req.Amount,
+		Description:     req.Description,
+		Tags:            req.Tags,
+	}
+	date, err := optionalTime("transaction_date", req.TransactionDate)
+	if err != nil {
+		return nil, err
+	}
+	if date != nil {
+		create.TransactionDate = *date
+	}
+	for _, split := range req.Splits {
+		create.Splits = append(create.Splits, transaction.SplitRequest{
+			CategoryID: split.CategoryId,
+			Amount:     split.Amount,
+			Note:       split.Note,
+		})
+	}
+	if err := validate(&create); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.txService.CreateTransaction(ctx, userIDFrom(ctx), create)
+	if err != nil {
+		return nil, err
+	}
+	return toTransaction(tx), nil
+}
+
+func (s *transactionServer) ListTransactions(ctx context.Context, req *pfnv1.ListTransactionsRequest) (*pfnv1.ListTransactionsResponse, error) {
+	filter, err := transactionFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit = int(req.Limit)
+	filter.Offset = int(req.Offset)
+	if err := validate(&filter); err != nil {
+		return nil, err
+	}
+
+	page, err := s.txService.GetUserTransactions(ctx, userIDFrom(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pfnv1.ListTransactionsResponse{
+		Transactions: make([]*pfnv1.Transaction, len(page.Transactions)),
+		Total:        page.Total,
+		Limit:        int32(page.Limit),
+		Offset:       int32(page.Offset),
+	}
+	for i := range page.Transactions {
+		resp.Transactions[i] = toTransaction(&page.Transactions[i])
+	}
+	return resp, nil
+}
+
+func (s *transactionServer) GetTransaction(ctx context.Context, req *pfnv1.GetTransactionRequest) (*pfnv1.Transaction, error) {
+	tx, err := s.txService.GetTransaction(ctx, req.Id, userIDFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toTransaction(tx), nil
+}
+
+func (s *transactionServer) DeleteTransaction(ctx context.Context, req *pfnv1.DeleteTransactionRequest) (*pfnv1.DeleteTransactionResponse, error) {
+	if err := s.txService.DeleteTransaction(ctx, req.Id, userIDFrom(ctx), req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	return &pfnv1.DeleteTransactionResponse{}, nil
+}
+
+func (s *transactionServer) GetStats(ctx context.Context, req *pfnv1.GetStatsRequest) (*pfnv1.Stats, error) {
+	startDate, err := optionalTime("start_date", req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := optionalTime("end_date", req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.txService.GetStats(ctx, userIDFrom(ctx), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return &pfnv1.Stats{
+		TotalIncome:   stats.TotalIncome,
+		TotalExpense:  stats.TotalExpense,
+		TotalTransfer: stats.TotalTransfer,
+		NetIncome:     stats.NetIncome,
+		Count:         stats.Count,
+	}, nil
+}
+
+// ExportTransactions pages through the listing and streams every
+// transaction, so exports of any size hold one page in memory
+func (s *transactionServer) ExportTransactions(req *pfnv1.ExportTransactionsRequest, stream pfnv1.TransactionService_ExportTransactionsServer) error {
+	ctx := stream.Context()
+	filter, err := transactionFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+	filter.Limit = exportPageSize
+	if err := validate(&filter); err != nil {
+		return err
+	}
+
+	for {
+		page, err := s.txService.GetUserTransactions(ctx, userIDFrom(ctx), filter)
+		if err != nil {
+			return err
+		}
+		for i := range page.Transactions {
+			if err := stream.Send(toTransaction(&page.Transactions[i])); err != nil {
+				return err
+			}
+		}
+
+		filter.Offset += len(page.Transactions)
+		if len(page.Transactions) < exportPageSize || int64(filter.Offset) >= page.Total {
+			return nil
+		}
+	}
+}
+
+func transactionFilter(f *pfnv1.TransactionFilter) (transaction.TransactionFilter, error) {
+	if f == nil {
+		return transaction.TransactionFilter{}, nil
+	}
+
+	startDate, err := optionalTime("start_date", f.StartDate)
+	if err != nil {
+		return transaction.TransactionFilter{}, err
+	}
+	endDate, err := optionalTime("end_date", f.EndDate)
+	if err != nil {
+		return transaction.TransactionFilter{}, err
+	}
+	return transaction.TransactionFilter{
+		CardID:          f.CardId,
+		TransactionType: f.TransactionType,
+		CategoryID:      f.CategoryId,
+		PayeeID:         f.PayeeId,
+		StartDate:       startDate,
+		EndDate:         endDate,
+	}, nil
+}
+
+func toTransaction(tx *transaction.TransactionResponse) *pfnv1.Transaction {
+	resp := &pfnv1.Transaction{
+		Id:              tx.ID,
+		UserId:          tx.UserID.String(),
+		CardId:          tx.CardID,
+		CategoryId:      tx.CategoryID,
+		Category:        toCategoryInfo(tx.Category),
+		PayeeId:         tx.PayeeID,
+		TransactionType: tx.TransactionType,
+		Amount:          tx.Amount,
+		TransactionDate: timestamppb.New(tx.TransactionDate),
+		Description:     tx.Description,
+		Tags:            tx.Tags,
+		CreatedBy:       optionalUUID(tx.CreatedBy),
+		CreatedAt:       timestamppb.New(tx.CreatedAt),
+		Version:         tx.Version,
+		Warnings:        tx.Warnings,
+	}
+	if tx.Payee != nil {
+		resp.Payee = &pfnv1.PayeeInfo{Id: tx.Payee.ID, Name: tx.Payee.Name}
+	}
+	for _, split := range tx.Splits {
+		resp.Splits = append(resp.Splits, &pfnv1.Split{
+			Id:         split.ID,
+			CategoryId: split.CategoryID,
+			Category:   toCategoryInfo(split.Category),
+			Amount:     split.Amount,
+			Note:       split.Note,
+		})
+	}
+	for _, duplicate := range tx.PossibleDuplicates {
+		resp.PossibleDuplicates = append(resp.PossibleDuplicates, &pfnv1.PossibleDuplicate{
+			Id:              duplicate.ID,
+			TransactionDate: timestamppb.New(duplicate.TransactionDate),
+			Description:     duplicate.Description,
+			Similarity:      duplicate.Similarity,
+		})
+	}
+	return resp
+}
+
+func toCategoryInfo(c *transaction.CategoryInfo) *pfnv1.CategoryInfo {
+	if c == nil {
+		return nil
+	}
+	return &pfnv1.CategoryInfo{Id: c.ID, Name: c.Name, Icon: c.Icon}
+}